@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"gosocial/models"
+	"strconv"
+)
+
+// GetModerationCasesHandler 审核队列
+// @Summary 审核队列
+// @Description 管理员按最近举报时间倒序获取审核工单(每次20条)，可按状态筛选
+// @Tags 管理
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "工单状态：pending/reviewing/resolved/dismissed，不传则返回全部"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamModerationCase}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 403 {object} models.Response "无权限"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/cases [get]
+func GetModerationCasesHandler(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", models.CaseStatusPending, models.CaseStatusReviewing, models.CaseStatusResolved, models.CaseStatusDismissed:
+	default:
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	cases, err := logic.GetModerationCases(status, offset)
+	if err != nil {
+		zap.L().Error("logic.GetModerationCases failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, cases)
+}
+
+// GetModerationCaseHandler 审核工单详情
+// @Summary 审核工单详情
+// @Description 获取工单的举报记录、举报时的内容快照、被举报内容的当前状态(含已删除到回收站的动态)、作者信息与此前受到的处置
+// @Tags 管理
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "工单ID"
+// @Success 200 {object} models.Response{data=models.ParamModerationCaseDetail}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 403 {object} models.Response "无权限"
+// @Failure 404 {object} models.Response "审核工单不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/cases/{id} [get]
+func GetModerationCaseHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	caseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	detail, err := logic.GetModerationCase(userID, caseID)
+	if err != nil {
+		zap.L().Error("logic.GetModerationCase failed", zap.Int64("case_id", caseID), zap.Error(err))
+		handleModerationError(c, err)
+		return
+	}
+	ResponseSuccess(c, detail)
+}
+
+// ClaimModerationCaseHandler 认领审核工单
+// @Summary 认领审核工单
+// @Description 认领待处理的工单，认领后其他管理员不能处理；重复认领自己的工单视为成功
+// @Tags 管理
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "工单ID"
+// @Success 200 {object} models.Response
+// @Failure 400 {object} models.Response "参数错误/工单已结案/已被其他管理员认领"
+// @Failure 403 {object} models.Response "无权限"
+// @Failure 404 {object} models.Response "审核工单不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/cases/{id}/claim [put]
+func ClaimModerationCaseHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	caseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.ClaimModerationCase(userID, caseID); err != nil {
+		zap.L().Error("logic.ClaimModerationCase failed", zap.Int64("case_id", caseID), zap.Error(err))
+		handleModerationError(c, err)
+		return
+	}
+	ResponseSuccess(c, "认领成功")
+}
+
+// DecideModerationCaseHandler 处理审核工单
+// @Summary 处理审核工单
+// @Description 对被举报内容执行隐藏(仅动态)或删除，对作者执行警告或封禁，并结案；内容与账号均不处置时驳回举报。未认领的工单自动认领，每项操作写入审核日志并通知作者
+// @Tags 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "工单ID"
+// @Param data body models.ParamModerationDecisionRequest true "处置方式"
+// @Success 200 {object} models.Response
+// @Failure 400 {object} models.Response "参数错误/处置方式无效/工单已结案/已被其他管理员认领"
+// @Failure 403 {object} models.Response "无权限"
+// @Failure 404 {object} models.Response "审核工单不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/cases/{id}/decision [post]
+func DecideModerationCaseHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	caseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 参数校验
+	var req models.ParamModerationDecisionRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("DecideModerationCase with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.DecideModerationCase(userID, caseID, &req); err != nil {
+		zap.L().Error("logic.DecideModerationCase failed", zap.Int64("case_id", caseID), zap.Error(err))
+		handleModerationError(c, err)
+		return
+	}
+	ResponseSuccess(c, "处理成功")
+}
+
+// GetModerationLogsHandler 审核日志
+// @Summary 审核日志
+// @Description 按时间倒序获取管理员的操作记录(每次20条)，可按操作人或被处置用户筛选
+// @Tags 管理
+// @Produce json
+// @Security ApiKeyAuth
+// @Param moderator_id query string false "操作的管理员ID"
+// @Param target_user_id query string false "被处置用户ID"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamModerationLog}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 403 {object} models.Response "无权限"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /admin/audit-logs [get]
+func GetModerationLogsHandler(c *gin.Context) {
+	moderatorID, err := strconv.ParseInt(c.DefaultQuery("moderator_id", "0"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	targetUserID, err := strconv.ParseInt(c.DefaultQuery("target_user_id", "0"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	logs, err := logic.GetModerationLogs(moderatorID, targetUserID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetModerationLogs failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, logs)
+}
+
+// handleModerationError 将审核相关错误转换为响应码
+func handleModerationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, mysql.ErrorCaseNotExist):
+		ResponseError(c, CodeCaseNotExist)
+	case errors.Is(err, mysql.ErrorCaseClosed):
+		ResponseError(c, CodeCaseClosed)
+	case errors.Is(err, mysql.ErrorCaseClaimed):
+		ResponseError(c, CodeCaseClaimed)
+	case errors.Is(err, mysql.ErrorInvalidModeration):
+		ResponseError(c, CodeInvalidModeration)
+	case errors.Is(err, mysql.ErrorMessageNotExist):
+		ResponseError(c, CodeMessageNotExist)
+	default:
+		ResponseError(c, CodeServerBusy)
+	}
+}
@@ -0,0 +1,506 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"gosocial/models"
+	"strconv"
+	"unicode/utf8"
+)
+
+const maxPhotoCaptionLen = 255 // 照片说明最大字数
+
+// CreateAlbumHandler 创建相册
+// @Summary 创建相册
+// @Description 创建相册，可见范围为public(所有人)、friends(仅好友，默认)或private(仅自己)
+// @Tags 相册
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body models.ParamCreateAlbumRequest true "相册信息"
+// @Success 200 {object} models.Response{data=models.ParamAlbumItem}
+// @Failure 400 {object} models.Response "参数错误/内容包含违规词语"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /albums [post]
+func CreateAlbumHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 参数校验
+	var req models.ParamCreateAlbumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("CreateAlbum with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	if req.Visibility == "" {
+		req.Visibility = models.PostVisibilityFriends
+	}
+	if !models.IsValidAlbumVisibility(req.Visibility) {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	// 敏感词过滤
+	if !filterText(c, userID, models.ContentSceneAlbum, &req.Name, &req.Description) {
+		return
+	}
+
+	album, err := logic.CreateAlbum(userID, req.Name, req.Description, req.Visibility)
+	if err != nil {
+		zap.L().Error("logic.CreateAlbum failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, album)
+}
+
+// GetAlbumsHandler 相册列表
+// @Summary 相册列表
+// @Description 获取指定用户的相册列表，第一项为自动生成的动态相册(id为0，包含发布动态时上传的图片)，其余相册只返回当前用户可见的
+// @Tags 相册
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string false "用户ID，不传则为自己"
+// @Success 200 {object} models.Response{data=[]models.ParamAlbumItem}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 403 {object} models.Response "存在拉黑关系"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /albums [get]
+func GetAlbumsHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	ownerID, ok := queryOwnerID(c, userID)
+	if !ok {
+		return
+	}
+
+	albums, err := logic.GetAlbums(userID, ownerID)
+	if err != nil {
+		zap.L().Error("logic.GetAlbums failed", zap.Int64("owner_id", ownerID), zap.Error(err))
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, albums)
+}
+
+// GetAlbumHandler 相册详情
+// @Summary 相册详情
+// @Description 获取相册信息、封面与照片数，不可见的相册视为不存在
+// @Tags 相册
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "相册ID"
+// @Success 200 {object} models.Response{data=models.ParamAlbumItem}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "相册不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /albums/{id} [get]
+func GetAlbumHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	albumID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	album, err := logic.GetAlbum(userID, albumID)
+	if err != nil {
+		zap.L().Error("logic.GetAlbum failed", zap.Int64("album_id", albumID), zap.Error(err))
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, album)
+}
+
+// UpdateAlbumHandler 修改相册
+// @Summary 修改相册
+// @Description 修改自己相册的名称、描述、可见范围或封面，未传字段保持不变；封面须为该相册中的照片，cover_photo_id为"0"时使用最新上传的照片
+// @Tags 相册
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "相册ID"
+// @Param data body models.ParamUpdateAlbumRequest true "相册信息"
+// @Success 200 {object} models.Response "修改成功"
+// @Failure 400 {object} models.Response "参数错误/内容包含违规词语"
+// @Failure 404 {object} models.Response "相册或封面照片不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /albums/{id} [put]
+func UpdateAlbumHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	albumID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 参数校验
+	var req models.ParamUpdateAlbumRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("UpdateAlbum with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	if req.Visibility != nil && !models.IsValidAlbumVisibility(*req.Visibility) {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	var coverPhotoID *int64
+	if req.CoverPhotoID != nil {
+		id, err := strconv.ParseInt(*req.CoverPhotoID, 10, 64)
+		if err != nil {
+			ResponseError(c, CodeInvalidParam)
+			return
+		}
+		coverPhotoID = &id
+	}
+	// 敏感词过滤
+	if req.Name != nil && !filterText(c, userID, models.ContentSceneAlbum, req.Name) {
+		return
+	}
+	if req.Description != nil && !filterText(c, userID, models.ContentSceneAlbum, req.Description) {
+		return
+	}
+
+	if err = logic.UpdateAlbum(userID, albumID, req.Name, req.Description, req.Visibility, coverPhotoID); err != nil {
+		zap.L().Error("logic.UpdateAlbum failed", zap.Int64("album_id", albumID), zap.Error(err))
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, "修改成功")
+}
+
+// DeleteAlbumHandler 删除相册
+// @Summary 删除相册
+// @Description 删除自己的相册及其中的全部照片，删除后不可恢复
+// @Tags 相册
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "相册ID"
+// @Success 200 {object} models.Response "删除成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "相册不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /albums/{id} [delete]
+func DeleteAlbumHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	albumID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.DeleteAlbum(userID, albumID); err != nil {
+		zap.L().Error("logic.DeleteAlbum failed", zap.Int64("album_id", albumID), zap.Error(err))
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, "删除成功")
+}
+
+// UploadPhotosHandler 上传照片
+// @Summary 上传照片
+// @Description 上传照片到自己的相册(每次最多9张，单张不超过10MB)，按文件内容校验真实格式并去除元数据，同时生成中图与缩略图
+// @Tags 相册
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "相册ID"
+// @Param photos formData file true "照片(可多张)"
+// @Param caption formData string false "照片说明(应用于本次上传的全部照片，最多255字)"
+// @Success 200 {object} models.Response{data=[]models.ParamPhotoItem}
+// @Failure 400 {object} models.Response "参数错误/图片格式不正确/最多上传9张图片"
+// @Failure 404 {object} models.Response "相册不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /albums/{id}/photos [post]
+func UploadPhotosHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	albumID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	caption := c.PostForm("caption")
+	if utf8.RuneCountInString(caption) > maxPhotoCaptionLen {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	// 敏感词过滤
+	if !filterText(c, userID, models.ContentSceneAlbum, &caption) {
+		return
+	}
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["photos"]) == 0 {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	imageData, ok := readPostImageFiles(c, form.File["photos"])
+	if !ok {
+		return
+	}
+
+	photos, err := logic.UploadPhotos(userID, albumID, caption, imageData)
+	if err != nil {
+		zap.L().Error("logic.UploadPhotos failed", zap.Int64("album_id", albumID), zap.Error(err))
+		if isInvalidImage(err) {
+			ResponseError(c, CodeInvalidImageFormat)
+			return
+		}
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, photos)
+}
+
+// GetAlbumPhotosHandler 相册照片列表
+// @Summary 相册照片列表
+// @Description 按上传时间倒序获取相册中的照片(每次20张)，不可见的相册视为不存在
+// @Tags 相册
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "相册ID"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamPhotoItem}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "相册不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /albums/{id}/photos [get]
+func GetAlbumPhotosHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	albumID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	photos, err := logic.GetAlbumPhotos(userID, albumID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetAlbumPhotos failed", zap.Int64("album_id", albumID), zap.Error(err))
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, photos)
+}
+
+// GetPostPhotosHandler 动态相册照片列表
+// @Summary 动态相册照片列表
+// @Description 获取自动生成的动态相册中的图片(每次20张)，按动态发布时间倒序排列，只包含当前用户可见的、未删除的动态中的图片；post_id为图片所属动态
+// @Tags 相册
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string false "用户ID，不传则为自己"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamPhotoItem}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 403 {object} models.Response "存在拉黑关系"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /albums/post/photos [get]
+func GetPostPhotosHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	ownerID, ok := queryOwnerID(c, userID)
+	if !ok {
+		return
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	photos, err := logic.GetPostPhotos(userID, ownerID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetPostPhotos failed", zap.Int64("owner_id", ownerID), zap.Error(err))
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, photos)
+}
+
+// GetPhotoHandler 照片详情
+// @Summary 照片详情
+// @Description 查看相册照片，他人查看时浏览量加一(同一用户24小时内重复查看只计一次)；所属相册不可见时视为照片不存在
+// @Tags 相册
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "照片ID"
+// @Success 200 {object} models.Response{data=models.ParamPhotoItem}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "照片不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /photos/{id} [get]
+func GetPhotoHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	photo, err := logic.GetPhoto(userID, photoID)
+	if err != nil {
+		zap.L().Error("logic.GetPhoto failed", zap.Int64("photo_id", photoID), zap.Error(err))
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, photo)
+}
+
+// UpdatePhotoCaptionHandler 修改照片说明
+// @Summary 修改照片说明
+// @Description 修改自己照片的说明，传空字符串清除说明
+// @Tags 相册
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "照片ID"
+// @Param data body models.ParamPhotoCaptionRequest true "照片说明"
+// @Success 200 {object} models.Response "修改成功"
+// @Failure 400 {object} models.Response "参数错误/内容包含违规词语"
+// @Failure 404 {object} models.Response "照片不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /photos/{id} [put]
+func UpdatePhotoCaptionHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 参数校验
+	var req models.ParamPhotoCaptionRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("UpdatePhotoCaption with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	// 敏感词过滤
+	if !filterText(c, userID, models.ContentSceneAlbum, &req.Caption) {
+		return
+	}
+
+	if err = logic.UpdatePhotoCaption(userID, photoID, req.Caption); err != nil {
+		zap.L().Error("logic.UpdatePhotoCaption failed", zap.Int64("photo_id", photoID), zap.Error(err))
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, "修改成功")
+}
+
+// MovePhotosHandler 移动照片
+// @Summary 移动照片
+// @Description 将自己的照片批量移动到自己的另一个相册(每次最多100张)，他人的照片会被忽略；原相册以被移走的照片为封面时恢复为使用最新上传的照片
+// @Tags 相册
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body models.ParamMovePhotosRequest true "照片与目标相册"
+// @Success 200 {object} models.Response "移动成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "相册或照片不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /photos/move [put]
+func MovePhotosHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 参数校验
+	var req models.ParamMovePhotosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("MovePhotos with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	photoIDs, err := parseIDs(req.PhotoIDs)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	albumID, err := strconv.ParseInt(req.AlbumID, 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.MovePhotos(userID, photoIDs, albumID); err != nil {
+		zap.L().Error("logic.MovePhotos failed", zap.Int64("album_id", albumID), zap.Error(err))
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, "移动成功")
+}
+
+// DeletePhotoHandler 删除照片
+// @Summary 删除照片
+// @Description 删除自己的照片，删除后不可恢复；动态相册中的图片随动态删除
+// @Tags 相册
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "照片ID"
+// @Success 200 {object} models.Response "删除成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "照片不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /photos/{id} [delete]
+func DeletePhotoHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	photoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.DeletePhoto(userID, photoID); err != nil {
+		zap.L().Error("logic.DeletePhoto failed", zap.Int64("photo_id", photoID), zap.Error(err))
+		handleAlbumError(c, err)
+		return
+	}
+	ResponseSuccess(c, "删除成功")
+}
+
+// queryOwnerID 解析查询参数中的相册主人ID，未传时为当前用户
+func queryOwnerID(c *gin.Context, userID int64) (int64, bool) {
+	s, ok := c.GetQuery("user_id")
+	if !ok {
+		return userID, true
+	}
+	ownerID, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return 0, false
+	}
+	return ownerID, true
+}
+
+// handleAlbumError 将相册相关错误转换为响应码
+func handleAlbumError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, mysql.ErrorAlbumNotExist):
+		ResponseError(c, CodeAlbumNotExist)
+	case errors.Is(err, mysql.ErrorPhotoNotExist):
+		ResponseError(c, CodePhotoNotExist)
+	case errors.Is(err, mysql.ErrorBlocked):
+		ResponseError(c, CodeBlocked)
+	default:
+		ResponseError(c, CodeServerBusy)
+	}
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"strconv"
+)
+
+// BlockUserHandler 拉黑用户
+// @Summary 拉黑用户
+// @Description 将指定用户加入黑名单，拉黑后双方无法互相搜索和添加好友
+// @Tags 黑名单
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path int true "用户ID"
+// @Success 200 {object} models.Response "拉黑成功"
+// @Failure 400 {object} models.Response "参数格式错误/不能拉黑自己"
+// @Failure 404 {object} models.Response "用户不存在"
+// @Failure 409 {object} models.Response "已将该用户拉黑"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /blocks/{uid} [post]
+func BlockUserHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取参数
+	blockedID, _ := strconv.ParseInt(c.Param("uid"), 10, 64)
+	if blockedID == 0 {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err := logic.BlockUser(userID, blockedID); err != nil {
+		zap.L().Error("logic.BlockUser failed", zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorCannotBlockSelf):
+			ResponseError(c, CodeCannotBlockSelf)
+		case errors.Is(err, mysql.ErrorUserNotExist):
+			ResponseError(c, CodeUserNotExist)
+		case errors.Is(err, mysql.ErrorIsBlocked):
+			ResponseError(c, CodeIsBlocked)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
+		return
+	}
+	ResponseSuccess(c, "拉黑成功")
+}
+
+// UnblockUserHandler 取消拉黑
+// @Summary 取消拉黑
+// @Description 将指定用户移出黑名单
+// @Tags 黑名单
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path int true "用户ID"
+// @Success 200 {object} models.Response "取消拉黑成功"
+// @Failure 400 {object} models.Response "参数格式错误"
+// @Failure 404 {object} models.Response "该用户不在黑名单中"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /blocks/{uid} [delete]
+func UnblockUserHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取参数
+	blockedID, _ := strconv.ParseInt(c.Param("uid"), 10, 64)
+	if blockedID == 0 {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err := logic.UnblockUser(userID, blockedID); err != nil {
+		zap.L().Error("logic.UnblockUser failed", zap.Error(err))
+		if errors.Is(err, mysql.ErrorIsNotBlocked) {
+			ResponseError(c, CodeIsNotBlocked)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "取消拉黑成功")
+}
+
+// GetBlockListHandler 获取黑名单列表
+// @Summary 获取黑名单列表
+// @Description 按拉黑时间倒序获取黑名单
+// @Tags 黑名单
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=[]models.ParamBlockItem}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /blocks [get]
+func GetBlockListHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+
+	blocks, err := logic.GetBlockList(userID)
+	if err != nil {
+		zap.L().Error("logic.GetBlockList failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, blocks)
+}
@@ -0,0 +1,311 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"gosocial/models"
+	"strconv"
+)
+
+// BookmarkPostHandler 收藏动态
+// @Summary 收藏动态
+// @Description 收藏自己可见的动态，可指定收藏夹(默认未分类)；重复收藏不会改变所属收藏夹
+// @Tags 收藏
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Param data body models.ParamBookmarkRequest false "收藏夹"
+// @Success 200 {object} models.Response "收藏成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "动态或收藏夹不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/bookmark [post]
+func BookmarkPostHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	collectionID, ok := bindCollectionID(c)
+	if !ok {
+		return
+	}
+
+	if err = logic.BookmarkPost(userID, postID, collectionID); err != nil {
+		zap.L().Error("logic.BookmarkPost failed", zap.Int64("post_id", postID), zap.Error(err))
+		handleBookmarkError(c, err)
+		return
+	}
+	ResponseSuccess(c, "收藏成功")
+}
+
+// UnbookmarkPostHandler 取消收藏
+// @Summary 取消收藏
+// @Description 取消收藏指定动态，动态已删除或已不可见时同样可以取消；未收藏时同样返回成功
+// @Tags 收藏
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Success 200 {object} models.Response "已取消收藏"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/bookmark [delete]
+func UnbookmarkPostHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.UnbookmarkPost(userID, postID); err != nil {
+		zap.L().Error("logic.UnbookmarkPost failed", zap.Int64("post_id", postID), zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "已取消收藏")
+}
+
+// MoveBookmarkHandler 移动收藏
+// @Summary 移动收藏
+// @Description 将已收藏的动态移动到指定收藏夹，collection_id为空或0时移回未分类
+// @Tags 收藏
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Param data body models.ParamBookmarkRequest true "目标收藏夹"
+// @Success 200 {object} models.Response "移动成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "未收藏该动态/收藏夹不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /bookmarks/{id} [put]
+func MoveBookmarkHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	collectionID, ok := bindCollectionID(c)
+	if !ok {
+		return
+	}
+
+	if err = logic.MoveBookmark(userID, postID, collectionID); err != nil {
+		zap.L().Error("logic.MoveBookmark failed", zap.Int64("post_id", postID), zap.Error(err))
+		handleBookmarkError(c, err)
+		return
+	}
+	ResponseSuccess(c, "移动成功")
+}
+
+// GetBookmarksHandler 收藏列表
+// @Summary 收藏列表
+// @Description 按收藏时间倒序获取收藏的动态(每次10条)，使用游标分页；每次读取都重新校验可见范围，作者已删除或当前用户已不可见的动态不会返回
+// @Tags 收藏
+// @Produce json
+// @Security ApiKeyAuth
+// @Param collection_id query string false "收藏夹ID，0为未分类，不传则返回全部收藏"
+// @Param cursor query string false "分页游标(取上一页返回的next_cursor，首页为空)"
+// @Success 200 {object} models.Response{data=models.ParamBookmarkPage}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "收藏夹不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /bookmarks [get]
+func GetBookmarksHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	var collectionID *int64
+	if s, ok := c.GetQuery("collection_id"); ok {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			ResponseError(c, CodeInvalidParam)
+			return
+		}
+		collectionID = &id
+	}
+
+	page, err := logic.GetBookmarks(userID, collectionID, c.Query("cursor"))
+	if err != nil {
+		zap.L().Error("logic.GetBookmarks failed", zap.Error(err))
+		handleBookmarkError(c, err)
+		return
+	}
+	ResponseSuccess(c, page)
+}
+
+// CreateCollectionHandler 创建收藏夹
+// @Summary 创建收藏夹
+// @Description 创建收藏夹，收藏夹仅自己可见
+// @Tags 收藏
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body models.ParamCollectionRequest true "收藏夹名称"
+// @Success 200 {object} models.Response{data=models.ParamCollectionItem}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /collections [post]
+func CreateCollectionHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 参数校验
+	var req models.ParamCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("CreateCollection with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	collection, err := logic.CreateCollection(userID, req.Name)
+	if err != nil {
+		zap.L().Error("logic.CreateCollection failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, collection)
+}
+
+// GetCollectionsHandler 收藏夹列表
+// @Summary 收藏夹列表
+// @Description 获取当前用户的全部收藏夹及收藏数
+// @Tags 收藏
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=[]models.ParamCollectionItem}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /collections [get]
+func GetCollectionsHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	collections, err := logic.GetCollections(userID)
+	if err != nil {
+		zap.L().Error("logic.GetCollections failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, collections)
+}
+
+// RenameCollectionHandler 重命名收藏夹
+// @Summary 重命名收藏夹
+// @Description 修改自己的收藏夹名称
+// @Tags 收藏
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "收藏夹ID"
+// @Param data body models.ParamCollectionRequest true "收藏夹名称"
+// @Success 200 {object} models.Response "修改成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "收藏夹不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /collections/{id} [put]
+func RenameCollectionHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	collectionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 参数校验
+	var req models.ParamCollectionRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("RenameCollection with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.RenameCollection(userID, collectionID, req.Name); err != nil {
+		zap.L().Error("logic.RenameCollection failed", zap.Int64("collection_id", collectionID), zap.Error(err))
+		handleBookmarkError(c, err)
+		return
+	}
+	ResponseSuccess(c, "修改成功")
+}
+
+// DeleteCollectionHandler 删除收藏夹
+// @Summary 删除收藏夹
+// @Description 删除自己的收藏夹，其中的收藏移回未分类
+// @Tags 收藏
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "收藏夹ID"
+// @Success 200 {object} models.Response "删除成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "收藏夹不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /collections/{id} [delete]
+func DeleteCollectionHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	collectionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.DeleteCollection(userID, collectionID); err != nil {
+		zap.L().Error("logic.DeleteCollection failed", zap.Int64("collection_id", collectionID), zap.Error(err))
+		handleBookmarkError(c, err)
+		return
+	}
+	ResponseSuccess(c, "删除成功")
+}
+
+// bindCollectionID 解析请求体中的收藏夹ID，请求体为空时返回0(未分类)
+func bindCollectionID(c *gin.Context) (int64, bool) {
+	var req models.ParamBookmarkRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			zap.L().Error("bind collection_id failed", zap.Error(err))
+			ResponseError(c, CodeInvalidParam)
+			return 0, false
+		}
+	}
+	if req.CollectionID == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseInt(req.CollectionID, 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return 0, false
+	}
+	return id, true
+}
+
+// handleBookmarkError 将收藏相关错误转换为响应码
+func handleBookmarkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, mysql.ErrorInvalidCursor):
+		ResponseError(c, CodeInvalidParam)
+	case errors.Is(err, mysql.ErrorPostNotExist):
+		ResponseError(c, CodePostNotExist)
+	case errors.Is(err, mysql.ErrorCollectionNotExist):
+		ResponseError(c, CodeCollectionNotExist)
+	default:
+		ResponseError(c, CodeServerBusy)
+	}
+}
@@ -25,6 +25,61 @@ const (
 	CodeTooManyImages
 	CodeInvalidImageFormat
 	CodeCannotDeleteOthersPost
+
+	CodeCannotBlockSelf
+	CodeIsBlocked
+	CodeIsNotBlocked
+	CodeBlocked
+
+	CodeCannotFollowSelf
+	CodeIsFollowing
+	CodeIsNotFollowing
+	CodeFollowRequestNotExist
+
+	CodePostNotExist
+	CodeCommentNotExist
+	CodeCannotDeleteComment
+
+	CodeFriendGroupNotExist
+	CodeInvalidAudience
+	CodeCannotEditOthersPost
+
+	CodeTopicNotExist
+	CodeRepostWidenAudience
+
+	CodeDraftNotExist
+	CodeDraftNotEditable
+	CodeInvalidScheduleTime
+	CodeEmptyPost
+
+	CodeInvalidPoll
+	CodePollNotExist
+	CodePollClosed
+	CodeAlreadyVoted
+	CodeInvalidPollOption
+	CodePollResultHidden
+	CodePollAnonymous
+
+	CodeSensitiveContent
+
+	CodeCannotReportSelf
+	CodeAlreadyReported
+	CodeMessageNotExist
+	CodeCaseNotExist
+	CodeCaseClosed
+	CodeCaseClaimed
+	CodeInvalidModeration
+	CodeUserSuspended
+	CodePostHidden
+	CodeNoPermission
+
+	CodeFeedExpired
+	CodeCollectionNotExist
+	CodeFeedTokenNotExist
+	CodeFeedDisabled
+
+	CodeAlbumNotExist
+	CodePhotoNotExist
 )
 
 var CodeMsg = map[ResCode]string{
@@ -49,6 +104,61 @@ var CodeMsg = map[ResCode]string{
 	CodeTooManyImages:          "最多上传9张图片",
 	CodeInvalidImageFormat:     "图片格式不正确",
 	CodeCannotDeleteOthersPost: "只能删除自己的动态",
+
+	CodeCannotBlockSelf: "不能拉黑自己",
+	CodeIsBlocked:       "已将该用户拉黑",
+	CodeIsNotBlocked:    "该用户不在黑名单中",
+	CodeBlocked:         "您与该用户存在拉黑关系",
+
+	CodeCannotFollowSelf:      "不能关注自己",
+	CodeIsFollowing:           "已经关注该用户",
+	CodeIsNotFollowing:        "未关注该用户",
+	CodeFollowRequestNotExist: "关注申请不存在",
+
+	CodePostNotExist:        "动态不存在",
+	CodeCommentNotExist:     "评论不存在",
+	CodeCannotDeleteComment: "只能删除自己的评论或自己动态下的评论",
+
+	CodeFriendGroupNotExist:  "好友分组不存在",
+	CodeInvalidAudience:      "可见名单无效，请选择自己的好友或好友分组",
+	CodeCannotEditOthersPost: "只能修改自己的动态",
+
+	CodeTopicNotExist:       "话题不存在",
+	CodeRepostWidenAudience: "原动态非公开，转发不能设置为公开",
+
+	CodeDraftNotExist:       "草稿不存在",
+	CodeDraftNotEditable:    "草稿正在发布或已发布，无法修改",
+	CodeInvalidScheduleTime: "定时发布时间须在1分钟后且30天以内",
+	CodeEmptyPost:           "动态内容与图片不能同时为空",
+
+	CodeInvalidPoll:       "投票须有2~10个不重复的选项，每项不超过50字，截止时间须在将来",
+	CodePollNotExist:      "投票不存在",
+	CodePollClosed:        "投票已截止",
+	CodeAlreadyVoted:      "您已经投过票了",
+	CodeInvalidPollOption: "投票选项无效，单选投票只能选择一项",
+	CodePollResultHidden:  "投票后或投票截止后才能查看结果",
+	CodePollAnonymous:     "匿名投票不公开投票人",
+
+	CodeSensitiveContent: "内容包含违规词语，请修改后再提交",
+
+	CodeCannotReportSelf:  "不能举报自己",
+	CodeAlreadyReported:   "您已举报过，请等待处理",
+	CodeMessageNotExist:   "私信不存在",
+	CodeCaseNotExist:      "审核工单不存在",
+	CodeCaseClosed:        "审核工单已结案",
+	CodeCaseClaimed:       "审核工单已被其他管理员认领",
+	CodeInvalidModeration: "处置方式不适用于该举报对象，封禁须填写天数",
+	CodeUserSuspended:     "账号已被封禁",
+	CodePostHidden:        "动态已被管理员隐藏，无法修改可见范围",
+	CodeNoPermission:      "无权限",
+
+	CodeFeedExpired:        "动态排序已过期，请刷新后重新加载",
+	CodeCollectionNotExist: "收藏夹不存在",
+	CodeFeedTokenNotExist:  "订阅链接不存在或已失效",
+	CodeFeedDisabled:       "站点未配置外部访问地址，暂不支持订阅",
+
+	CodeAlbumNotExist: "相册不存在",
+	CodePhotoNotExist: "照片不存在",
 }
 
 func (c ResCode) Msg() string {
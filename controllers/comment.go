@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"gosocial/models"
+	"strconv"
+)
+
+// CreateCommentHandler 发表评论
+// @Summary 发表评论
+// @Description 评论指定动态，传入reply_to时表示回复该评论
+// @Tags 评论
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Param data body models.ParamCreateCommentRequest true "评论内容"
+// @Success 200 {object} models.Response{data=models.ParamCommentItem}
+// @Failure 400 {object} models.Response "参数错误/内容包含违规词语"
+// @Failure 404 {object} models.Response "动态或评论不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/comments [post]
+func CreateCommentHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 参数校验
+	var req models.ParamCreateCommentRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("CreateComment with invalid param", zap.Error(err))
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			ResponseError(c, CodeInvalidParam)
+			return
+		}
+		ResponseErrorWithMsg(c, CodeInvalidParam, removeTopStruct(errs.Translate(trans)))
+		return
+	}
+	if !filterText(c, userID, models.ContentSceneComment, &req.Content) {
+		return
+	}
+
+	comment, err := logic.CreateComment(userID, postID, req.ReplyTo, req.Content)
+	if err != nil {
+		zap.L().Error("logic.CreateComment failed", zap.Int64("post_id", postID), zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorPostNotExist):
+			ResponseError(c, CodePostNotExist)
+		case errors.Is(err, mysql.ErrorCommentNotExist):
+			ResponseError(c, CodeCommentNotExist)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
+		return
+	}
+	ResponseSuccess(c, comment)
+}
+
+// GetPostCommentsHandler 获取评论列表
+// @Summary 获取评论列表
+// @Description 按评论时间升序获取动态的评论(每次20条)，仅展示当前用户的好友、动态作者及自己的评论
+// @Tags 评论
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamCommentItem}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/comments [get]
+func GetPostCommentsHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID与偏移量
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	comments, err := logic.GetPostComments(userID, postID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetPostComments failed", zap.Int64("post_id", postID), zap.Error(err))
+		if errors.Is(err, mysql.ErrorPostNotExist) {
+			ResponseError(c, CodePostNotExist)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, comments)
+}
+
+// DeleteCommentHandler 删除评论
+// @Summary 删除评论
+// @Description 删除自己的评论，或删除自己动态下的任意评论；删除一级评论时其回复一并删除
+// @Tags 评论
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "评论ID"
+// @Success 200 {object} models.Response "删除成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 403 {object} models.Response "无权删除"
+// @Failure 404 {object} models.Response "评论不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /comments/{id} [delete]
+func DeleteCommentHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取评论ID
+	commentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.DeleteComment(userID, commentID); err != nil {
+		zap.L().Error("logic.DeleteComment failed", zap.Int64("comment_id", commentID), zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorCommentNotExist):
+			ResponseError(c, CodeCommentNotExist)
+		case errors.Is(err, mysql.ErrorCannotDeleteComment):
+			ResponseError(c, CodeCannotDeleteComment)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
+		return
+	}
+	ResponseSuccess(c, "删除成功")
+}
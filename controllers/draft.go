@@ -0,0 +1,374 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"gosocial/models"
+	"strconv"
+)
+
+// CreateDraftHandler 保存草稿
+// @Summary 保存草稿
+// @Description 保存动态草稿，图片在保存时即上传处理，之后可继续编辑、定时发布或立即发布
+// @Tags 草稿
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param content formData string false "文字内容(不超过500字，支持 #话题# 与 @用户名)"
+// @Param images formData []file false "图片文件(最多9张,单张不超过10MB,支持jpg/png/gif/webp)"
+// @Param visibility formData string false "可见范围(public/friends/private/allow/deny，默认friends)"
+// @Param user_ids formData string false "visibility为allow/deny时的好友ID，多个用逗号分隔"
+// @Param group_ids formData string false "visibility为allow/deny时的好友分组ID，多个用逗号分隔"
+// @Success 200 {object} models.Response{data=models.ParamDraftItem} "保存成功"
+// @Failure 400 {object} models.Response "参数错误/图片格式错误/图片过多/内容包含违规词语"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /drafts [post]
+func CreateDraftHandler(c *gin.Context) {
+	userID := c.MustGet("uid").(int64)
+
+	content := c.PostForm("content")
+	if len([]rune(content)) > 500 {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	if !filterText(c, userID, models.ContentScenePost, &content) {
+		return
+	}
+	visibility := c.DefaultPostForm("visibility", models.PostVisibilityFriends)
+	if !models.IsValidPostVisibility(visibility) {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	userIDs, err := parseIDList(c.PostForm("user_ids"))
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	groupIDs, err := parseIDList(c.PostForm("group_ids"))
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	form, err := c.MultipartForm()
+	if err != nil {
+		zap.L().Error("c.MultipartForm failed", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	imageData, ok := readPostImageFiles(c, form.File["images"])
+	if !ok {
+		return
+	}
+
+	draft, err := logic.CreateDraft(userID, content, visibility, userIDs, groupIDs, imageData)
+	if err != nil {
+		handleDraftError(c, err)
+		return
+	}
+	ResponseSuccess(c, draft)
+}
+
+// GetDraftsHandler 草稿箱
+// @Summary 草稿箱
+// @Description 按更新时间倒序获取自己的草稿与定时动态(每次10条)
+// @Tags 草稿
+// @Produce json
+// @Security ApiKeyAuth
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamDraftItem}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /drafts [get]
+func GetDraftsHandler(c *gin.Context) {
+	userID := c.MustGet("uid").(int64)
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	drafts, err := logic.GetDrafts(userID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetDrafts failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, drafts)
+}
+
+// GetDraftHandler 草稿详情
+// @Summary 草稿详情
+// @Description 获取草稿内容、图片与定时发布状态，用于继续编辑
+// @Tags 草稿
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "草稿ID"
+// @Success 200 {object} models.Response{data=models.ParamDraftItem}
+// @Failure 404 {object} models.Response "草稿不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /drafts/{id} [get]
+func GetDraftHandler(c *gin.Context) {
+	userID := c.MustGet("uid").(int64)
+	draftID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	draft, err := logic.GetDraft(userID, draftID)
+	if err != nil {
+		handleDraftError(c, err)
+		return
+	}
+	ResponseSuccess(c, draft)
+}
+
+// UpdateDraftHandler 修改草稿
+// @Summary 修改草稿
+// @Description 修改草稿的内容与可见范围，定时动态修改后仍按原时间发布
+// @Tags 草稿
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "草稿ID"
+// @Param data body models.ParamDraftRequest true "草稿内容"
+// @Success 200 {object} models.Response "修改成功"
+// @Failure 400 {object} models.Response "参数错误/草稿正在发布或已发布/内容包含违规词语"
+// @Failure 404 {object} models.Response "草稿不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /drafts/{id} [put]
+func UpdateDraftHandler(c *gin.Context) {
+	userID := c.MustGet("uid").(int64)
+	draftID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	var req models.ParamDraftRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("UpdateDraft with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	if !filterText(c, userID, models.ContentScenePost, &req.Content) {
+		return
+	}
+	if req.Visibility == "" {
+		req.Visibility = models.PostVisibilityFriends
+	}
+	userIDs, err := parseIDs(req.UserIDs)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	groupIDs, err := parseIDs(req.GroupIDs)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	if err = logic.UpdateDraft(userID, draftID, req.Content, req.Visibility, userIDs, groupIDs); err != nil {
+		handleDraftError(c, err)
+		return
+	}
+	ResponseSuccess(c, "修改成功")
+}
+
+// AddDraftImagesHandler 草稿追加图片
+// @Summary 草稿追加图片
+// @Description 为草稿上传图片，草稿图片总数不超过9张
+// @Tags 草稿
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "草稿ID"
+// @Param images formData []file true "图片文件(单张不超过10MB,支持jpg/png/gif/webp)"
+// @Success 200 {object} models.Response{data=models.ParamDraftItem} "上传成功"
+// @Failure 400 {object} models.Response "参数错误/图片格式错误/图片过多"
+// @Failure 404 {object} models.Response "草稿不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /drafts/{id}/images [post]
+func AddDraftImagesHandler(c *gin.Context) {
+	userID := c.MustGet("uid").(int64)
+	draftID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["images"]) == 0 {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	imageData, ok := readPostImageFiles(c, form.File["images"])
+	if !ok {
+		return
+	}
+	if err = logic.AddDraftImages(userID, draftID, imageData); err != nil {
+		handleDraftError(c, err)
+		return
+	}
+	draft, err := logic.GetDraft(userID, draftID)
+	if err != nil {
+		handleDraftError(c, err)
+		return
+	}
+	ResponseSuccess(c, draft)
+}
+
+// DeleteDraftImageHandler 删除草稿图片
+// @Summary 删除草稿图片
+// @Tags 草稿
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "草稿ID"
+// @Param imageID path int true "图片ID"
+// @Success 200 {object} models.Response "删除成功"
+// @Failure 404 {object} models.Response "草稿不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /drafts/{id}/images/{imageID} [delete]
+func DeleteDraftImageHandler(c *gin.Context) {
+	userID := c.MustGet("uid").(int64)
+	draftID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	imageID, err := strconv.ParseInt(c.Param("imageID"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	if err = logic.DeleteDraftImage(userID, draftID, imageID); err != nil {
+		handleDraftError(c, err)
+		return
+	}
+	ResponseSuccess(c, "删除成功")
+}
+
+// DeleteDraftHandler 删除草稿
+// @Summary 删除草稿
+// @Description 删除草稿及其图片，定时动态删除后不再发布
+// @Tags 草稿
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "草稿ID"
+// @Success 200 {object} models.Response "删除成功"
+// @Failure 400 {object} models.Response "草稿正在发布或已发布"
+// @Failure 404 {object} models.Response "草稿不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /drafts/{id} [delete]
+func DeleteDraftHandler(c *gin.Context) {
+	handleDraft(c, logic.DeleteDraft, "删除成功")
+}
+
+// PublishDraftHandler 立即发布草稿
+// @Summary 立即发布草稿
+// @Description 立即发布草稿或定时动态，发布后与直接创建的动态相同
+// @Tags 草稿
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "草稿ID"
+// @Success 200 {object} models.Response{data=models.Post} "发布成功"
+// @Failure 400 {object} models.Response "内容为空/可见名单无效/草稿正在发布或已发布"
+// @Failure 404 {object} models.Response "草稿不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /drafts/{id}/publish [post]
+func PublishDraftHandler(c *gin.Context) {
+	userID := c.MustGet("uid").(int64)
+	draftID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	post, err := logic.PublishDraft(userID, draftID)
+	if err != nil {
+		handleDraftError(c, err)
+		return
+	}
+	ResponseSuccess(c, post)
+}
+
+// ScheduleDraftHandler 定时发布
+// @Summary 定时发布
+// @Description 设置或修改草稿的定时发布时间，到时间后由后台任务发布，发布失败会通知作者
+// @Tags 草稿
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "草稿ID"
+// @Param data body models.ParamScheduleDraftRequest true "发布时间"
+// @Success 200 {object} models.Response "设置成功"
+// @Failure 400 {object} models.Response "参数错误/发布时间无效/内容为空/可见名单无效"
+// @Failure 404 {object} models.Response "草稿不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /drafts/{id}/schedule [put]
+func ScheduleDraftHandler(c *gin.Context) {
+	userID := c.MustGet("uid").(int64)
+	draftID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	var req models.ParamScheduleDraftRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("ScheduleDraft with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	if err = logic.ScheduleDraft(userID, draftID, req.ScheduledAt); err != nil {
+		handleDraftError(c, err)
+		return
+	}
+	ResponseSuccess(c, "设置成功")
+}
+
+// CancelDraftScheduleHandler 取消定时发布
+// @Summary 取消定时发布
+// @Description 取消定时发布，动态回到草稿箱
+// @Tags 草稿
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "草稿ID"
+// @Success 200 {object} models.Response "取消成功"
+// @Failure 400 {object} models.Response "草稿正在发布或已发布"
+// @Failure 404 {object} models.Response "草稿不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /drafts/{id}/schedule [delete]
+func CancelDraftScheduleHandler(c *gin.Context) {
+	handleDraft(c, logic.CancelDraftSchedule, "取消成功")
+}
+
+// handleDraft 处理单个草稿的操作
+func handleDraft(c *gin.Context, op func(userID, draftID int64) error, msg string) {
+	userID := c.MustGet("uid").(int64)
+	draftID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	if err = op(userID, draftID); err != nil {
+		handleDraftError(c, err)
+		return
+	}
+	ResponseSuccess(c, msg)
+}
+
+// handleDraftError 将草稿相关的错误转换为响应
+func handleDraftError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, mysql.ErrorDraftNotExist):
+		ResponseError(c, CodeDraftNotExist)
+	case errors.Is(err, mysql.ErrorDraftNotEditable):
+		ResponseError(c, CodeDraftNotEditable)
+	case errors.Is(err, mysql.ErrorInvalidScheduleTime):
+		ResponseError(c, CodeInvalidScheduleTime)
+	case errors.Is(err, mysql.ErrorEmptyPost):
+		ResponseError(c, CodeEmptyPost)
+	case errors.Is(err, mysql.ErrorInvalidAudience):
+		ResponseError(c, CodeInvalidAudience)
+	case errors.Is(err, mysql.ErrorTooManyImages):
+		ResponseError(c, CodeTooManyImages)
+	case isInvalidImage(err):
+		ResponseError(c, CodeInvalidImageFormat)
+	default:
+		zap.L().Error("draft operation failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+	}
+}
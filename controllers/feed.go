@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"gosocial/pkg/feed"
+	"gosocial/settings"
+)
+
+// GetUserFeedHandler 订阅源
+// @Summary 订阅源
+// @Description 免登录获取令牌所属用户最新20条公开动态的订阅源，支持RSS 2.0(rss)、Atom 1.0(atom)与JSON Feed 1.1(json)；图片作为附件输出(RSS仅第一张图片作为附件，全部图片包含在正文中)
+// @Description 响应带有ETag与Last-Modified，请求携带If-None-Match或If-Modified-Since且内容未变化时返回304；令牌不存在或已吊销时返回404，站点未配置外部访问地址时返回503
+// @Tags 订阅源
+// @Produce xml
+// @Produce json
+// @Param token path string true "订阅令牌"
+// @Param format path string true "订阅源格式" Enums(rss, atom, json)
+// @Success 200 {string} string "订阅源内容"
+// @Success 304 {string} string "内容未变化"
+// @Failure 404 {object} models.Response "订阅链接不存在或已失效"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Failure 503 {object} models.Response "订阅功能未启用"
+// @Router /feeds/{token}/{format} [get]
+func GetUserFeedHandler(c *gin.Context) {
+	format := c.Param("format")
+	if format != logic.FeedFormatRSS && format != logic.FeedFormatAtom && format != logic.FeedFormatJSON {
+		feedError(c, http.StatusNotFound, CodeInvalidParam)
+		return
+	}
+
+	baseURL := feedBaseURL()
+	if baseURL == "" {
+		feedError(c, http.StatusServiceUnavailable, CodeFeedDisabled)
+		return
+	}
+	f, err := logic.GetUserFeed(c.Param("token"), baseURL, baseURL+c.Request.URL.Path)
+	if err != nil {
+		if errors.Is(err, mysql.ErrorFeedTokenNotExist) || errors.Is(err, mysql.ErrorUserNotExist) {
+			feedError(c, http.StatusNotFound, CodeFeedTokenNotExist)
+			return
+		}
+		zap.L().Error("logic.GetUserFeed failed", zap.Error(err))
+		feedError(c, http.StatusInternalServerError, CodeServerBusy)
+		return
+	}
+
+	var body []byte
+	var contentType string
+	switch format {
+	case logic.FeedFormatRSS:
+		body, err = f.RSS()
+		contentType = feed.ContentTypeRSS
+	case logic.FeedFormatAtom:
+		body, err = f.Atom()
+		contentType = feed.ContentTypeAtom
+	default:
+		body, err = f.JSON()
+		contentType = feed.ContentTypeJSON
+	}
+	if err != nil {
+		zap.L().Error("render feed failed", zap.String("format", format), zap.Error(err))
+		feedError(c, http.StatusInternalServerError, CodeServerBusy)
+		return
+	}
+
+	// 内容哈希作为强ETag，订阅源内容最后变化的时间作为Last-Modified
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	modified := f.Updated.UTC().Truncate(time.Second)
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", modified.Format(http.TimeFormat))
+	c.Header("Cache-Control", "no-cache")
+	if notModified(c.Request, etag, modified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// GetFeedTokenHandler 获取订阅地址
+// @Summary 获取订阅地址
+// @Description 获取当前用户的订阅令牌及RSS、Atom、JSON Feed订阅地址，订阅源只包含公开动态；尚未生成时返回订阅链接不存在；站点未配置外部访问地址时不支持订阅
+// @Tags 订阅源
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=models.ParamFeedToken}
+// @Failure 404 {object} models.Response "尚未生成订阅链接"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /user/feed-token [get]
+func GetFeedTokenHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	baseURL := feedBaseURL()
+	if baseURL == "" {
+		ResponseError(c, CodeFeedDisabled)
+		return
+	}
+	token, err := logic.GetFeedToken(userID, baseURL)
+	if err != nil {
+		if errors.Is(err, mysql.ErrorFeedTokenNotExist) {
+			ResponseError(c, CodeFeedTokenNotExist)
+			return
+		}
+		zap.L().Error("logic.GetFeedToken failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, token)
+}
+
+// ResetFeedTokenHandler 生成订阅地址
+// @Summary 生成订阅地址
+// @Description 生成新的订阅令牌并返回订阅地址，已有的订阅地址立即失效；站点未配置外部访问地址时不支持订阅
+// @Tags 订阅源
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=models.ParamFeedToken}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /user/feed-token [post]
+func ResetFeedTokenHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	baseURL := feedBaseURL()
+	if baseURL == "" {
+		ResponseError(c, CodeFeedDisabled)
+		return
+	}
+	token, err := logic.ResetFeedToken(userID, baseURL)
+	if err != nil {
+		zap.L().Error("logic.ResetFeedToken failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, token)
+}
+
+// RevokeFeedTokenHandler 关闭订阅地址
+// @Summary 关闭订阅地址
+// @Description 吊销订阅令牌，所有订阅地址立即失效；未生成时同样返回成功
+// @Tags 订阅源
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response "已关闭订阅"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /user/feed-token [delete]
+func RevokeFeedTokenHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	if err := logic.RevokeFeedToken(userID); err != nil {
+		zap.L().Error("logic.RevokeFeedToken failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "已关闭订阅")
+}
+
+// feedBaseURL 配置的站点外部访问地址，未配置时返回空字符串
+// 不根据请求的Host推断，避免伪造Host生成指向其他站点的订阅地址与图片地址
+func feedBaseURL() string {
+	if cfg := settings.Conf.FeedConfig; cfg != nil {
+		return strings.TrimRight(cfg.BaseURL, "/")
+	}
+	return ""
+}
+
+// notModified 判断条件请求的内容是否未变化
+// 携带If-None-Match时只比较ETag，否则比较If-Modified-Since
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		t, err := http.ParseTime(ims)
+		if err == nil && !modified.After(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// feedError 订阅源接口面向订阅阅读器，出错时使用对应的HTTP状态码
+func feedError(c *gin.Context, status int, code ResCode) {
+	c.JSON(status, &ResponseData{
+		Code: code,
+		Msg:  code.Msg(),
+	})
+}
@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"strconv"
+)
+
+// FollowHandler 关注用户
+// @Summary 关注用户
+// @Description 关注指定用户，对方开启关注审核时返回pending状态
+// @Tags 关注
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path int true "用户ID"
+// @Success 200 {object} models.Response "{"status":"accepted/pending"}"
+// @Failure 400 {object} models.Response "参数格式错误/不能关注自己"
+// @Failure 404 {object} models.Response "用户不存在"
+// @Failure 409 {object} models.Response "已经关注该用户/存在拉黑关系"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /follows/{uid} [post]
+func FollowHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取参数
+	followeeID, _ := strconv.ParseInt(c.Param("uid"), 10, 64)
+	if followeeID == 0 {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	status, err := logic.Follow(userID, followeeID)
+	if err != nil {
+		zap.L().Error("logic.Follow failed", zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorCannotFollowSelf):
+			ResponseError(c, CodeCannotFollowSelf)
+		case errors.Is(err, mysql.ErrorUserNotExist):
+			ResponseError(c, CodeUserNotExist)
+		case errors.Is(err, mysql.ErrorBlocked):
+			ResponseError(c, CodeBlocked)
+		case errors.Is(err, mysql.ErrorIsFollowing):
+			ResponseError(c, CodeIsFollowing)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
+		return
+	}
+	ResponseSuccess(c, gin.H{
+		"status": status,
+	})
+}
+
+// UnfollowHandler 取消关注
+// @Summary 取消关注
+// @Description 取消关注指定用户或撤回关注申请
+// @Tags 关注
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path int true "用户ID"
+// @Success 200 {object} models.Response "取消关注成功"
+// @Failure 400 {object} models.Response "参数格式错误"
+// @Failure 404 {object} models.Response "未关注该用户"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /follows/{uid} [delete]
+func UnfollowHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取参数
+	followeeID, _ := strconv.ParseInt(c.Param("uid"), 10, 64)
+	if followeeID == 0 {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err := logic.Unfollow(userID, followeeID); err != nil {
+		zap.L().Error("logic.Unfollow failed", zap.Error(err))
+		if errors.Is(err, mysql.ErrorIsNotFollowing) {
+			ResponseError(c, CodeIsNotFollowing)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "取消关注成功")
+}
+
+// GetFollowersHandler 获取粉丝列表
+// @Summary 获取粉丝列表
+// @Description 按关注时间倒序获取指定用户的粉丝列表(每次20条)，不传uid时获取自己的
+// @Tags 关注
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid query int false "用户ID"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamFollowItem}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /followers [get]
+func GetFollowersHandler(c *gin.Context) {
+	userID := queryUserID(c)
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	followers, err := logic.GetFollowers(userID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetFollowers failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, followers)
+}
+
+// GetFollowingHandler 获取关注列表
+// @Summary 获取关注列表
+// @Description 按关注时间倒序获取指定用户的关注列表(每次20条)，不传uid时获取自己的
+// @Tags 关注
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid query int false "用户ID"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamFollowItem}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /following [get]
+func GetFollowingHandler(c *gin.Context) {
+	userID := queryUserID(c)
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	following, err := logic.GetFollowing(userID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetFollowing failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, following)
+}
+
+// GetFollowCountHandler 获取关注数与粉丝数
+// @Summary 获取关注数与粉丝数
+// @Description 获取指定用户的关注数与粉丝数，不传uid时获取自己的
+// @Tags 关注
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid query int false "用户ID"
+// @Success 200 {object} models.Response{data=models.ParamFollowCount}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /follows/count [get]
+func GetFollowCountHandler(c *gin.Context) {
+	userID := queryUserID(c)
+
+	count, err := logic.GetFollowCount(userID)
+	if err != nil {
+		zap.L().Error("logic.GetFollowCount failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, count)
+}
+
+// GetFollowRequestsHandler 获取待审核的关注申请
+// @Summary 获取关注申请列表
+// @Description 按申请时间倒序获取待审核的关注申请(每次20条)
+// @Tags 关注
+// @Produce json
+// @Security ApiKeyAuth
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamFollowItem}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /follows/requests [get]
+func GetFollowRequestsHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	requests, err := logic.GetFollowRequests(userID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetFollowRequests failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, requests)
+}
+
+// AcceptFollowRequestHandler 通过关注申请
+// @Summary 通过关注申请
+// @Description 通过指定用户的关注申请
+// @Tags 关注
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path int true "申请者ID"
+// @Success 200 {object} models.Response "已通过关注申请"
+// @Failure 404 {object} models.Response "关注申请不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /follows/requests/{uid} [put]
+func AcceptFollowRequestHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+	followerID, _ := strconv.ParseInt(c.Param("uid"), 10, 64)
+
+	if err := logic.AcceptFollowRequest(userID, followerID); err != nil {
+		zap.L().Error("logic.AcceptFollowRequest failed", zap.Error(err))
+		if errors.Is(err, mysql.ErrorFollowRequestNotExist) {
+			ResponseError(c, CodeFollowRequestNotExist)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "已通过关注申请")
+}
+
+// RejectFollowRequestHandler 拒绝关注申请
+// @Summary 拒绝关注申请
+// @Description 拒绝指定用户的关注申请
+// @Tags 关注
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uid path int true "申请者ID"
+// @Success 200 {object} models.Response "已拒绝关注申请"
+// @Failure 404 {object} models.Response "关注申请不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /follows/requests/{uid} [delete]
+func RejectFollowRequestHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+	followerID, _ := strconv.ParseInt(c.Param("uid"), 10, 64)
+
+	if err := logic.RejectFollowRequest(userID, followerID); err != nil {
+		zap.L().Error("logic.RejectFollowRequest failed", zap.Error(err))
+		if errors.Is(err, mysql.ErrorFollowRequestNotExist) {
+			ResponseError(c, CodeFollowRequestNotExist)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "已拒绝关注申请")
+}
+
+// queryUserID 获取查询参数中的uid，未传时使用当前登录用户
+func queryUserID(c *gin.Context) int64 {
+	if uid, err := strconv.ParseInt(c.Query("uid"), 10, 64); err == nil && uid != 0 {
+		return uid
+	}
+	return c.MustGet("uid").(int64)
+}
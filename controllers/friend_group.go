@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"gosocial/models"
+	"strconv"
+)
+
+// CreateFriendGroupHandler 创建好友分组
+// @Summary 创建好友分组
+// @Description 创建好友分组，可用于设置动态的部分可见/不给谁看名单
+// @Tags 好友分组
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body models.ParamFriendGroupRequest true "分组信息"
+// @Success 200 {object} models.Response{data=models.ParamFriendGroupItem}
+// @Failure 400 {object} models.Response "参数错误/成员不是好友"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /friend-groups [post]
+func CreateFriendGroupHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 参数校验
+	var req models.ParamFriendGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("CreateFriendGroup with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	memberIDs, err := parseIDs(req.MemberIDs)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	group, err := logic.CreateFriendGroup(userID, req.Name, memberIDs)
+	if err != nil {
+		zap.L().Error("logic.CreateFriendGroup failed", zap.Error(err))
+		if errors.Is(err, mysql.ErrorIsNotFriend) {
+			ResponseError(c, CodeIsNotFriend)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, group)
+}
+
+// GetFriendGroupsHandler 获取好友分组列表
+// @Summary 获取好友分组列表
+// @Description 获取当前用户的全部好友分组及成员
+// @Tags 好友分组
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=[]models.ParamFriendGroupItem}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /friend-groups [get]
+func GetFriendGroupsHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	groups, err := logic.GetFriendGroups(userID)
+	if err != nil {
+		zap.L().Error("logic.GetFriendGroups failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, groups)
+}
+
+// UpdateFriendGroupHandler 修改好友分组
+// @Summary 修改好友分组
+// @Description 修改分组名称并整体替换分组成员，使用该分组的动态可见范围随之生效
+// @Tags 好友分组
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "分组ID"
+// @Param data body models.ParamFriendGroupRequest true "分组信息"
+// @Success 200 {object} models.Response "修改成功"
+// @Failure 400 {object} models.Response "参数错误/成员不是好友"
+// @Failure 404 {object} models.Response "分组不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /friend-groups/{id} [put]
+func UpdateFriendGroupHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取分组ID
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 参数校验
+	var req models.ParamFriendGroupRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("UpdateFriendGroup with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	memberIDs, err := parseIDs(req.MemberIDs)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.UpdateFriendGroup(userID, groupID, req.Name, memberIDs); err != nil {
+		zap.L().Error("logic.UpdateFriendGroup failed", zap.Int64("group_id", groupID), zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorFriendGroupNotExist):
+			ResponseError(c, CodeFriendGroupNotExist)
+		case errors.Is(err, mysql.ErrorIsNotFriend):
+			ResponseError(c, CodeIsNotFriend)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
+		return
+	}
+	ResponseSuccess(c, "修改成功")
+}
+
+// DeleteFriendGroupHandler 删除好友分组
+// @Summary 删除好友分组
+// @Description 删除好友分组，动态可见名单中对该分组的引用一并移除
+// @Tags 好友分组
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "分组ID"
+// @Success 200 {object} models.Response "删除成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "分组不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /friend-groups/{id} [delete]
+func DeleteFriendGroupHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取分组ID
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.DeleteFriendGroup(userID, groupID); err != nil {
+		zap.L().Error("logic.DeleteFriendGroup failed", zap.Int64("group_id", groupID), zap.Error(err))
+		if errors.Is(err, mysql.ErrorFriendGroupNotExist) {
+			ResponseError(c, CodeFriendGroupNotExist)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "删除成功")
+}
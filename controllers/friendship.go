@@ -6,25 +6,41 @@ import (
 	"go.uber.org/zap"
 	"gosocial/dao/mysql"
 	"gosocial/logic"
+	"gosocial/models"
 	"strconv"
 )
 
 // GetFriendListHandler	获取好友列表
 // @Summary 获取好友列表
-// @Description 按最后互动时间排序的好友列表
+// @Description 按最后互动时间或亲密度排序的好友列表
 // @Tags 好友管理
 // @Produce json
 // @Security ApiKeyAuth
-// @Param request body models.ParamFriendItem true "好友列表请求参数"
-// @Success 200 {object} models.Response{data=[]models.ParamFriendItem}
+// @Param sort query string false "排序方式(last_interact:最后互动时间 intimacy:亲密度，默认last_interact)"
+// @Param cursor query string false "分页游标(取上一页返回的next_cursor，首页不传)"
+// @Param limit query int false "每页数量(默认20，最大100)"
+// @Success 200 {object} models.Response{data=models.ParamFriendPage}
+// @Failure 400 {object} models.Response "参数格式错误"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /friends [get]
 func GetFriendListHandler(c *gin.Context) {
 	// 获取用户ID
 	userID := c.MustGet("uid").(int64)
+
+	// 获取分页参数
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 || limit > 100 {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
 	// 获取好友列表
-	friendList, err := logic.GetFriendList(userID)
+	friendList, err := logic.GetFriendList(userID, c.DefaultQuery("sort", logic.FriendSortLastInteract), c.Query("cursor"), limit)
 	if err != nil {
+		if errors.Is(err, mysql.ErrorInvalidCursor) {
+			ResponseError(c, CodeInvalidParam)
+			return
+		}
 		zap.L().Error("GetFriendListHandler failed", zap.Error(err))
 		ResponseError(c, CodeServerBusy)
 		return
@@ -33,6 +49,36 @@ func GetFriendListHandler(c *gin.Context) {
 	ResponseSuccess(c, friendList)
 }
 
+// GetUpcomingBirthdaysHandler 好友生日提醒
+// @Summary 好友生日提醒
+// @Description 获取包括今天在内的days天内过生日的好友，按距离生日的天数升序排序；2月29日出生的好友在平年按2月28日提醒。隐藏生日的好友不返回，隐藏出生年份的好友不返回岁数
+// @Tags 好友管理
+// @Produce json
+// @Security ApiKeyAuth
+// @Param days query int false "查询天数(默认7，最大366)"
+// @Success 200 {object} models.Response{data=[]models.ParamBirthdayItem}
+// @Failure 400 {object} models.Response "参数格式错误"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /friends/birthdays [get]
+func GetUpcomingBirthdaysHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil || days <= 0 || days > logic.BirthdayMaxDays {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	birthdays, err := logic.GetUpcomingBirthdays(userID, days)
+	if err != nil {
+		zap.L().Error("logic.GetUpcomingBirthdays failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, birthdays)
+}
+
 // AddFriendHandler 添加好友
 // @Summary 添加好友
 // @Description 添加好友关系
@@ -44,7 +90,7 @@ func GetFriendListHandler(c *gin.Context) {
 // @Failure 400 {object} models.Response "参数格式错误"
 // @Failure 401 {object} models.Response "不能添加自己为好友"
 // @Failure 404 {object} models.Response "好友不存在"
-// @Failure 409 {object} models.Response "已经是好友关系"
+// @Failure 409 {object} models.Response "已经是好友关系/存在拉黑关系"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /friends/{friendID} [post]
 func AddFriendHandler(c *gin.Context) {
@@ -66,6 +112,9 @@ func AddFriendHandler(c *gin.Context) {
 		case errors.Is(err, mysql.ErrorIsFriend):
 			zap.L().Error("AddFriendHandler() user have been your friend", zap.Error(err)) //不能重复添加好友
 			ResponseError(c, CodeIsFriend)
+		case errors.Is(err, mysql.ErrorBlocked):
+			zap.L().Error("AddFriendHandler() blocked", zap.Error(err)) //存在拉黑关系
+			ResponseError(c, CodeBlocked)
 		default:
 			zap.L().Error("AddFriendHandler() failed", zap.Error(err))
 			ResponseError(c, CodeServerBusy)
@@ -104,6 +153,11 @@ func GetFriendDetailHandler(c *gin.Context) {
 		return
 	}
 
+	// 记录访问好友主页的互动
+	if err := logic.RecordInteraction(c, userID, FriendID, logic.InteractWeightProfileVisit); err != nil {
+		zap.L().Error("RecordInteraction failed", zap.Error(err))
+	}
+
 	// 调用业务逻辑获取用户信息
 	user, err := logic.GetFriendInfoLogic(FriendID)
 	if err != nil {
@@ -118,7 +172,7 @@ func GetFriendDetailHandler(c *gin.Context) {
 
 // SearchFriendHandler 搜索好友
 // @Summary 搜索好友
-// @Description 根据备注或用户名搜索好友，按匹配优先级排序
+// @Description 根据备注或用户名搜索好友，支持全拼、拼音首字母与容错匹配，按匹配优先级排序
 // @Tags 好友管理
 // @Produce json
 // @Security ApiKeyAuth
@@ -194,7 +248,7 @@ func DeleteFriendHandler(c *gin.Context) {
 // @Param uid query int true "好友ID"
 // @Param remark query string true "新备注"
 // @Success 200 {object} models.Response "备注更新成功"
-// @Failure 400 {object} models.Response "参数格式错误"
+// @Failure 400 {object} models.Response "参数格式错误/内容包含违规词语"
 // @Failure 403 {object} models.Response "不是好友关系"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /friends/remark [put]
@@ -209,6 +263,9 @@ func UpdateFriendRemarkHandler(c *gin.Context) {
 		ResponseError(c, CodeInvalidParam)
 		return
 	}
+	if !filterText(c, userID, models.ContentSceneRemark, &remark) {
+		return
+	}
 	// 调用logic更新好友备注
 	if err := logic.UpdateFriendRemark(userID, friendID, remark); err != nil {
 		if errors.Is(err, mysql.ErrorIsNotFriend) {
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"strconv"
+)
+
+// LikePostHandler 点赞动态
+// @Summary 点赞动态
+// @Description 点赞指定动态，重复点赞不会重复计数
+// @Tags 动态
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Success 200 {object} models.Response "点赞成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/like [post]
+func LikePostHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.LikePost(userID, postID); err != nil {
+		zap.L().Error("logic.LikePost failed", zap.Int64("post_id", postID), zap.Error(err))
+		if errors.Is(err, mysql.ErrorPostNotExist) {
+			ResponseError(c, CodePostNotExist)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "点赞成功")
+}
+
+// UnlikePostHandler 取消点赞
+// @Summary 取消点赞
+// @Description 取消对指定动态的点赞，未点赞时同样返回成功
+// @Tags 动态
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Success 200 {object} models.Response "已取消点赞"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/like [delete]
+func UnlikePostHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.UnlikePost(userID, postID); err != nil {
+		zap.L().Error("logic.UnlikePost failed", zap.Int64("post_id", postID), zap.Error(err))
+		if errors.Is(err, mysql.ErrorPostNotExist) {
+			ResponseError(c, CodePostNotExist)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "已取消点赞")
+}
+
+// GetPostLikersHandler 获取点赞用户列表
+// @Summary 获取点赞用户列表
+// @Description 按点赞时间倒序获取动态的点赞用户(每次20条)，仅展示当前用户的好友
+// @Tags 动态
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamLikerItem}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/likes [get]
+func GetPostLikersHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID与偏移量
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	likers, err := logic.GetPostLikers(userID, postID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetPostLikers failed", zap.Int64("post_id", postID), zap.Error(err))
+		if errors.Is(err, mysql.ErrorPostNotExist) {
+			ResponseError(c, CodePostNotExist)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, likers)
+}
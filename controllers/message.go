@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"errors"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"gosocial/dao/mysql"
@@ -32,7 +31,7 @@ func NewMessageController(messageDao *r.MessageDao, mysqlDao *mysql.MessageDao)
 // @Param Authorization header string true "Bearer 用户令牌"
 // @Param object body models.ParamTextReq true "文本消息参数"
 // @Success 200 {object} models.Response "{"timestamp":时间戳,"receiver_id":接收者ID,"status":"sent"}"
-// @Failure 400 {object} models.Response "错误信息"
+// @Failure 400 {object} models.Response "错误信息/内容包含违规词语"
 // @Router /api/v1/messages [post]
 func (c *MessageController) SendMessageHandler(ctx *gin.Context) {
 	// 解析请求体中的消息类型和内容
@@ -58,11 +57,14 @@ func (c *MessageController) SendMessageHandler(ctx *gin.Context) {
 
 	// 获取当前用户ID,并判断是否为好友
 	from, _ := ctx.Get("uid")
-	if err := mysql.IsFriend(from.(int64), req.To); !errors.Is(err, mysql.ErrorIsFriend) {
+	if err := logic.IsFriend(from.(int64), req.To); err != nil {
 		zap.L().Error("is friend failed, err: ", zap.Error(err))
 		ResponseError(ctx, CodeIsNotFriend)
 		return
 	}
+	if !filterText(ctx, from.(int64), models.ContentSceneMessage, &req.Content) {
+		return
+	}
 
 	err := c.logic.SendTextMessage(ctx, from.(int64), req.To, req.Content)
 	if err != nil {
@@ -103,7 +105,7 @@ func (c *MessageController) GetMessagesHandler(ctx *gin.Context) {
 	userID, _ := ctx.Get("uid")
 	friendID, _ := strconv.ParseInt(ctx.Query("friend_id"), 10, 64)
 	//判断是否为好友
-	if err := mysql.IsFriend(userID.(int64), friendID); errors.Is(err, mysql.ErrorIsNotFriend) {
+	if err := logic.IsFriend(userID.(int64), friendID); err != nil {
 		zap.L().Error("is friend failed, err: ", zap.Error(err))
 		ResponseError(ctx, CodeIsNotFriend)
 		return
@@ -167,6 +169,7 @@ func (c *MessageController) GetMessagesHandler(ctx *gin.Context) {
 			"direct":     direct, //direct=1 方向则为用户发给好友，direct=2 方向则为好友发给用户
 			"created_at": msg.CreatedAt,
 			"content":    msg.Content,
+			"username":   msg.My.Username,
 			"avatar_url": msg.My.AvatarURL,
 			"hide_time":  msg.HideTime,
 		})
@@ -199,7 +202,7 @@ func (c *MessageController) SendImageMessageHandler(ctx *gin.Context) {
 
 	// 获取当前用户ID,并判断是否为好友
 	from, _ := ctx.Get("uid")
-	if err := mysql.IsFriend(from.(int64), req.To); !errors.Is(err, mysql.ErrorIsFriend) {
+	if err := logic.IsFriend(from.(int64), req.To); err != nil {
 		zap.L().Error("is friend failed, err: ", zap.Error(err))
 		ResponseError(ctx, CodeIsNotFriend)
 		return
@@ -256,7 +259,7 @@ func (c *MessageController) SendFileMessageHandler(ctx *gin.Context) {
 
 	// 获取当前用户ID,并判断是否为好友
 	from, _ := ctx.Get("uid")
-	if err := mysql.IsFriend(from.(int64), req.To); !errors.Is(err, mysql.ErrorIsFriend) {
+	if err := logic.IsFriend(from.(int64), req.To); err != nil {
 		zap.L().Error("is friend failed, err: ", zap.Error(err))
 		ResponseError(ctx, CodeIsNotFriend)
 		return
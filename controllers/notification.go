@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/logic"
+	"strconv"
+)
+
+// GetNotificationsHandler 获取通知列表
+// @Summary 获取通知列表
+// @Description 按时间倒序获取站内通知(每次20条)
+// @Tags 通知
+// @Produce json
+// @Security ApiKeyAuth
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamNotificationItem}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /notifications [get]
+func GetNotificationsHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	notifications, err := logic.GetNotifications(userID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetNotifications failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, notifications)
+}
+
+// GetUnreadNotificationsHandler 获取未读通知数
+// @Summary 获取未读通知数
+// @Description 获取当前用户的未读通知数
+// @Tags 通知
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response "{"count":未读数量}"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /notifications/unread [get]
+func GetUnreadNotificationsHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	count, err := logic.CountUnreadNotifications(userID)
+	if err != nil {
+		zap.L().Error("logic.CountUnreadNotifications failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, gin.H{
+		"count": count,
+	})
+}
+
+// MarkNotificationsReadHandler 将全部通知标记为已读
+// @Summary 标记通知已读
+// @Description 将当前用户的全部通知标记为已读
+// @Tags 通知
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response "已全部标记为已读"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /notifications/read [put]
+func MarkNotificationsReadHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	if err := logic.MarkNotificationsRead(userID); err != nil {
+		zap.L().Error("logic.MarkNotificationsRead failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "已全部标记为已读")
+}
+
+// GetMemoriesHandler 那年今日
+// @Summary 那年今日
+// @Description 获取往年今天(最多回溯20年)自己发布的动态与添加的好友，按年份倒序排列，已删除的动态不返回；平年的2月28日同时展示往年2月29日的回忆。在设置中关闭那年今日后返回空结果
+// @Tags 动态
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=models.ParamMemories}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /memories [get]
+func GetMemoriesHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	memories, err := logic.GetMemories(userID)
+	if err != nil {
+		zap.L().Error("logic.GetMemories failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, memories)
+}
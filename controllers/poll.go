@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"gosocial/models"
+	"strconv"
+	"time"
+)
+
+// VotePollHandler 投票
+// @Summary 投票
+// @Description 对自己可见的动态中的投票进行投票，每人只能投一次且不可修改；投票后返回包含结果的最新投票信息
+// @Tags 投票
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Param data body models.ParamPollVoteRequest true "选择的选项ID"
+// @Success 200 {object} models.Response{data=models.ParamPoll}
+// @Failure 400 {object} models.Response "参数错误/选项无效/投票已截止/已经投过票"
+// @Failure 404 {object} models.Response "动态或投票不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/poll/votes [post]
+func VotePollHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 参数校验
+	var req models.ParamPollVoteRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("VotePoll with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	optionIDs, err := parseIDs(req.OptionIDs)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	poll, err := logic.VotePoll(userID, postID, optionIDs)
+	if err != nil {
+		handlePollError(c, err)
+		return
+	}
+	ResponseSuccess(c, poll)
+}
+
+// GetPollVotersHandler 投票人列表
+// @Summary 投票人列表
+// @Description 按投票时间倒序获取实名投票的投票人(每次20条)，需已投票或投票已截止；匿名投票不公开投票人
+// @Tags 投票
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Param option_id query string false "选项ID，不传则返回全部投票人"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamPollVoter}
+// @Failure 400 {object} models.Response "参数错误/选项无效/未投票/匿名投票"
+// @Failure 404 {object} models.Response "动态或投票不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/poll/voters [get]
+func GetPollVotersHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID、选项ID与偏移量
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	optionID, err := strconv.ParseInt(c.DefaultQuery("option_id", "0"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	voters, err := logic.GetPollVoters(userID, postID, optionID, offset)
+	if err != nil {
+		handlePollError(c, err)
+		return
+	}
+	ResponseSuccess(c, voters)
+}
+
+// parsePollForm 解析发布动态表单中的投票参数，未传选项时返回nil
+func parsePollForm(c *gin.Context) (*models.ParamPollRequest, error) {
+	options := c.PostFormArray("poll_options")
+	if len(options) == 0 {
+		return nil, nil
+	}
+	poll := &models.ParamPollRequest{Options: options}
+	var err error
+	if poll.Multiple, err = strconv.ParseBool(c.DefaultPostForm("poll_multiple", "false")); err != nil {
+		return nil, err
+	}
+	if poll.Anonymous, err = strconv.ParseBool(c.DefaultPostForm("poll_anonymous", "false")); err != nil {
+		return nil, err
+	}
+	if s := c.PostForm("poll_deadline"); s != "" {
+		deadline, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, err
+		}
+		poll.Deadline = &deadline
+	}
+	return poll, nil
+}
+
+// handlePollError 将投票相关错误转换为响应码
+func handlePollError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, mysql.ErrorPostNotExist):
+		ResponseError(c, CodePostNotExist)
+	case errors.Is(err, mysql.ErrorPollNotExist):
+		ResponseError(c, CodePollNotExist)
+	case errors.Is(err, mysql.ErrorPollClosed):
+		ResponseError(c, CodePollClosed)
+	case errors.Is(err, mysql.ErrorAlreadyVoted):
+		ResponseError(c, CodeAlreadyVoted)
+	case errors.Is(err, mysql.ErrorInvalidPollOption):
+		ResponseError(c, CodeInvalidPollOption)
+	case errors.Is(err, mysql.ErrorPollResultHidden):
+		ResponseError(c, CodePollResultHidden)
+	case errors.Is(err, mysql.ErrorPollAnonymous):
+		ResponseError(c, CodePollAnonymous)
+	default:
+		zap.L().Error("poll operation failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+	}
+}
@@ -2,26 +2,57 @@ package controllers
 
 import (
 	"errors"
-	"fmt"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"gosocial/dao/mysql"
 	"gosocial/logic"
-	"path/filepath"
+	"gosocial/models"
+	"gosocial/pkg/imageproc"
+	"mime/multipart"
 	"strconv"
-	"strings"
-	"time"
 )
 
+const maxPostImageSize = 10 << 20 // 动态单张图片大小上限(10MB)
+
+// readPostImageFiles 读取上传的动态图片，数量或大小超限时直接返回错误响应
+func readPostImageFiles(c *gin.Context, files []*multipart.FileHeader) ([][]byte, bool) {
+	if len(files) > 9 {
+		ResponseError(c, CodeTooManyImages)
+		return nil, false
+	}
+	imageData := make([][]byte, 0, len(files))
+	for _, file := range files {
+		if file.Size > maxPostImageSize {
+			ResponseErrorWithMsg(c, CodeInvalidParam, "单张图片不能超过10MB")
+			return nil, false
+		}
+		data, err := readFormFile(file)
+		if err != nil {
+			zap.L().Error("readFormFile failed", zap.Error(err))
+			ResponseError(c, CodeServerBusy)
+			return nil, false
+		}
+		imageData = append(imageData, data)
+	}
+	return imageData, true
+}
+
+// isInvalidImage 判断是否为图片格式或尺寸不合法
+func isInvalidImage(err error) bool {
+	return errors.Is(err, imageproc.ErrUnsupportedFormat) || errors.Is(err, imageproc.ErrImageTooLarge)
+}
+
 // GetFriendPostsHandler 获取所有好友动态列表(类QQ个人空间)
 // @Summary 获取好友动态列表
-// @Description 按时间倒序获取所有好友的动态(每次10条)
+// @Description 获取所有好友的动态及关注用户的公开动态(每次10条)，使用游标分页。默认按时间倒序；mode=ranked时按发布时间衰减、点赞、评论、浏览量与好友亲密度综合排序，首页生成的排序快照保留30分钟，期间翻页顺序不变，过期后需从首页重新加载
 // @Tags 动态
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param offset query int false "偏移量(默认0)"
-// @Success 200 {object} models.Response{data=[]models.ParamPostWithUserInfo} "成功获取动态列表"
+// @Param mode query string false "排序方式(latest:按时间倒序 ranked:按热度排序，默认latest)"
+// @Param cursor query string false "分页游标(取上一页返回的next_cursor，首页为空)"
+// @Success 200 {object} models.Response{data=models.ParamPostPage} "成功获取动态列表"
+// @Failure 400 {object} models.Response "参数错误/排序已过期"
 // @Failure 401 {object} models.Response "未授权"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /api/v1/posts [get]
@@ -29,14 +60,28 @@ func GetFriendPostsHandler(c *gin.Context) {
 	// 获取当前用户ID
 	userID := c.MustGet("uid").(int64)
 
-	// 获取偏移量
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
 	// 调用逻辑层获取数据
-	posts, err := logic.GetFriendPosts(userID, offset)
+	var page *models.ParamPostPage
+	var err error
+	switch c.DefaultQuery("mode", logic.FeedModeLatest) {
+	case logic.FeedModeLatest:
+		page, err = logic.GetFriendPosts(userID, c.Query("cursor"))
+	case logic.FeedModeRanked:
+		page, err = logic.GetRankedFeed(userID, c.Query("cursor"))
+	default:
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
 	if err != nil {
-		zap.L().Error("logic.GetFriendPosts failed", zap.Error(err))
-		ResponseError(c, CodeServerBusy)
+		zap.L().Error("get friend posts failed", zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorInvalidCursor):
+			ResponseError(c, CodeInvalidParam)
+		case errors.Is(err, mysql.ErrorFeedExpired):
+			ResponseError(c, CodeFeedExpired)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
 		return
 	}
 
@@ -49,12 +94,12 @@ func GetFriendPostsHandler(c *gin.Context) {
 	//	}
 	//}
 
-	ResponseSuccess(c, posts)
+	ResponseSuccess(c, page)
 }
 
 // GetUserPostsHandler 获取指定用户动态列表
 // @Summary 获取指定用户动态列表
-// @Description 按时间倒序获取指定用户的动态(每次10条)
+// @Description 按时间倒序获取指定用户的动态(每次10条)，非好友仅能查看公开动态
 // @Tags 动态
 // @Accept json
 // @Produce json
@@ -64,15 +109,15 @@ func GetFriendPostsHandler(c *gin.Context) {
 // @Success 200 {object} models.Response{data=[]models.ParamPostWithUserInfo} "成功获取用户动态"
 // @Failure 400 {object} models.Response "参数错误"
 // @Failure 401 {object} models.Response "未授权"
-// @Failure 403 {object} models.Response "非好友关系"
+// @Failure 403 {object} models.Response "存在拉黑关系"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /api/v1/posts/{user_id} [get]
 func GetUserPostsHandler(c *gin.Context) {
 	// 获取当前用户ID
 	currentUserID := c.MustGet("uid").(int64)
 
-	// 获取目标用户ID
-	targetUserID, _ := strconv.ParseInt(c.Param("uid"), 10, 64)
+	// 获取目标用户ID(路由与动态子资源共用通配符:id，此处表示用户ID)
+	targetUserID, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 
 	// 获取偏移量
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
@@ -80,9 +125,9 @@ func GetUserPostsHandler(c *gin.Context) {
 	// 调用逻辑层获取数据
 	posts, err := logic.GetUserPosts(currentUserID, targetUserID, offset)
 	if err != nil {
-		if errors.Is(err, mysql.ErrorIsNotFriend) {
-			zap.L().Error("The user is not current user's friend", zap.Error(err))
-			ResponseError(c, CodeIsNotFriend)
+		if errors.Is(err, mysql.ErrorBlocked) {
+			zap.L().Error("The user is blocked", zap.Error(err))
+			ResponseError(c, CodeBlocked)
 			return
 		}
 		zap.L().Error("logic.GetUserPosts failed", zap.Error(err))
@@ -104,7 +149,7 @@ func GetUserPostsHandler(c *gin.Context) {
 
 // IncrementPostViewHandler 增加动态浏览量
 // @Summary 增加动态浏览量
-// @Description 每次浏览动态时增加1次浏览量
+// @Description 浏览动态时调用，同一用户24小时内重复浏览只计一次，并记录访客(可在设置中关闭)
 // @Tags 动态
 // @Accept json
 // @Produce json
@@ -113,9 +158,13 @@ func GetUserPostsHandler(c *gin.Context) {
 // @Success 200 {object} models.Response "操作成功"
 // @Failure 400 {object} models.Response "参数错误"
 // @Failure 401 {object} models.Response "未授权"
+// @Failure 404 {object} models.Response "动态不存在"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /api/v1/posts/{id}/view [put]
 func IncrementPostViewHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
 	// 获取动态ID
 	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
@@ -124,10 +173,14 @@ func IncrementPostViewHandler(c *gin.Context) {
 	}
 
 	// 调用逻辑层更新浏览量
-	if err = logic.IncrementPostViewCount(postID); err != nil {
+	if err = logic.IncrementPostViewCount(userID, postID); err != nil {
 		zap.L().Error("logic.IncrementPostViewCount failed",
 			zap.Int64("post_id", postID),
 			zap.Error(err))
+		if errors.Is(err, mysql.ErrorPostNotExist) {
+			ResponseError(c, CodePostNotExist)
+			return
+		}
 		ResponseError(c, CodeServerBusy)
 		return
 	}
@@ -135,6 +188,68 @@ func IncrementPostViewHandler(c *gin.Context) {
 	ResponseSuccess(c, "浏览量更新成功")
 }
 
+// GetPostVisitorsHandler 获取动态访客列表
+// @Summary 获取动态访客列表
+// @Description 作者按最近浏览时间倒序查看某条动态的访客(每次20条)
+// @Tags 动态
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamVisitorItem}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/visitors [get]
+func GetPostVisitorsHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID与偏移量
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	visitors, err := logic.GetPostVisitors(userID, postID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetPostVisitors failed", zap.Int64("post_id", postID), zap.Error(err))
+		if errors.Is(err, mysql.ErrorPostNotExist) {
+			ResponseError(c, CodePostNotExist)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, visitors)
+}
+
+// GetMyPostVisitorsHandler 获取谁看过我的动态
+// @Summary 谁看过我
+// @Description 按最近浏览时间倒序获取自己所有动态的访客记录(每次20条)
+// @Tags 动态
+// @Produce json
+// @Security ApiKeyAuth
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamVisitorItem}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/visitors [get]
+func GetMyPostVisitorsHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	visitors, err := logic.GetMyPostVisitors(userID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetMyPostVisitors failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, visitors)
+}
+
 // CreatePostHandler 创建用户动态
 // @Summary 创建用户动态
 // @Description 创建用户动态(支持纯文本、纯图片、图文混合)
@@ -142,10 +257,17 @@ func IncrementPostViewHandler(c *gin.Context) {
 // @Accept multipart/form-data
 // @Produce json
 // @Security ApiKeyAuth
-// @Param content formData string false "文字内容(不超过500字)"
-// @Param images formData []file false "图片文件(最多9张,支持jpg/jpeg/png)"
+// @Param content formData string false "文字内容(不超过500字，支持 #话题# 与 @用户名)"
+// @Param images formData []file false "图片文件(最多9张,单张不超过10MB,支持jpg/png/gif/webp,上传后去除EXIF/GPS信息并生成中图与缩略图)"
+// @Param visibility formData string false "可见范围(public:公开 friends:仅好友 private:仅自己 allow:部分可见 deny:不给谁看，默认friends)"
+// @Param user_ids formData string false "visibility为allow/deny时的好友ID，多个用逗号分隔"
+// @Param group_ids formData string false "visibility为allow/deny时的好友分组ID，多个用逗号分隔"
+// @Param poll_options formData []string false "投票选项(2~10个，每项不超过50字)，不传则不附带投票" collectionFormat(multi)
+// @Param poll_multiple formData bool false "投票是否多选(默认单选)"
+// @Param poll_anonymous formData bool false "是否匿名投票(默认实名)"
+// @Param poll_deadline formData string false "投票截止时间(RFC3339)，不传则不截止"
 // @Success 200 {object} models.Response{data=models.Post} "动态创建成功"
-// @Failure 400 {object} models.Response "参数错误/图片格式错误/图片过多"
+// @Failure 400 {object} models.Response "参数错误/图片格式错误/图片过多/投票无效/内容包含违规词语"
 // @Failure 401 {object} models.Response "未授权"
 // @Failure 413 {object} models.Response "文件过大"
 // @Failure 500 {object} models.Response "服务器内部错误"
@@ -156,6 +278,37 @@ func CreatePostHandler(c *gin.Context) {
 
 	// 获取表单数据
 	content := c.PostForm("content")
+	visibility := c.DefaultPostForm("visibility", models.PostVisibilityFriends)
+	if !models.IsValidPostVisibility(visibility) {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	audienceUserIDs, err := parseIDList(c.PostForm("user_ids"))
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	audienceGroupIDs, err := parseIDList(c.PostForm("group_ids"))
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	poll, err := parsePollForm(c)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	// 敏感词过滤
+	if !filterText(c, userID, models.ContentScenePost, &content) {
+		return
+	}
+	if poll != nil {
+		for i := range poll.Options {
+			if !filterText(c, userID, models.ContentScenePost, &poll.Options[i]) {
+				return
+			}
+		}
+	}
 	form, err := c.MultipartForm()
 	if err != nil {
 		zap.L().Error("c.MultipartForm failed", zap.Error(err))
@@ -169,40 +322,34 @@ func CreatePostHandler(c *gin.Context) {
 		return
 	}
 
-	// 处理图片上传
-	var imageURLs []string
-	files := form.File["images"]
-	if len(files) > 9 {
-		ResponseError(c, CodeTooManyImages)
+	// 处理图片上传：按文件内容校验真实格式，不信任客户端文件名
+	imageData, ok := readPostImageFiles(c, form.File["images"])
+	if !ok {
 		return
 	}
-
-	for _, file := range files {
-		// 检查图片格式
-		ext := filepath.Ext(file.Filename)
-		if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
+	images, err := logic.SavePostImages(userID, imageData)
+	if err != nil {
+		if isInvalidImage(err) {
 			ResponseError(c, CodeInvalidImageFormat)
 			return
 		}
-
-		// 生成唯一文件名
-		filename := fmt.Sprintf("%d_%s", time.Now().UnixNano(), file.Filename)
-		dst := filepath.Join("static", "images", filename)
-
-		// 保存文件
-		if err = c.SaveUploadedFile(file, dst); err != nil {
-			zap.L().Error("c.SaveUploadedFile failed", zap.Error(err))
-			ResponseError(c, CodeServerBusy)
-			return
-		}
-
-		imageURLs = append(imageURLs, "/static/images/"+filename)
+		zap.L().Error("logic.SavePostImages failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
 	}
 
 	// 调用逻辑层创建动态
-	post, err := logic.CreatePost(userID, content, strings.Join(imageURLs, ","))
+	post, err := logic.CreatePost(userID, content, images, visibility, audienceUserIDs, audienceGroupIDs, poll)
 	if err != nil {
 		zap.L().Error("logic.CreatePost failed", zap.Error(err))
+		if errors.Is(err, mysql.ErrorInvalidAudience) {
+			ResponseError(c, CodeInvalidAudience)
+			return
+		}
+		if errors.Is(err, mysql.ErrorInvalidPoll) {
+			ResponseError(c, CodeInvalidPoll)
+			return
+		}
 		ResponseError(c, CodeServerBusy)
 		return
 	}
@@ -211,7 +358,7 @@ func CreatePostHandler(c *gin.Context) {
 
 // DeletePostHandler 删除动态
 // @Summary 删除动态
-// @Description 删除用户自己的动态
+// @Description 删除用户自己的动态，删除后进入回收站，30天内可恢复
 // @Tags 动态
 // @Accept json
 // @Produce json
@@ -220,6 +367,8 @@ func CreatePostHandler(c *gin.Context) {
 // @Success 200 {object} models.Response "删除成功"
 // @Failure 400 {object} models.Response "参数错误"
 // @Failure 401 {object} models.Response "未授权"
+// @Failure 403 {object} models.Response "只能删除自己的动态"
+// @Failure 404 {object} models.Response "动态不存在"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /api/v1/posts/{id} [delete]
 func DeletePostHandler(c *gin.Context) {
@@ -232,15 +381,323 @@ func DeletePostHandler(c *gin.Context) {
 		ResponseError(c, CodeInvalidParam)
 		return
 	}
-	if userID != postID {
-		zap.L().Error("cannot delete others post", zap.Int64("user_id", userID))
-		ResponseError(c, CodeCannotDeleteOthersPost)
-	}
 	// 调用逻辑层删除动态
-	if err = logic.DeletePostByUser(userID); err != nil {
-		ResponseError(c, CodeServerBusy)
+	if err = logic.DeletePost(userID, postID); err != nil {
+		zap.L().Error("logic.DeletePost failed", zap.Int64("post_id", postID), zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorPostNotExist):
+			ResponseError(c, CodePostNotExist)
+		case errors.Is(err, mysql.ErrorCannotDeleteOthersPost):
+			ResponseError(c, CodeCannotDeleteOthersPost)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
 		return
 	}
 
 	ResponseSuccess(c, "动态删除成功")
 }
+
+// EditPostHandler 编辑动态
+// @Summary 编辑动态
+// @Description 编辑自己动态的文字内容，编辑前的内容保存到编辑历史
+// @Tags 动态
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Param data body models.ParamUpdatePostRequest true "新的文字内容"
+// @Success 200 {object} models.Response "编辑成功"
+// @Failure 400 {object} models.Response "参数错误/内容包含违规词语"
+// @Failure 403 {object} models.Response "只能修改自己的动态"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id} [put]
+func EditPostHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 参数校验
+	var req models.ParamUpdatePostRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("EditPost with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	if !filterText(c, userID, models.ContentScenePost, &req.Content) {
+		return
+	}
+
+	if err = logic.EditPost(userID, postID, req.Content); err != nil {
+		zap.L().Error("logic.EditPost failed", zap.Int64("post_id", postID), zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorPostNotExist):
+			ResponseError(c, CodePostNotExist)
+		case errors.Is(err, mysql.ErrorCannotEditOthersPost):
+			ResponseError(c, CodeCannotEditOthersPost)
+		case errors.Is(err, mysql.ErrorInvalidParam):
+			ResponseError(c, CodeInvalidParam)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
+		return
+	}
+	ResponseSuccess(c, "编辑成功")
+}
+
+// GetPostEditHistoryHandler 获取动态编辑历史
+// @Summary 获取动态编辑历史
+// @Description 按编辑时间倒序获取动态每次编辑前的内容
+// @Tags 动态
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Success 200 {object} models.Response{data=[]models.ParamPostEditItem}
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/history [get]
+func GetPostEditHistoryHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	edits, err := logic.GetPostEditHistory(userID, postID)
+	if err != nil {
+		zap.L().Error("logic.GetPostEditHistory failed", zap.Int64("post_id", postID), zap.Error(err))
+		if errors.Is(err, mysql.ErrorPostNotExist) {
+			ResponseError(c, CodePostNotExist)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, edits)
+}
+
+// GetTrashPostsHandler 获取回收站动态列表
+// @Summary 获取回收站动态列表
+// @Description 按删除时间倒序获取30天内删除的动态(每次10条)
+// @Tags 动态
+// @Produce json
+// @Security ApiKeyAuth
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamTrashPostItem}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/trash [get]
+func GetTrashPostsHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	posts, err := logic.GetTrashPosts(userID, offset)
+	if err != nil {
+		zap.L().Error("logic.GetTrashPosts failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, posts)
+}
+
+// RestorePostHandler 恢复动态
+// @Summary 恢复动态
+// @Description 从回收站恢复自己删除的动态
+// @Tags 动态
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Success 200 {object} models.Response "恢复成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/restore [post]
+func RestorePostHandler(c *gin.Context) {
+	handleTrashPost(c, logic.RestorePost, "恢复成功")
+}
+
+// PurgePostHandler 彻底删除动态
+// @Summary 彻底删除动态
+// @Description 彻底删除回收站中的动态，删除后不可恢复
+// @Tags 动态
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Success 200 {object} models.Response "彻底删除成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/purge [delete]
+func PurgePostHandler(c *gin.Context) {
+	handleTrashPost(c, logic.PurgePost, "彻底删除成功")
+}
+
+// handleTrashPost 处理回收站中单条动态的操作
+func handleTrashPost(c *gin.Context, op func(userID, postID int64) error, msg string) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = op(userID, postID); err != nil {
+		zap.L().Error("trash post operation failed", zap.Int64("post_id", postID), zap.Error(err))
+		if errors.Is(err, mysql.ErrorPostNotExist) {
+			ResponseError(c, CodePostNotExist)
+			return
+		}
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, msg)
+}
+
+// UpdatePostVisibilityHandler 修改动态可见范围
+// @Summary 修改动态可见范围
+// @Description 作者修改已发布动态的可见范围，allow/deny时需同时提供好友或好友分组名单
+// @Tags 动态
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "动态ID"
+// @Param data body models.ParamUpdatePostVisibilityRequest true "可见范围"
+// @Success 200 {object} models.Response "修改成功"
+// @Failure 400 {object} models.Response "参数错误/可见名单无效"
+// @Failure 403 {object} models.Response "只能修改自己的动态/动态已被管理员隐藏"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/visibility [put]
+func UpdatePostVisibilityHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 参数校验
+	var req models.ParamUpdatePostVisibilityRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("UpdatePostVisibility with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	userIDs, err := parseIDs(req.UserIDs)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	groupIDs, err := parseIDs(req.GroupIDs)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err = logic.UpdatePostVisibility(userID, postID, req.Visibility, userIDs, groupIDs); err != nil {
+		zap.L().Error("logic.UpdatePostVisibility failed", zap.Int64("post_id", postID), zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorPostNotExist):
+			ResponseError(c, CodePostNotExist)
+		case errors.Is(err, mysql.ErrorCannotEditOthersPost):
+			ResponseError(c, CodeCannotEditOthersPost)
+		case errors.Is(err, mysql.ErrorInvalidAudience):
+			ResponseError(c, CodeInvalidAudience)
+		case errors.Is(err, mysql.ErrorRepostWidenAudience):
+			ResponseError(c, CodeRepostWidenAudience)
+		case errors.Is(err, mysql.ErrorPostHidden):
+			ResponseError(c, CodePostHidden)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
+		return
+	}
+	ResponseSuccess(c, "修改成功")
+}
+
+// RepostPostHandler 转发动态
+// @Summary 转发动态
+// @Description 转发自己可见的动态并可附带评语，转发的转发会引用最初的原动态；原动态非公开时转发不能设置为公开，
+// @Description 查看转发的用户需能看到原动态才会展示原文，否则显示"内容无法查看"占位
+// @Tags 动态
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "原动态ID"
+// @Param data body models.ParamRepostRequest true "转发评语与可见范围"
+// @Success 200 {object} models.Response{data=models.Post} "转发成功"
+// @Failure 400 {object} models.Response "参数错误/可见名单无效/不能扩大可见范围/内容包含违规词语"
+// @Failure 401 {object} models.Response "未授权"
+// @Failure 404 {object} models.Response "动态不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /posts/{id}/repost [post]
+func RepostPostHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取动态ID
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 参数校验
+	var req models.ParamRepostRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("RepostPost with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	if !filterText(c, userID, models.ContentScenePost, &req.Content) {
+		return
+	}
+	if req.Visibility == "" {
+		req.Visibility = models.PostVisibilityFriends
+	}
+	userIDs, err := parseIDs(req.UserIDs)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	groupIDs, err := parseIDs(req.GroupIDs)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	post, err := logic.RepostPost(userID, postID, req.Content, req.Visibility, userIDs, groupIDs)
+	if err != nil {
+		zap.L().Error("logic.RepostPost failed", zap.Int64("post_id", postID), zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorPostNotExist):
+			ResponseError(c, CodePostNotExist)
+		case errors.Is(err, mysql.ErrorInvalidAudience):
+			ResponseError(c, CodeInvalidAudience)
+		case errors.Is(err, mysql.ErrorRepostWidenAudience):
+			ResponseError(c, CodeRepostWidenAudience)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
+		return
+	}
+	ResponseSuccess(c, post)
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+	"gosocial/models"
+	"strconv"
+)
+
+// CreateReportHandler 举报
+// @Summary 举报动态、私信或账号
+// @Description 举报自己可见的动态、别人发给自己的私信或其他账号，同一对象的举报归入同一个审核工单，每人只能举报一次；举报时保存内容快照，私信额外保存前后的聊天记录
+// @Tags 举报
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body models.ParamReportRequest true "举报对象与原因；举报私信时target_id为发送者ID，message_at为私信的发送时间"
+// @Success 200 {object} models.Response
+// @Failure 400 {object} models.Response "参数错误/不能举报自己/已经举报过"
+// @Failure 404 {object} models.Response "动态、私信或用户不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /reports [post]
+func CreateReportHandler(c *gin.Context) {
+	// 获取当前用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 参数校验
+	var req models.ParamReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("CreateReport with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+	targetID, err := strconv.ParseInt(req.TargetID, 10, 64)
+	if err != nil {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	err = logic.CreateReport(userID, req.TargetType, targetID, req.MessageAt, req.Reason, req.Detail)
+	if err != nil {
+		zap.L().Error("logic.CreateReport failed", zap.String("target_type", req.TargetType),
+			zap.Int64("target_id", targetID), zap.Error(err))
+		switch {
+		case errors.Is(err, mysql.ErrorInvalidParam):
+			ResponseError(c, CodeInvalidParam)
+		case errors.Is(err, mysql.ErrorCannotReportSelf):
+			ResponseError(c, CodeCannotReportSelf)
+		case errors.Is(err, mysql.ErrorAlreadyReported):
+			ResponseError(c, CodeAlreadyReported)
+		case errors.Is(err, mysql.ErrorPostNotExist):
+			ResponseError(c, CodePostNotExist)
+		case errors.Is(err, mysql.ErrorUserNotExist):
+			ResponseError(c, CodeUserNotExist)
+		case errors.Is(err, mysql.ErrorMessageNotExist):
+			ResponseError(c, CodeMessageNotExist)
+		default:
+			ResponseError(c, CodeServerBusy)
+		}
+		return
+	}
+	ResponseSuccess(c, "举报成功，我们会尽快处理")
+}
@@ -1,3 +1,60 @@
 package controllers
 
+import (
+	"github.com/gin-gonic/gin"
+	"gosocial/logic"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"strings"
+)
+
 const CtxUserIDKey = "uid"
+
+// parseIDs 将字符串形式的ID列表解析为int64
+func parseIDs(ids []string) ([]int64, error) {
+	result := make([]int64, 0, len(ids))
+	for _, s := range ids {
+		id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+// parseIDList 解析逗号分隔的ID列表，空字符串返回空列表
+func parseIDList(s string) ([]int64, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	return parseIDs(strings.Split(s, ","))
+}
+
+// readFormFile 读取上传文件的全部内容
+func readFormFile(fh *multipart.FileHeader) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// filterText 对用户输入做敏感词过滤，过滤结果写回原字段
+// 命中拒绝类敏感词时返回错误响应，调用方应直接返回
+func filterText(c *gin.Context, userID int64, scene string, texts ...*string) bool {
+	for _, text := range texts {
+		if *text == "" {
+			continue
+		}
+		filtered, err := logic.FilterText(userID, scene, *text)
+		if err != nil {
+			ResponseError(c, CodeSensitiveContent)
+			return false
+		}
+		*text = filtered
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/logic"
+	"gosocial/models"
+)
+
+// GetUserSettingHandler 获取用户设置
+// @Summary 获取用户设置
+// @Description 获取当前登录用户的隐私与偏好设置
+// @Tags 用户管理
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.Response{data=models.UserSetting}
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /user/settings [get]
+func GetUserSettingHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+
+	setting, err := logic.GetUserSetting(userID)
+	if err != nil {
+		zap.L().Error("logic.GetUserSetting failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, setting)
+}
+
+// UpdateUserSettingHandler 更新用户设置
+// @Summary 更新用户设置
+// @Description 更新当前登录用户的隐私与偏好设置，未传字段保持不变
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body models.ParamUpdateUserSettingRequest true "设置参数"
+// @Success 200 {object} models.Response "更新成功"
+// @Failure 400 {object} models.Response "参数格式错误"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /user/settings [put]
+func UpdateUserSettingHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 解析请求参数
+	var req models.ParamUpdateUserSettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("UpdateUserSetting with invalid param", zap.Error(err))
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	if err := logic.UpdateUserSetting(userID, req); err != nil {
+		zap.L().Error("logic.UpdateUserSetting failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, "设置更新成功")
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/logic"
+)
+
+// GetTopicPostsHandler 获取话题下的动态
+// @Summary 话题动态列表
+// @Description 按时间倒序获取话题下当前用户可见的动态(每次10条)，使用游标分页；动态内容中的 #话题# 会自动关联话题
+// @Tags 话题
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param name path string true "话题名称(不含#)"
+// @Param cursor query string false "分页游标(取上一页返回的next_cursor，首页为空)"
+// @Success 200 {object} models.Response{data=models.ParamTopicPage} "成功"
+// @Failure 400 {object} models.Response "参数错误"
+// @Failure 401 {object} models.Response "未授权"
+// @Failure 404 {object} models.Response "话题不存在"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /topics/{name}/posts [get]
+func GetTopicPostsHandler(c *gin.Context) {
+	userID := c.MustGet("uid").(int64)
+	page, err := logic.GetTopicPosts(userID, c.Param("name"), c.Query("cursor"))
+	if err != nil {
+		switch {
+		case errors.Is(err, mysql.ErrorInvalidCursor):
+			ResponseError(c, CodeInvalidParam)
+		case errors.Is(err, mysql.ErrorTopicNotExist):
+			ResponseError(c, CodeTopicNotExist)
+		default:
+			zap.L().Error("logic.GetTopicPosts failed", zap.Error(err))
+			ResponseError(c, CodeServerBusy)
+		}
+		return
+	}
+	ResponseSuccess(c, page)
+}
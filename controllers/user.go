@@ -11,6 +11,7 @@ import (
 	"gosocial/logic"
 	"gosocial/models"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -24,7 +25,7 @@ import (
 // @Param   body  body   models.ParamRegister  true  "注册参数"
 // @Security ApiKeyAuth
 // @Success 200 {object}  int "用户uid"
-// @Failure 400 {object}  models.Response "参数格式错误（具体错误字段提示）"
+// @Failure 400 {object}  models.Response "参数格式错误（具体错误字段提示）/内容包含违规词语"
 // @Failure 422 {object}  models.Response "该邮箱已被注册"
 // @Failure 500 {object}  models.Response "服务器内部错误"
 // @Router /api/v1/register [post]
@@ -45,6 +46,9 @@ func RegisterHandler(c *gin.Context) {
 		ResponseErrorWithMsg(c, CodeInvalidParam, removeTopStruct(errs.Translate(trans))) //翻译错误
 		return
 	}
+	if !filterText(c, 0, models.ContentSceneUsername, &user.Username) {
+		return
+	}
 	//2.业务处理
 	var uid int64
 	if uid, err = logic.RegisterLogic(user); err != nil {
@@ -106,6 +110,9 @@ func LoginHandler(c *gin.Context) {
 		} else if errors.Is(err, mysql.ErrorInvalidPassword) {
 			ResponseError(c, CodeInvalidPassword)
 			return
+		} else if errors.Is(err, mysql.ErrorUserSuspended) {
+			ResponseError(c, CodeUserSuspended)
+			return
 		}
 		ResponseError(c, CodeInvalidParam)
 		return
@@ -188,7 +195,7 @@ func GetUserInfoHandler(c *gin.Context) {
 // @Param   body  body   models.ParamUpdateUserInfoRequest  true  "更新参数"
 // @Security ApiKeyAuth
 // @Success 200 {object} models.Response "更新成功"
-// @Failure 400 {object} models.Response "参数格式错误"
+// @Failure 400 {object} models.Response "参数格式错误/内容包含违规词语"
 // @Failure 401 {object} models.Response "未授权"
 // @Failure 500 {object} models.Response "服务器内部错误"
 // @Router /api/v1/user/update_info [put]
@@ -208,6 +215,11 @@ func UpdateUserInfoHandler(c *gin.Context) {
 		ResponseError(c, CodeInvalidParam)
 		return
 	}
+	// 敏感词过滤
+	if !filterText(c, userID.(int64), models.ContentSceneUsername, &req.Username) ||
+		!filterText(c, userID.(int64), models.ContentSceneSignature, &req.Signature) {
+		return
+	}
 
 	// 处理生日字段并计算年龄
 	if req.Birthday != "" {
@@ -365,3 +377,37 @@ func UpdatePasswordHandler(c *gin.Context) {
 	}
 	ResponseSuccess(c, "密码修改成功")
 }
+
+// SearchUsersHandler 全站搜索用户
+// @Summary 全站搜索用户
+// @Description 按用户名(完全/前缀/包含)、邮箱(仅完全匹配)或UID(仅完全匹配)搜索用户，按匹配优先级排序(每次10条)，并标记已是好友的用户
+// @Tags 用户管理
+// @Produce json
+// @Security ApiKeyAuth
+// @Param keyword query string true "搜索关键字"
+// @Param offset query int false "偏移量(默认0)"
+// @Success 200 {object} models.Response{data=[]models.ParamUserSearchItem}
+// @Failure 400 {object} models.Response "参数格式错误"
+// @Failure 500 {object} models.Response "服务器内部错误"
+// @Router /users/search [get]
+func SearchUsersHandler(c *gin.Context) {
+	// 获取用户ID
+	userID := c.MustGet("uid").(int64)
+
+	// 获取参数
+	keyword := c.Query("keyword")
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if strings.TrimSpace(keyword) == "" || offset < 0 {
+		ResponseError(c, CodeInvalidParam)
+		return
+	}
+
+	// 调用logic搜索用户
+	users, err := logic.SearchUsers(userID, keyword, offset)
+	if err != nil {
+		zap.L().Error("logic.SearchUsers failed", zap.Error(err))
+		ResponseError(c, CodeServerBusy)
+		return
+	}
+	ResponseSuccess(c, users)
+}
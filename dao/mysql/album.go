@@ -0,0 +1,224 @@
+package mysql
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gosocial/models"
+)
+
+// CreateAlbum 创建相册
+func CreateAlbum(album *models.Album) error {
+	return db.Create(album).Error
+}
+
+// GetAlbumByID 根据ID获取相册
+func GetAlbumByID(albumID int64) (*models.Album, error) {
+	var album models.Album
+	err := db.Where("id = ?", albumID).First(&album).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorAlbumNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &album, nil
+}
+
+// GetAlbumsByUserID 获取用户指定可见范围的相册，按创建时间倒序排序
+func GetAlbumsByUserID(userID int64, visibilities []string) ([]models.Album, error) {
+	var albums []models.Album
+	err := db.Where("user_id = ? AND visibility IN ?", userID, visibilities).
+		Order("id DESC").
+		Find(&albums).Error
+	return albums, err
+}
+
+// UpdateAlbum 修改相册信息
+func UpdateAlbum(albumID int64, updates map[string]interface{}) error {
+	return db.Model(&models.Album{}).Where("id = ?", albumID).Updates(updates).Error
+}
+
+// DeleteAlbum 删除相册及其中的全部照片，返回被删除的照片以便清理文件
+func DeleteAlbum(albumID int64) (photos []models.Photo, err error) {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("album_id = ?", albumID).Find(&photos).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("album_id = ?", albumID).Delete(&models.Photo{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", albumID).Delete(&models.Album{}).Error
+	})
+	return photos, err
+}
+
+// CreatePhotos 批量保存上传到相册的照片
+func CreatePhotos(photos []models.Photo) error {
+	if len(photos) == 0 {
+		return nil
+	}
+	return db.Create(&photos).Error
+}
+
+// GetPhotoByID 根据ID获取照片
+func GetPhotoByID(photoID int64) (*models.Photo, error) {
+	var photo models.Photo
+	err := db.Where("id = ?", photoID).First(&photo).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorPhotoNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+// GetPhotosByIDs 批量获取照片
+func GetPhotosByIDs(photoIDs []int64) ([]models.Photo, error) {
+	var photos []models.Photo
+	if len(photoIDs) == 0 {
+		return photos, nil
+	}
+	err := db.Where("id IN ?", photoIDs).Find(&photos).Error
+	return photos, err
+}
+
+// GetAlbumPhotos 获取相册中的照片，按上传时间倒序排序
+func GetAlbumPhotos(albumID int64, offset, limit int) ([]models.Photo, error) {
+	var photos []models.Photo
+	err := db.Where("album_id = ?", albumID).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&photos).Error
+	return photos, err
+}
+
+// CountAlbumPhotos 批量统计相册中的照片数
+func CountAlbumPhotos(albumIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(albumIDs))
+	if len(albumIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		AlbumID int64
+		Count   int64
+	}
+	err := db.Model(&models.Photo{}).
+		Select("album_id, COUNT(*) AS count").
+		Where("album_id IN ?", albumIDs).
+		Group("album_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		counts[r.AlbumID] = r.Count
+	}
+	return counts, nil
+}
+
+// GetLatestAlbumPhotos 批量获取各相册最新上传的照片，用作未设置封面的相册封面
+func GetLatestAlbumPhotos(albumIDs []int64) (map[int64]models.Photo, error) {
+	latest := make(map[int64]models.Photo, len(albumIDs))
+	if len(albumIDs) == 0 {
+		return latest, nil
+	}
+	var photos []models.Photo
+	err := db.Where("id IN (?)", db.Model(&models.Photo{}).
+		Select("MAX(id)").
+		Where("album_id IN ?", albumIDs).
+		Group("album_id")).
+		Find(&photos).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range photos {
+		latest[p.AlbumID] = p
+	}
+	return latest, nil
+}
+
+// UpdatePhotoCaption 修改照片说明
+func UpdatePhotoCaption(photoID int64, caption string) error {
+	return db.Model(&models.Photo{}).Where("id = ?", photoID).Update("caption", caption).Error
+}
+
+// MovePhotos 将用户自己的照片移动到指定相册，原相册以这些照片为封面时恢复为使用最新的照片
+// matched为属于该用户的照片数(含本就在目标相册中的照片)
+func MovePhotos(userID int64, photoIDs []int64, albumID int64) (matched int64, err error) {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Photo{}).
+			Where("id IN ? AND user_id = ?", photoIDs, userID).
+			Count(&matched).Error
+		if err != nil || matched == 0 {
+			return err
+		}
+		err = tx.Model(&models.Photo{}).
+			Where("id IN ? AND user_id = ?", photoIDs, userID).
+			Update("album_id", albumID).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.Album{}).
+			Where("user_id = ? AND id <> ? AND cover_photo_id IN ?", userID, albumID, photoIDs).
+			Update("cover_photo_id", 0).Error
+	})
+	return matched, err
+}
+
+// DeletePhoto 删除照片，所属相册以该照片为封面时恢复为使用最新的照片
+func DeletePhoto(photo *models.Photo) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Photo{}, photo.ID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Album{}).
+			Where("id = ? AND cover_photo_id = ?", photo.AlbumID, photo.ID).
+			Update("cover_photo_id", 0).Error
+	})
+}
+
+// IncrementPhotoViewCount 照片浏览量加一
+func IncrementPhotoViewCount(photoID int64) error {
+	return db.Model(&models.Photo{}).
+		Where("id = ?", photoID).
+		UpdateColumn("view_count", gorm.Expr("view_count + ?", 1)).Error
+}
+
+// postPhotosQuery 动态相册的查询条件：作者未删除的动态中查看者可见的图片
+// 作者本人可见全部动态，好友按动态可见范围过滤，其他用户仅公开动态可见
+func postPhotosQuery(viewerID, authorID int64, isFriend bool) *gorm.DB {
+	query := db.Model(&models.PostImage{}).
+		Joins("JOIN posts ON posts.id = post_images.post_id").
+		Where("posts.user_id = ? AND posts.deleted_at IS NULL", authorID)
+	switch {
+	case viewerID == authorID:
+		// 作者本人不过滤
+	case isFriend:
+		query = query.Where(friendVisibleSQL, friendVisibleArgs(viewerID))
+	default:
+		query = query.Where("posts.visibility = ?", models.PostVisibilityPublic)
+	}
+	return query
+}
+
+// GetPostPhotos 获取动态相册中查看者可见的图片，按动态发布时间倒序、同一动态内按图片顺序排序
+func GetPostPhotos(viewerID, authorID int64, isFriend bool, offset, limit int) ([]models.PostImage, error) {
+	var images []models.PostImage
+	err := postPhotosQuery(viewerID, authorID, isFriend).
+		Select("post_images.*").
+		Order("posts.created_at DESC, posts.id DESC, post_images.sort").
+		Offset(offset).
+		Limit(limit).
+		Find(&images).Error
+	return images, err
+}
+
+// CountPostPhotos 统计动态相册中查看者可见的图片数
+func CountPostPhotos(viewerID, authorID int64, isFriend bool) (int64, error) {
+	var count int64
+	err := postPhotosQuery(viewerID, authorID, isFriend).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,48 @@
+package mysql
+
+import (
+	"gosocial/models"
+)
+
+// BlockUser 将用户加入黑名单
+func BlockUser(userID, blockedID int64) error {
+	return db.Create(&models.Block{
+		UserID:    userID,
+		BlockedID: blockedID,
+	}).Error
+}
+
+// UnblockUser 将用户移出黑名单
+func UnblockUser(userID, blockedID int64) error {
+	return db.Where("user_id = ? AND blocked_id = ?", userID, blockedID).
+		Delete(&models.Block{}).Error
+}
+
+// HasBlocked 判断userID是否拉黑了blockedID
+func HasBlocked(userID, blockedID int64) (bool, error) {
+	var count int64
+	err := db.Model(&models.Block{}).
+		Where("user_id = ? AND blocked_id = ?", userID, blockedID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// IsBlockedBetween 判断两个用户之间是否存在任意方向的拉黑关系
+func IsBlockedBetween(userID, targetID int64) (bool, error) {
+	var count int64
+	err := db.Model(&models.Block{}).
+		Where("(user_id = ? AND blocked_id = ?) OR (user_id = ? AND blocked_id = ?)",
+			userID, targetID, targetID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetBlockList 获取黑名单列表，按拉黑时间降序排序
+func GetBlockList(userID int64) ([]models.Block, error) {
+	var blocks []models.Block
+	err := db.Preload("Blocked").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&blocks).Error
+	return blocks, err
+}
@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gosocial/models"
+)
+
+// CreateBookmark 收藏动态，重复收藏不报错，created为false表示此前已收藏
+func CreateBookmark(bookmark *models.Bookmark) (created bool, err error) {
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(bookmark)
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteBookmark 取消收藏，deleted为false表示此前未收藏
+func DeleteBookmark(userID, postID int64) (deleted bool, err error) {
+	result := db.Where("user_id = ? AND post_id = ?", userID, postID).
+		Delete(&models.Bookmark{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// MoveBookmark 将收藏移动到指定收藏夹，moved为false表示此前未收藏
+func MoveBookmark(userID, postID, collectionID int64) (moved bool, err error) {
+	var count int64
+	err = db.Model(&models.Bookmark{}).
+		Where("user_id = ? AND post_id = ?", userID, postID).
+		Count(&count).Error
+	if err != nil || count == 0 {
+		return false, err
+	}
+	err = db.Model(&models.Bookmark{}).
+		Where("user_id = ? AND post_id = ?", userID, postID).
+		Update("collection_id", collectionID).Error
+	return err == nil, err
+}
+
+// GetBookmarks 游标分页获取收藏记录，按收藏时间降序排序
+// collectionID为nil时返回全部收藏，为0时返回未分类的收藏；beforeAt为零值时从最新开始
+func GetBookmarks(userID int64, collectionID *int64, beforeAt time.Time, beforeID int64, limit int) ([]models.Bookmark, error) {
+	var bookmarks []models.Bookmark
+	query := db.Where("user_id = ?", userID)
+	if collectionID != nil {
+		query = query.Where("collection_id = ?", *collectionID)
+	}
+	if !beforeAt.IsZero() {
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", beforeAt, beforeAt, beforeID)
+	}
+	err := query.Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&bookmarks).Error
+	return bookmarks, err
+}
+
+// GetBookmarkedPostIDs 从给定动态中筛选出用户已收藏的动态ID
+func GetBookmarkedPostIDs(userID int64, postIDs []int64) ([]int64, error) {
+	var ids []int64
+	if len(postIDs) == 0 {
+		return ids, nil
+	}
+	err := db.Model(&models.Bookmark{}).
+		Where("user_id = ? AND post_id IN ?", userID, postIDs).
+		Pluck("post_id", &ids).Error
+	return ids, err
+}
+
+// CreateCollection 创建收藏夹
+func CreateCollection(collection *models.Collection) error {
+	return db.Create(collection).Error
+}
+
+// GetCollectionByID 通过ID获取收藏夹
+func GetCollectionByID(collectionID int64) (*models.Collection, error) {
+	var collection models.Collection
+	err := db.Where("id = ?", collectionID).First(&collection).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorCollectionNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// GetCollections 获取用户的全部收藏夹
+func GetCollections(userID int64) ([]models.Collection, error) {
+	var collections []models.Collection
+	err := db.Where("user_id = ?", userID).Order("id ASC").Find(&collections).Error
+	return collections, err
+}
+
+// CountCollectionBookmarks 统计用户各收藏夹的收藏数，未分类的收藏计入ID为0的项
+func CountCollectionBookmarks(userID int64) (map[int64]int64, error) {
+	var rows []struct {
+		CollectionID int64
+		Count        int64
+	}
+	err := db.Model(&models.Bookmark{}).
+		Select("collection_id, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("collection_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int64]int64, len(rows))
+	for _, r := range rows {
+		counts[r.CollectionID] = r.Count
+	}
+	return counts, nil
+}
+
+// RenameCollection 修改收藏夹名称
+func RenameCollection(collectionID int64, name string) error {
+	return db.Model(&models.Collection{}).Where("id = ?", collectionID).Update("name", name).Error
+}
+
+// DeleteCollection 删除收藏夹，其中的收藏移回未分类
+func DeleteCollection(collectionID int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Bookmark{}).
+			Where("collection_id = ?", collectionID).
+			Update("collection_id", 0).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Collection{}, collectionID).Error
+	})
+}
@@ -0,0 +1,98 @@
+package mysql
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gosocial/models"
+)
+
+// CreateComment 创建评论
+func CreateComment(comment *models.Comment) error {
+	return db.Create(comment).Error
+}
+
+// GetCommentByID 通过ID获取评论
+func GetCommentByID(commentID int64) (*models.Comment, error) {
+	var comment models.Comment
+	err := db.Where("id = ?", commentID).First(&comment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorCommentNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// DeleteComment 删除评论，删除一级评论时一并删除其下的所有回复
+func DeleteComment(comment *models.Comment) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if comment.RootID == 0 {
+			if err := tx.Where("root_id = ?", comment.ID).Delete(&models.Comment{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&models.Comment{}, comment.ID).Error
+	})
+}
+
+// visibleCommentScope 评论可见范围：评论者是查看者本人、查看者的好友或动态作者
+func visibleCommentScope(viewerID int64) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("comments.user_id = ? OR comments.user_id = posts.user_id OR comments.user_id IN (?)",
+			viewerID,
+			db.Model(&models.Friendship{}).Select("friend_id").Where("user_id = ?", viewerID))
+	}
+}
+
+// GetVisibleCommentByID 获取查看者可见的评论，不存在或不可见时返回ErrorCommentNotExist
+func GetVisibleCommentByID(commentID, viewerID int64) (*models.Comment, error) {
+	var comment models.Comment
+	err := db.Joins("JOIN posts ON posts.id = comments.post_id").
+		Where("comments.id = ?", commentID).
+		Scopes(visibleCommentScope(viewerID)).
+		First(&comment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorCommentNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetVisibleComments 获取动态下查看者可见的评论，按评论时间升序排序
+func GetVisibleComments(postID, viewerID int64, offset, limit int) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := db.Joins("JOIN posts ON posts.id = comments.post_id").
+		Where("comments.post_id = ?", postID).
+		Scopes(visibleCommentScope(viewerID)).
+		Order("comments.created_at ASC, comments.id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&comments).Error
+	return comments, err
+}
+
+// CountVisibleComments 批量统计动态下查看者可见的评论数
+func CountVisibleComments(postIDs []int64, viewerID int64) (map[int64]int64, error) {
+	var rows []struct {
+		PostID int64
+		Count  int64
+	}
+	err := db.Model(&models.Comment{}).
+		Select("comments.post_id, COUNT(*) AS count").
+		Joins("JOIN posts ON posts.id = comments.post_id").
+		Where("comments.post_id IN ?", postIDs).
+		Scopes(visibleCommentScope(viewerID)).
+		Group("comments.post_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int64]int64, len(rows))
+	for _, r := range rows {
+		counts[r.PostID] = r.Count
+	}
+	return counts, nil
+}
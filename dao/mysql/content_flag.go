@@ -0,0 +1,8 @@
+package mysql
+
+import "gosocial/models"
+
+// CreateContentFlag 记录待审核的用户输入
+func CreateContentFlag(flag *models.ContentFlag) error {
+	return db.Create(flag).Error
+}
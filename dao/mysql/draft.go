@@ -0,0 +1,152 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gosocial/models"
+)
+
+// CreateDraft 创建草稿及其图片
+func CreateDraft(draft *models.PostDraft, images []models.PostImage) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(draft).Error; err != nil {
+			return err
+		}
+		return createDraftImages(tx, draft.ID, images)
+	})
+}
+
+// createDraftImages 写入草稿图片
+func createDraftImages(tx *gorm.DB, draftID int64, images []models.PostImage) error {
+	if len(images) == 0 {
+		return nil
+	}
+	for i := range images {
+		images[i].DraftID = draftID
+	}
+	return tx.Create(&images).Error
+}
+
+// AddDraftImages 为草稿追加图片
+func AddDraftImages(draftID int64, images []models.PostImage) error {
+	return createDraftImages(db, draftID, images)
+}
+
+// GetDraftByID 通过ID获取草稿
+func GetDraftByID(draftID int64) (*models.PostDraft, error) {
+	var draft models.PostDraft
+	err := db.Where("id = ?", draftID).First(&draft).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorDraftNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// GetDrafts 获取用户未发布的草稿与定时动态，按更新时间降序排序
+func GetDrafts(userID int64, offset, limit int) ([]models.PostDraft, error) {
+	var drafts []models.PostDraft
+	err := db.Where("user_id = ? AND status <> ?", userID, models.DraftStatusPublished).
+		Order("updated_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&drafts).Error
+	return drafts, err
+}
+
+// GetDraftImages 批量获取草稿图片，按草稿内的顺序排序
+func GetDraftImages(draftIDs []int64) ([]models.PostImage, error) {
+	var images []models.PostImage
+	if len(draftIDs) == 0 {
+		return images, nil
+	}
+	err := db.Where("draft_id IN ? AND post_id = 0", draftIDs).Order("draft_id, sort, id").Find(&images).Error
+	return images, err
+}
+
+// UpdateDraft 更新草稿，仅未进入发布流程的草稿可以修改
+func UpdateDraft(draftID int64, fields map[string]interface{}) error {
+	return db.Model(&models.PostDraft{}).
+		Where("id = ? AND status IN ?", draftID, editableDraftStatuses()).
+		Updates(fields).Error
+}
+
+// DeleteDraftImage 删除草稿中的一张图片
+func DeleteDraftImage(draftID, imageID int64) (*models.PostImage, error) {
+	var image models.PostImage
+	err := db.Where("id = ? AND draft_id = ? AND post_id = 0", imageID, draftID).First(&image).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorDraftNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &image, db.Delete(&image).Error
+}
+
+// DeleteDraft 删除草稿及其未发布的图片
+func DeleteDraft(draftID int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("draft_id = ? AND post_id = 0", draftID).Delete(&models.PostImage{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.PostDraft{}, draftID).Error
+	})
+}
+
+// ClaimDraft 将草稿从from中的状态原子地切换为发布中，返回是否抢占成功，避免重复发布
+func ClaimDraft(draftID int64, from []string) (bool, error) {
+	result := db.Model(&models.PostDraft{}).
+		Where("id = ? AND status IN ?", draftID, from).
+		Update("status", models.DraftStatusPublishing)
+	return result.RowsAffected == 1, result.Error
+}
+
+// GetDueDraftIDs 获取到达发布时间的定时动态
+func GetDueDraftIDs(now time.Time, limit int) ([]int64, error) {
+	var ids []int64
+	err := db.Model(&models.PostDraft{}).
+		Where("status = ? AND scheduled_at <= ?", models.DraftStatusScheduled, now).
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// FailStaleDrafts 将长时间停留在发布中(如发布过程中服务重启)的草稿标记为失败，由作者重新发布
+// 动态与草稿状态在同一事务中保存，停留在发布中的草稿一定未发布
+func FailStaleDrafts(before time.Time, lastError string) error {
+	return db.Model(&models.PostDraft{}).
+		Where("status = ? AND updated_at < ?", models.DraftStatusPublishing, before).
+		Updates(map[string]interface{}{"status": models.DraftStatusFailed, "last_error": lastError}).Error
+}
+
+// finishDraft 在发布动态的事务中标记草稿已发布，图片归属到动态
+// 草稿已不在发布中(如已被标记为发布失败)时返回ErrorDraftNotEditable，动态随事务回滚，避免重复发布
+func finishDraft(tx *gorm.DB, draftID, postID int64) error {
+	result := tx.Model(&models.PostDraft{}).
+		Where("id = ? AND status = ?", draftID, models.DraftStatusPublishing).
+		Updates(map[string]interface{}{"status": models.DraftStatusPublished, "post_id": postID, "last_error": ""})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorDraftNotEditable
+	}
+	return tx.Model(&models.PostImage{}).Where("draft_id = ?", draftID).Update("draft_id", 0).Error
+}
+
+// SetDraftStatus 设置草稿状态及失败原因
+func SetDraftStatus(draftID int64, status, lastError string) error {
+	return db.Model(&models.PostDraft{}).Where("id = ?", draftID).
+		Updates(map[string]interface{}{"status": status, "last_error": lastError}).Error
+}
+
+// editableDraftStatuses 可以修改的草稿状态
+func editableDraftStatuses() []string {
+	return []string{models.DraftStatusDraft, models.DraftStatusScheduled, models.DraftStatusFailed}
+}
@@ -3,16 +3,62 @@ package mysql
 import "errors"
 
 var (
-	ErrEmailExists        = errors.New("该邮箱已被注册")
-	ErrorUserNotExist     = errors.New("该用户名不存在")
-	ErrorInvalidPassword  = errors.New("密码错误")
-	ErrorInvalidId        = errors.New("无效的ID")
-	ErrorSystem           = errors.New("系统错误")
-	ErrorDBSelect         = errors.New("数据库查询错误")
-	ErrorGenerateToken    = errors.New("生成token失败")
-	ErrorIsFriend         = errors.New("已经是好友")
-	ErrorCannotAddSelf    = errors.New("不能添加自己为好友")
-	ErrorCannotDeleteSelf = errors.New("不能删除自己")
-	ErrorIsNotFriend      = errors.New("该用户不是您的好友")
-	ErrorInvalidParam     = errors.New("无效的参数")
+	ErrEmailExists              = errors.New("该邮箱已被注册")
+	ErrorUserNotExist           = errors.New("该用户名不存在")
+	ErrorInvalidPassword        = errors.New("密码错误")
+	ErrorInvalidId              = errors.New("无效的ID")
+	ErrorSystem                 = errors.New("系统错误")
+	ErrorDBSelect               = errors.New("数据库查询错误")
+	ErrorGenerateToken          = errors.New("生成token失败")
+	ErrorIsFriend               = errors.New("已经是好友")
+	ErrorCannotAddSelf          = errors.New("不能添加自己为好友")
+	ErrorCannotDeleteSelf       = errors.New("不能删除自己")
+	ErrorIsNotFriend            = errors.New("该用户不是您的好友")
+	ErrorInvalidParam           = errors.New("无效的参数")
+	ErrorCannotBlockSelf        = errors.New("不能拉黑自己")
+	ErrorIsBlocked              = errors.New("已将该用户拉黑")
+	ErrorIsNotBlocked           = errors.New("该用户不在黑名单中")
+	ErrorBlocked                = errors.New("存在拉黑关系")
+	ErrorCannotFollowSelf       = errors.New("不能关注自己")
+	ErrorIsFollowing            = errors.New("已经关注该用户")
+	ErrorIsNotFollowing         = errors.New("未关注该用户")
+	ErrorFollowRequestNotExist  = errors.New("关注申请不存在")
+	ErrorPostNotExist           = errors.New("动态不存在")
+	ErrorInvalidCursor          = errors.New("无效的分页游标")
+	ErrorCommentNotExist        = errors.New("评论不存在")
+	ErrorCannotDeleteComment    = errors.New("无权删除该评论")
+	ErrorFriendGroupNotExist    = errors.New("好友分组不存在")
+	ErrorInvalidAudience        = errors.New("无效的可见名单")
+	ErrorCannotEditOthersPost   = errors.New("只能修改自己的动态")
+	ErrorCannotDeleteOthersPost = errors.New("只能删除自己的动态")
+	ErrorTopicNotExist          = errors.New("话题不存在")
+	ErrorRepostWidenAudience    = errors.New("转发不能扩大原动态的可见范围")
+	ErrorDraftNotExist          = errors.New("草稿不存在")
+	ErrorDraftNotEditable       = errors.New("草稿正在发布或已发布")
+	ErrorInvalidScheduleTime    = errors.New("无效的定时发布时间")
+	ErrorEmptyPost              = errors.New("动态内容与图片不能同时为空")
+	ErrorTooManyImages          = errors.New("最多上传9张图片")
+	ErrorInvalidPoll            = errors.New("无效的投票")
+	ErrorPollNotExist           = errors.New("投票不存在")
+	ErrorPollClosed             = errors.New("投票已截止")
+	ErrorAlreadyVoted           = errors.New("已经投过票")
+	ErrorInvalidPollOption      = errors.New("无效的投票选项")
+	ErrorPollResultHidden       = errors.New("投票后或截止后才能查看结果")
+	ErrorPollAnonymous          = errors.New("匿名投票不公开投票人")
+	ErrorSensitiveContent       = errors.New("内容包含违规词语")
+	ErrorCannotReportSelf       = errors.New("不能举报自己")
+	ErrorAlreadyReported        = errors.New("已经举报过")
+	ErrorMessageNotExist        = errors.New("私信不存在")
+	ErrorCaseNotExist           = errors.New("审核工单不存在")
+	ErrorCaseClosed             = errors.New("审核工单已结案")
+	ErrorCaseClaimed            = errors.New("审核工单已被其他管理员认领")
+	ErrorInvalidModeration      = errors.New("处置方式不适用于该举报对象")
+	ErrorUserSuspended          = errors.New("账号已被封禁")
+	ErrorPostHidden             = errors.New("动态已被管理员隐藏")
+	ErrorNoPermission           = errors.New("无权限")
+	ErrorFeedExpired            = errors.New("动态流排序已过期")
+	ErrorCollectionNotExist     = errors.New("收藏夹不存在")
+	ErrorFeedTokenNotExist      = errors.New("订阅令牌不存在")
+	ErrorAlbumNotExist          = errors.New("相册不存在")
+	ErrorPhotoNotExist          = errors.New("照片不存在")
 )
@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gosocial/models"
+)
+
+// GetFeedTokenByUserID 获取用户的订阅令牌，未生成时返回ErrorFeedTokenNotExist
+func GetFeedTokenByUserID(userID int64) (*models.FeedToken, error) {
+	var token models.FeedToken
+	err := db.Where("user_id = ?", userID).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorFeedTokenNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetFeedTokenByToken 根据令牌查询订阅令牌，令牌不存在或已失效时返回ErrorFeedTokenNotExist
+func GetFeedTokenByToken(token string) (*models.FeedToken, error) {
+	var t models.FeedToken
+	err := db.Where("token = ?", token).First(&t).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorFeedTokenNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ResetFeedToken 为用户生成新的订阅令牌，旧令牌同时失效
+func ResetFeedToken(token *models.FeedToken) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", token.UserID).Delete(&models.FeedToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// TouchFeedToken 更新用户订阅源内容的最后变化时间，未生成令牌时不做处理
+func TouchFeedToken(userID int64, at time.Time) error {
+	return db.Model(&models.FeedToken{}).
+		Where("user_id = ?", userID).
+		Update("modified_at", at).Error
+}
+
+// DeleteFeedToken 吊销用户的订阅令牌，未生成时同样返回成功
+func DeleteFeedToken(userID int64) error {
+	return db.Where("user_id = ?", userID).Delete(&models.FeedToken{}).Error
+}
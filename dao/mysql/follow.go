@@ -0,0 +1,104 @@
+package mysql
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gosocial/models"
+)
+
+// CreateFollow 创建关注关系
+func CreateFollow(follow *models.Follow) error {
+	return db.Create(follow).Error
+}
+
+// GetFollow 获取关注关系，不存在时返回ErrorIsNotFollowing
+func GetFollow(followerID, followeeID int64) (*models.Follow, error) {
+	var follow models.Follow
+	err := db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		First(&follow).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorIsNotFollowing
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &follow, nil
+}
+
+// DeleteFollow 取消关注(包括撤回未通过的关注申请)
+func DeleteFollow(followerID, followeeID int64) error {
+	return db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		Delete(&models.Follow{}).Error
+}
+
+// DeleteFollowBetween 删除两个用户之间任意方向的关注关系
+func DeleteFollowBetween(userID, targetID int64) error {
+	return db.Where("(follower_id = ? AND followee_id = ?) OR (follower_id = ? AND followee_id = ?)",
+		userID, targetID, targetID, userID).
+		Delete(&models.Follow{}).Error
+}
+
+// UpdateFollowStatus 更新关注状态
+func UpdateFollowStatus(followerID, followeeID int64, status string) error {
+	return db.Model(&models.Follow{}).
+		Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		Update("status", status).Error
+}
+
+// GetFollowers 获取指定状态的粉丝列表，按关注时间降序排序
+func GetFollowers(userID int64, status string, offset, limit int) ([]models.Follow, error) {
+	var follows []models.Follow
+	err := db.Preload("Follower").
+		Where("followee_id = ? AND status = ?", userID, status).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&follows).Error
+	return follows, err
+}
+
+// GetFollowing 获取关注列表，按关注时间降序排序
+func GetFollowing(userID int64, offset, limit int) ([]models.Follow, error) {
+	var follows []models.Follow
+	err := db.Preload("Followee").
+		Where("follower_id = ? AND status = ?", userID, models.FollowStatusAccepted).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&follows).Error
+	return follows, err
+}
+
+// GetFollowingIDs 获取已关注用户的ID列表
+func GetFollowingIDs(userID int64) ([]int64, error) {
+	var ids []int64
+	err := db.Model(&models.Follow{}).
+		Where("follower_id = ? AND status = ?", userID, models.FollowStatusAccepted).
+		Pluck("followee_id", &ids).Error
+	return ids, err
+}
+
+// GetFollowerIDs 获取已通过的粉丝ID列表
+func GetFollowerIDs(userID int64) ([]int64, error) {
+	var ids []int64
+	err := db.Model(&models.Follow{}).
+		Where("followee_id = ? AND status = ?", userID, models.FollowStatusAccepted).
+		Pluck("follower_id", &ids).Error
+	return ids, err
+}
+
+// CountFollowers 统计粉丝数
+func CountFollowers(userID int64) (count int64, err error) {
+	err = db.Model(&models.Follow{}).
+		Where("followee_id = ? AND status = ?", userID, models.FollowStatusAccepted).
+		Count(&count).Error
+	return
+}
+
+// CountFollowing 统计关注数
+func CountFollowing(userID int64) (count int64, err error) {
+	err = db.Model(&models.Follow{}).
+		Where("follower_id = ? AND status = ?", userID, models.FollowStatusAccepted).
+		Count(&count).Error
+	return
+}
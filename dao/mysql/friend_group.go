@@ -0,0 +1,111 @@
+package mysql
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gosocial/models"
+)
+
+// CreateFriendGroup 创建好友分组并写入成员
+func CreateFriendGroup(group *models.FriendGroup, memberIDs []int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(group).Error; err != nil {
+			return err
+		}
+		return createFriendGroupMembers(tx, group.ID, memberIDs)
+	})
+}
+
+// createFriendGroupMembers 批量写入分组成员
+func createFriendGroupMembers(tx *gorm.DB, groupID int64, memberIDs []int64) error {
+	if len(memberIDs) == 0 {
+		return nil
+	}
+	members := make([]models.FriendGroupMember, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		members = append(members, models.FriendGroupMember{GroupID: groupID, FriendID: id})
+	}
+	return tx.Create(&members).Error
+}
+
+// GetFriendGroupByID 通过ID获取好友分组
+func GetFriendGroupByID(groupID int64) (*models.FriendGroup, error) {
+	var group models.FriendGroup
+	err := db.Where("id = ?", groupID).First(&group).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorFriendGroupNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// GetFriendGroups 获取用户的全部好友分组
+func GetFriendGroups(userID int64) ([]models.FriendGroup, error) {
+	var groups []models.FriendGroup
+	err := db.Where("user_id = ?", userID).Order("id ASC").Find(&groups).Error
+	return groups, err
+}
+
+// GetFriendGroupMembers 批量获取分组成员，返回分组ID到成员ID列表的映射
+func GetFriendGroupMembers(groupIDs []int64) (map[int64][]int64, error) {
+	members := make(map[int64][]int64, len(groupIDs))
+	if len(groupIDs) == 0 {
+		return members, nil
+	}
+	var rows []models.FriendGroupMember
+	if err := db.Where("group_id IN ?", groupIDs).Order("id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		members[r.GroupID] = append(members[r.GroupID], r.FriendID)
+	}
+	return members, nil
+}
+
+// CountFriendGroupsIn 统计给定分组ID中属于userID的分组数量
+func CountFriendGroupsIn(userID int64, groupIDs []int64) (int64, error) {
+	var count int64
+	if len(groupIDs) == 0 {
+		return 0, nil
+	}
+	err := db.Model(&models.FriendGroup{}).
+		Where("user_id = ? AND id IN ?", userID, groupIDs).
+		Count(&count).Error
+	return count, err
+}
+
+// UpdateFriendGroup 更新分组名称并整体替换成员
+func UpdateFriendGroup(groupID int64, name string, memberIDs []int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.FriendGroup{}).Where("id = ?", groupID).Update("name", name).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ?", groupID).Delete(&models.FriendGroupMember{}).Error; err != nil {
+			return err
+		}
+		return createFriendGroupMembers(tx, groupID, memberIDs)
+	})
+}
+
+// DeleteFriendGroup 删除好友分组，同时清理成员与引用该分组的动态可见名单
+func DeleteFriendGroup(groupID int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", groupID).Delete(&models.FriendGroupMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("target_type = ? AND target_id = ?", models.AudienceTargetGroup, groupID).
+			Delete(&models.PostAudience{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.FriendGroup{}, groupID).Error
+	})
+}
+
+// removeFriendFromGroups 将friendID从userID的所有分组中移除
+func removeFriendFromGroups(tx *gorm.DB, userID, friendID int64) error {
+	return tx.Where("friend_id = ? AND group_id IN (?)", friendID,
+		tx.Model(&models.FriendGroup{}).Select("id").Where("user_id = ?", userID)).
+		Delete(&models.FriendGroupMember{}).Error
+}
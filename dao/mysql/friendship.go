@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gosocial/models"
 	"time"
 )
@@ -24,10 +25,11 @@ func AddFriend(userID, friendID int64) error {
 	})
 }
 
-// GetFriendList 获取好友列表，按最后互动时间降序排序
+// GetFriendList 获取全部好友关系，按最后互动时间降序排序
+// 只查询好友关系本身，好友的昵称与头像由调用方从资料缓存批量获取
 func GetFriendList(userID int64) ([]models.Friendship, error) {
 	var friends []models.Friendship
-	err := db.Preload("Friend").
+	err := db.Select("id", "friend_id", "remark", "last_interact_at", "intimacy", "intimacy_at").
 		Where("user_id = ?", userID).
 		Order("last_interact_at DESC").
 		Find(&friends).Error
@@ -46,14 +48,23 @@ func IsFriend(userID, friendID int64) error {
 
 // DeleteFriend 双向删除好友
 func DeleteFriend(userID, friendID int64) error {
-	err := db.Where("user_id = ? AND friend_id = ?", userID, friendID).
-		Delete(&models.Friendship{}).Error
-	if err != nil {
-		return err
-	}
-	err = db.Where("user_id = ? AND friend_id = ?", friendID, userID).
-		Delete(&models.Friendship{}).Error
-	return err
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND friend_id = ?", userID, friendID).
+			Delete(&models.Friendship{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("user_id = ? AND friend_id = ?", friendID, userID).
+			Delete(&models.Friendship{}).Error
+		if err != nil {
+			return err
+		}
+		// 解除好友后双方互相移出对方的好友分组
+		if err = removeFriendFromGroups(tx, userID, friendID); err != nil {
+			return err
+		}
+		return removeFriendFromGroups(tx, friendID, userID)
+	})
 }
 
 func UpdateFriendRemark(userID, friendID int64, remark string) error {
@@ -68,3 +79,83 @@ func UpdateFriendRemark(userID, friendID int64, remark string) error {
 		Where("user_id = ? AND friend_id = ?", userID, friendID).
 		Update("remark", remark).Error
 }
+
+// GetFriendIDsIn 从给定用户ID中筛选出userID的好友
+func GetFriendIDsIn(userID int64, ids []int64) ([]int64, error) {
+	var friendIDs []int64
+	if len(ids) == 0 {
+		return friendIDs, nil
+	}
+	err := db.Model(&models.Friendship{}).
+		Where("user_id = ? AND friend_id IN ?", userID, ids).
+		Pluck("friend_id", &friendIDs).Error
+	return friendIDs, err
+}
+
+// UpdateInteraction 批量落库好友互动：双向更新最后互动时间，并在衰减旧亲密度后累加增量
+func UpdateInteraction(userID, friendID int64, at time.Time, weight float64) error {
+	// 注意SET子句按从左到右执行，intimacy需在intimacy_at更新前计算
+	return db.Exec("UPDATE friendships SET "+
+		"last_interact_at = GREATEST(last_interact_at, ?), "+
+		"intimacy = COALESCE(intimacy * POW(0.5, GREATEST(TIMESTAMPDIFF(SECOND, intimacy_at, ?), 0) / ?), 0) + ?, "+
+		"intimacy_at = ? "+
+		"WHERE (user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?)",
+		at, at, models.IntimacyHalfLife.Seconds(), weight, at,
+		userID, friendID, friendID, userID).Error
+}
+
+// GetFriendRemarks 获取好友ID与备注的映射
+func GetFriendRemarks(userID int64) (map[int64]string, error) {
+	var rows []models.Friendship
+	err := db.Select("friend_id", "remark").
+		Where("user_id = ?", userID).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	remarks := make(map[int64]string, len(rows))
+	for _, r := range rows {
+		remarks[r.FriendID] = r.Remark
+	}
+	return remarks, nil
+}
+
+// GetFriendIntimacies 获取全部好友的亲密度记录(仅好友ID、亲密度与计算时间)
+func GetFriendIntimacies(userID int64) ([]models.Friendship, error) {
+	var rows []models.Friendship
+	err := db.Select("friend_id", "intimacy", "intimacy_at").
+		Where("user_id = ?", userID).
+		Find(&rows).Error
+	return rows, err
+}
+
+// GetFriendPage 游标分页获取好友列表，按最后互动时间降序排序
+// lastAt与lastID为上一页最后一条记录的最后互动时间与主键，首页传零值
+func GetFriendPage(userID int64, lastAt time.Time, lastID int64, limit int) ([]models.Friendship, error) {
+	var friends []models.Friendship
+	tx := db.Preload("Friend").Where("user_id = ?", userID)
+	if lastID > 0 {
+		tx = tx.Where("last_interact_at < ? OR (last_interact_at = ? AND id < ?)", lastAt, lastAt, lastID)
+	}
+	err := tx.Order("last_interact_at DESC, id DESC").
+		Limit(limit).
+		Find(&friends).Error
+	return friends, err
+}
+
+// GetFriendPageByIntimacy 分页获取好友列表，按当前亲密度降序排序
+// 亲密度随时间衰减，无法使用键值游标，因此按偏移量分页
+func GetFriendPageByIntimacy(userID int64, offset, limit int) ([]models.Friendship, error) {
+	var friends []models.Friendship
+	err := db.Preload("Friend").
+		Where("user_id = ?", userID).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "COALESCE(intimacy * POW(0.5, TIMESTAMPDIFF(SECOND, intimacy_at, NOW()) / ?), 0) DESC, id DESC",
+			Vars:               []interface{}{models.IntimacyHalfLife.Seconds()},
+			WithoutParentheses: true,
+		}}).
+		Offset(offset).
+		Limit(limit).
+		Find(&friends).Error
+	return friends, err
+}
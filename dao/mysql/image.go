@@ -0,0 +1,13 @@
+package mysql
+
+import "gosocial/models"
+
+// GetPostImages 批量获取动态图片，按动态内的顺序排序
+func GetPostImages(postIDs []int64) ([]models.PostImage, error) {
+	var images []models.PostImage
+	if len(postIDs) == 0 {
+		return images, nil
+	}
+	err := db.Where("post_id IN ?", postIDs).Order("post_id, sort").Find(&images).Error
+	return images, err
+}
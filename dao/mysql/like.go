@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"gorm.io/gorm/clause"
+	"gosocial/models"
+)
+
+// CreatePostLike 点赞动态，重复点赞不报错，created为false表示此前已点赞
+func CreatePostLike(postID, userID int64) (created bool, err error) {
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.PostLike{PostID: postID, UserID: userID})
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeletePostLike 取消点赞，deleted为false表示此前未点赞
+func DeletePostLike(postID, userID int64) (deleted bool, err error) {
+	result := db.Where("post_id = ? AND user_id = ?", postID, userID).
+		Delete(&models.PostLike{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountPostLikes 批量统计动态的点赞数
+func CountPostLikes(postIDs []int64) (map[int64]int64, error) {
+	var rows []struct {
+		PostID int64
+		Count  int64
+	}
+	err := db.Model(&models.PostLike{}).
+		Select("post_id, COUNT(*) AS count").
+		Where("post_id IN ?", postIDs).
+		Group("post_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int64]int64, len(postIDs))
+	for _, id := range postIDs {
+		counts[id] = 0
+	}
+	for _, r := range rows {
+		counts[r.PostID] = r.Count
+	}
+	return counts, nil
+}
+
+// GetLikedPostIDs 从给定动态中筛选出用户已点赞的动态ID
+func GetLikedPostIDs(userID int64, postIDs []int64) ([]int64, error) {
+	var ids []int64
+	if len(postIDs) == 0 {
+		return ids, nil
+	}
+	err := db.Model(&models.PostLike{}).
+		Where("user_id = ? AND post_id IN ?", userID, postIDs).
+		Pluck("post_id", &ids).Error
+	return ids, err
+}
+
+// GetFriendLikers 获取动态的点赞用户中当前用户的好友(及自己)，按点赞时间降序排序
+func GetFriendLikers(postID, viewerID int64, offset, limit int) ([]models.PostLike, error) {
+	var likes []models.PostLike
+	err := db.Preload("User").
+		Where("post_id = ?", postID).
+		Where("user_id = ? OR user_id IN (?)", viewerID,
+			db.Model(&models.Friendship{}).Select("friend_id").Where("user_id = ?", viewerID)).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&likes).Error
+	return likes, err
+}
+
+// UpdatePostLikeCount 回写动态的点赞数
+func UpdatePostLikeCount(postID, count int64) error {
+	return db.Model(&models.Post{}).
+		Where("id = ?", postID).
+		UpdateColumn("like_count", count).Error
+}
@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	"strings"
+	"time"
+
+	"gosocial/models"
+)
+
+// DateRange 左闭右开的时间范围
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// dateRangesCond 生成column落在任一时间范围内的查询条件
+func dateRangesCond(column string, ranges []DateRange) (string, []interface{}) {
+	conds := make([]string, len(ranges))
+	args := make([]interface{}, 0, len(ranges)*2)
+	for i, r := range ranges {
+		conds[i] = column + " >= ? AND " + column + " < ?"
+		args = append(args, r.Start, r.End)
+	}
+	return strings.Join(conds, " OR "), args
+}
+
+// GetUserPostsInRanges 获取用户在给定时间范围内发布的未删除动态，按发布时间降序排序
+func GetUserPostsInRanges(userID int64, ranges []DateRange) ([]models.Post, error) {
+	var posts []models.Post
+	if len(ranges) == 0 {
+		return posts, nil
+	}
+	cond, args := dateRangesCond("created_at", ranges)
+	err := db.Where("user_id = ?", userID).
+		Where(cond, args...).
+		Order("created_at DESC, id DESC").
+		Find(&posts).Error
+	return posts, err
+}
+
+// GetFriendshipsInRanges 获取用户在给定时间范围内添加的好友，按添加时间降序排序
+func GetFriendshipsInRanges(userID int64, ranges []DateRange) ([]models.Friendship, error) {
+	var friendships []models.Friendship
+	if len(ranges) == 0 {
+		return friendships, nil
+	}
+	cond, args := dateRangesCond("created_at", ranges)
+	err := db.Where("user_id = ?", userID).
+		Where(cond, args...).
+		Order("created_at DESC, id DESC").
+		Find(&friendships).Error
+	return friendships, err
+}
+
+// CountPostsInRangesByUser 统计各用户在给定时间范围内发布的未删除动态数
+func CountPostsInRangesByUser(ranges []DateRange) (map[int64]int64, error) {
+	return countInRangesByUser(&models.Post{}, ranges)
+}
+
+// CountFriendshipsInRangesByUser 统计各用户在给定时间范围内添加的好友数
+func CountFriendshipsInRangesByUser(ranges []DateRange) (map[int64]int64, error) {
+	return countInRangesByUser(&models.Friendship{}, ranges)
+}
+
+// countInRangesByUser 按user_id分组统计给定时间范围内的记录数
+func countInRangesByUser(model interface{}, ranges []DateRange) (map[int64]int64, error) {
+	counts := make(map[int64]int64)
+	if len(ranges) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		UserID int64
+		Count  int64
+	}
+	cond, args := dateRangesCond("created_at", ranges)
+	err := db.Model(model).
+		Select("user_id, COUNT(*) AS count").
+		Where(cond, args...).
+		Group("user_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		counts[r.UserID] = r.Count
+	}
+	return counts, nil
+}
@@ -33,3 +33,12 @@ func (d *MessageDao) GetMessages(ctx context.Context, from, to int64, start, end
 	}
 	return messages, nil
 }
+
+// DeleteMessage 删除已持久化的私信，按发送者、接收者、发送时间与内容匹配
+// MySQL中的时间精度低于原始发送时间，按前后1秒匹配
+func (d *MessageDao) DeleteMessage(ctx context.Context, msg *models.Message) error {
+	return GetDB().WithContext(ctx).
+		Where("`from` = ? AND `to` = ? AND content = ?", msg.From, msg.To, msg.Content).
+		Where("created_at BETWEEN ? AND ?", msg.CreatedAt.Add(-time.Second), msg.CreatedAt.Add(time.Second)).
+		Delete(&models.Message{}).Error
+}
@@ -30,10 +30,39 @@ func Init(cfg *settings.MySQLConfig) (err error) {
 	}
 	// 自动迁移模型（创建表或更新表结构）
 	err = db.AutoMigrate(
-		&models.User{},       // 用户模型
-		&models.Friendship{}, // 好友模型
-		&models.Message{},    // 消息模型
-		&models.Post{},       // 动态模型
+		&models.User{},              // 用户模型
+		&models.Friendship{},        // 好友模型
+		&models.Message{},           // 消息模型
+		&models.Post{},              // 动态模型
+		&models.UserSetting{},       // 用户设置模型
+		&models.Block{},             // 黑名单模型
+		&models.Follow{},            // 关注模型
+		&models.PostLike{},          // 动态点赞模型
+		&models.Notification{},      // 通知模型
+		&models.Comment{},           // 评论模型
+		&models.FriendGroup{},       // 好友分组模型
+		&models.FriendGroupMember{}, // 好友分组成员模型
+		&models.PostAudience{},      // 动态可见名单模型
+		&models.PostEdit{},          // 动态编辑历史模型
+		&models.PostVisit{},         // 动态访客模型
+		&models.PostImage{},         // 动态图片模型
+		&models.Topic{},             // 话题模型
+		&models.PostTopic{},         // 动态话题关联模型
+		&models.PostMention{},       // 动态提及用户模型
+		&models.PostDraft{},         // 动态草稿与定时发布模型
+		&models.Poll{},              // 动态投票模型
+		&models.PollOption{},        // 投票选项模型
+		&models.PollVoter{},         // 投票人模型
+		&models.PollVote{},          // 投票选项记录模型
+		&models.ModerationCase{},    // 审核工单模型
+		&models.Report{},            // 举报记录模型
+		&models.ModerationLog{},     // 审核日志模型
+		&models.ContentFlag{},       // 敏感内容审核标记模型
+		&models.Bookmark{},          // 动态收藏模型
+		&models.Collection{},        // 收藏夹模型
+		&models.FeedToken{},         // 订阅源令牌模型
+		&models.Album{},             // 相册模型
+		&models.Photo{},             // 相册照片模型
 	)
 	if err != nil {
 		zap.L().Error("自动迁移失败", zap.Error(err))
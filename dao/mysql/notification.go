@@ -0,0 +1,37 @@
+package mysql
+
+import (
+	"gosocial/models"
+)
+
+// CreateNotification 创建通知
+func CreateNotification(n *models.Notification) error {
+	return db.Create(n).Error
+}
+
+// GetNotifications 获取通知列表，按通知时间降序排序
+func GetNotifications(userID int64, offset, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := db.Preload("Actor").
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// CountUnreadNotifications 统计未读通知数
+func CountUnreadNotifications(userID int64) (count int64, err error) {
+	err = db.Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Count(&count).Error
+	return
+}
+
+// MarkNotificationsRead 将用户的全部通知标记为已读
+func MarkNotificationsRead(userID int64) error {
+	return db.Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Update("is_read", true).Error
+}
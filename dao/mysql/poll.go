@@ -0,0 +1,114 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gosocial/models"
+)
+
+// preloadPollOptions 按选项顺序预加载投票选项
+func preloadPollOptions(db *gorm.DB) *gorm.DB {
+	return db.Order("sort, id")
+}
+
+// GetPollByPostID 获取动态附带的投票及其选项
+func GetPollByPostID(postID int64) (*models.Poll, error) {
+	var poll models.Poll
+	err := db.Preload("Options", preloadPollOptions).Where("post_id = ?", postID).First(&poll).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorPollNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &poll, nil
+}
+
+// GetPollsByPostIDs 批量获取动态附带的投票及其选项
+func GetPollsByPostIDs(postIDs []int64) ([]models.Poll, error) {
+	var polls []models.Poll
+	if len(postIDs) == 0 {
+		return polls, nil
+	}
+	err := db.Preload("Options", preloadPollOptions).Where("post_id IN ?", postIDs).Find(&polls).Error
+	return polls, err
+}
+
+// GetUserPollVotes 批量获取用户在给定投票中选择的选项
+func GetUserPollVotes(userID int64, pollIDs []int64) ([]models.PollVote, error) {
+	var votes []models.PollVote
+	if len(pollIDs) == 0 {
+		return votes, nil
+	}
+	err := db.Where("user_id = ? AND poll_id IN ?", userID, pollIDs).Find(&votes).Error
+	return votes, err
+}
+
+// CreatePollVote 在同一事务中记录投票人、所选选项并累加票数
+// 投票人唯一索引保证每人只能投一次，截止时间在事务内再次校验
+func CreatePollVote(pollID, userID int64, optionIDs []int64, now time.Time) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.PollVoter{PollID: pollID, UserID: userID, CreatedAt: now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrorAlreadyVoted
+		}
+		result = tx.Model(&models.Poll{}).
+			Where("id = ? AND (deadline IS NULL OR deadline > ?)", pollID, now).
+			UpdateColumn("voter_count", gorm.Expr("voter_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrorPollClosed
+		}
+		votes := make([]models.PollVote, len(optionIDs))
+		for i, id := range optionIDs {
+			votes[i] = models.PollVote{PollID: pollID, OptionID: id, UserID: userID, CreatedAt: now}
+		}
+		if err := tx.Create(&votes).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.PollOption{}).
+			Where("poll_id = ? AND id IN ?", pollID, optionIDs).
+			UpdateColumn("vote_count", gorm.Expr("vote_count + 1")).Error
+	})
+}
+
+// GetPollVoters 获取投票人列表，optionID不为0时只返回选择了该选项的用户，按投票时间降序排序
+func GetPollVoters(pollID, optionID int64, offset, limit int) ([]models.PollVoter, error) {
+	var voters []models.PollVoter
+	query := db.Model(&models.PollVoter{}).Where("poll_id = ?", pollID)
+	if optionID != 0 {
+		query = db.Model(&models.PollVote{}).Select("id, poll_id, user_id, created_at").
+			Where("poll_id = ? AND option_id = ?", pollID, optionID)
+	}
+	err := query.Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&voters).Error
+	return voters, err
+}
+
+// deletePostPolls 删除动态附带的投票、选项与投票记录
+func deletePostPolls(tx *gorm.DB, postIDs []int64) error {
+	var pollIDs []int64
+	if err := tx.Model(&models.Poll{}).Where("post_id IN ?", postIDs).Pluck("id", &pollIDs).Error; err != nil {
+		return err
+	}
+	if len(pollIDs) == 0 {
+		return nil
+	}
+	for _, model := range []interface{}{&models.PollVote{}, &models.PollVoter{}, &models.PollOption{}} {
+		if err := tx.Where("poll_id IN ?", pollIDs).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Where("id IN ?", pollIDs).Delete(&models.Poll{}).Error
+}
@@ -1,14 +1,95 @@
 package mysql
 
 import (
+	"errors"
 	"gorm.io/gorm"
 	"gosocial/models"
+	"time"
 )
 
-// GetPostsByUserIDs 获取多个用户的动态,按动态发布时间降序排序
-func GetPostsByUserIDs(userIDs []int64, offset, limit int) ([]models.Post, error) {
+// friendVisibleSQL 好友可见动态的过滤条件：公开或仅好友可见；部分可见时查看者须在名单内；
+// 不给谁看时查看者不在名单内。名单中的分组在查询时解析为成员
+const friendVisibleSQL = `posts.visibility IN @shared OR ` +
+	`(posts.visibility = @allow AND EXISTS (` + audienceMatchSQL + `)) OR ` +
+	`(posts.visibility = @deny AND NOT EXISTS (` + audienceMatchSQL + `))`
+
+// audienceMatchSQL 查看者是否命中动态的可见名单
+const audienceMatchSQL = `SELECT 1 FROM post_audiences pa WHERE pa.post_id = posts.id AND (` +
+	`(pa.target_type = @target_user AND pa.target_id = @viewer) OR ` +
+	`(pa.target_type = @target_group AND pa.target_id IN (SELECT group_id FROM friend_group_members WHERE friend_id = @viewer)))`
+
+// friendVisibleArgs friendVisibleSQL的命名参数
+func friendVisibleArgs(viewerID int64) map[string]interface{} {
+	return map[string]interface{}{
+		"shared":       []string{models.PostVisibilityPublic, models.PostVisibilityFriends},
+		"allow":        models.PostVisibilityAllow,
+		"deny":         models.PostVisibilityDeny,
+		"target_user":  models.AudienceTargetUser,
+		"target_group": models.AudienceTargetGroup,
+		"viewer":       viewerID,
+	}
+}
+
+// feedCond 动态流的可见条件：查看者自己的动态全部可见，friendIDs 中用户的动态按可见范围过滤，
+// publicUserIDs 中用户仅公开动态可见
+func feedCond(viewerID int64, friendIDs, publicUserIDs []int64) *gorm.DB {
+	cond := db.Where("posts.user_id = ?", viewerID)
+	if len(friendIDs) > 0 {
+		cond = cond.Or(db.Where("posts.user_id IN ?", friendIDs).
+			Where(friendVisibleSQL, friendVisibleArgs(viewerID)))
+	}
+	if len(publicUserIDs) > 0 {
+		cond = cond.Or("posts.user_id IN ? AND posts.visibility = ?", publicUserIDs, models.PostVisibilityPublic)
+	}
+	return cond
+}
+
+// GetPostsByUserIDs 获取查看者可见的、早于(beforeAt, beforeID)的动态，按发布时间降序排序
+// beforeAt为零值时从最新的动态开始
+func GetPostsByUserIDs(viewerID int64, friendIDs, publicUserIDs []int64, beforeAt time.Time, beforeID int64, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	query := db.Where(feedCond(viewerID, friendIDs, publicUserIDs))
+	if !beforeAt.IsZero() {
+		query = query.Where("posts.created_at < ? OR (posts.created_at = ? AND posts.id < ?)", beforeAt, beforeAt, beforeID)
+	}
+	err := query.
+		Order("posts.created_at DESC, posts.id DESC").
+		Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetFeedPostKeys 获取查看者可见的最新动态的ID与发布时间，用于构建时间线
+func GetFeedPostKeys(viewerID int64, friendIDs, publicUserIDs []int64, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	err := db.Select("posts.id", "posts.created_at").
+		Where(feedCond(viewerID, friendIDs, publicUserIDs)).
+		Order("posts.created_at DESC, posts.id DESC").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
+
+// GetFeedPostsByIDs 按ID批量获取动态，并重新过滤掉查看者不可见或已删除的动态
+func GetFeedPostsByIDs(viewerID int64, friendIDs, publicUserIDs, postIDs []int64) ([]models.Post, error) {
 	var posts []models.Post
-	err := db.Where("user_id IN ?", userIDs).
+	if len(postIDs) == 0 {
+		return posts, nil
+	}
+	err := db.Where("posts.id IN ?", postIDs).
+		Where(feedCond(viewerID, friendIDs, publicUserIDs)).
+		Find(&posts).Error
+	return posts, err
+}
+
+// GetFriendVisiblePostsByUserID 获取好友可见的单个用户动态,按动态发布时间降序排序
+func GetFriendVisiblePostsByUserID(viewerID, userID int64, offset, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	err := db.Where("posts.user_id = ?", userID).
+		Where(friendVisibleSQL, friendVisibleArgs(viewerID)).
 		Order("created_at DESC").
 		Offset(offset).
 		Limit(limit).
@@ -19,6 +100,51 @@ func GetPostsByUserIDs(userIDs []int64, offset, limit int) ([]models.Post, error
 	return posts, nil
 }
 
+// IsPostVisibleToFriend 判断动态对作者的好友viewerID是否可见
+func IsPostVisibleToFriend(postID, viewerID int64) (bool, error) {
+	var count int64
+	err := db.Model(&models.Post{}).
+		Where("posts.id = ?", postID).
+		Where(friendVisibleSQL, friendVisibleArgs(viewerID)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetPostAudiences 批量获取动态的自定义可见名单
+func GetPostAudiences(postIDs []int64) ([]models.PostAudience, error) {
+	var audiences []models.PostAudience
+	if len(postIDs) == 0 {
+		return audiences, nil
+	}
+	err := db.Where("post_id IN ?", postIDs).Order("id ASC").Find(&audiences).Error
+	return audiences, err
+}
+
+// createPostAudiences 写入动态的自定义可见名单
+func createPostAudiences(tx *gorm.DB, postID int64, audiences []models.PostAudience) error {
+	if len(audiences) == 0 {
+		return nil
+	}
+	for i := range audiences {
+		audiences[i].PostID = postID
+	}
+	return tx.Create(&audiences).Error
+}
+
+// UpdatePostVisibility 修改动态可见范围并整体替换自定义可见名单
+func UpdatePostVisibility(postID int64, visibility string, audiences []models.PostAudience) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Post{}).Where("id = ?", postID).Update("visibility", visibility).Error
+		if err != nil {
+			return err
+		}
+		if err = tx.Where("post_id = ?", postID).Delete(&models.PostAudience{}).Error; err != nil {
+			return err
+		}
+		return createPostAudiences(tx, postID, audiences)
+	})
+}
+
 // GetPostsByUserID 获取单个用户的动态,按动态发布时间降序排序
 func GetPostsByUserID(userID int64, offset, limit int) ([]models.Post, error) {
 	var posts []models.Post
@@ -33,41 +159,191 @@ func GetPostsByUserID(userID int64, offset, limit int) ([]models.Post, error) {
 	return posts, nil
 }
 
-// IncrementPostViewCount 增加动态浏览量
-func IncrementPostViewCount(postID int64) error {
-	return db.Model(&models.Post{}).
-		Where("id = ?", postID).
-		UpdateColumn("view_count", gorm.Expr("view_count + ?", 1)).Error
+// GetPublicPostsByUserID 获取单个用户的公开动态,按动态发布时间降序排序
+func GetPublicPostsByUserID(userID int64, offset, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	err := db.Where("user_id = ? AND visibility = ?", userID, models.PostVisibilityPublic).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
 }
 
-// GetRemarkByPost 获取用户对好友动态的备注
-func GetRemarkByPost(userID int64, post models.Post) (remark string, err error) {
-	//若是自己的动态，则直接返回自己的Username
-	if userID == post.UserID {
-		return post.Username, nil
+// GetPostByID 通过ID获取动态
+func GetPostByID(postID int64) (*models.Post, error) {
+	var post models.Post
+	err := db.Where("id = ?", postID).First(&post).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorPostNotExist
 	}
-	var friendship models.Friendship
-	err = db.Select("remark").Where("user_id = ? AND friend_id = ?", userID, post.UserID).
-		First(&friendship).Error
 	if err != nil {
-		return "", err
-	}
-	if friendship.Remark == "" {
-		return post.Username, nil
+		return nil, err
 	}
-	return friendship.Remark, nil
+	return &post, nil
+}
+
+// AddPostViewCounts 批量累加动态浏览量
+func AddPostViewCounts(counts map[int64]int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for postID, n := range counts {
+			err := tx.Model(&models.Post{}).Unscoped().
+				Where("id = ?", postID).
+				UpdateColumn("view_count", gorm.Expr("view_count + ?", n)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreatePost 创建用户动态及其自定义可见名单、话题与提及的用户，由草稿发布时同时将草稿标记为已发布
+func CreatePost(post *models.Post, audiences []models.PostAudience, topics []string, mentionIDs []int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+		if err := createPostAudiences(tx, post.ID, audiences); err != nil {
+			return err
+		}
+		if err := savePostTopics(tx, post.ID, topics); err != nil {
+			return err
+		}
+		if err := savePostMentions(tx, post.ID, mentionIDs); err != nil {
+			return err
+		}
+		if post.DraftID > 0 {
+			return finishDraft(tx, post.DraftID, post.ID)
+		}
+		return nil
+	})
+}
+
+// DeletePost 软删除动态，动态进入回收站
+func DeletePost(postID int64) error {
+	return db.Delete(&models.Post{}, postID).Error
 }
 
-// CreatePost 创建用户动态
-func CreatePost(post *models.Post) error {
-	return db.Create(post).Error
+// UpdatePostContent 编辑动态内容，并将编辑前的内容写入编辑历史
+func UpdatePostContent(post *models.Post, content string, editedAt time.Time, topics []string, mentionIDs []int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&models.PostEdit{PostID: post.ID, Content: post.Content, CreatedAt: editedAt}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.Post{}).Where("id = ?", post.ID).
+			Updates(map[string]interface{}{"content": content, "edited_at": editedAt}).Error
+		if err != nil {
+			return err
+		}
+		// 话题按新内容整体替换；提及记录保留，编辑历史中的提及仍可正常展示
+		if err = tx.Where("post_id = ?", post.ID).Delete(&models.PostTopic{}).Error; err != nil {
+			return err
+		}
+		if err = savePostTopics(tx, post.ID, topics); err != nil {
+			return err
+		}
+		return savePostMentions(tx, post.ID, mentionIDs)
+	})
+}
+
+// GetPostEdits 获取动态的编辑历史，按编辑时间降序排序
+func GetPostEdits(postID int64) ([]models.PostEdit, error) {
+	var edits []models.PostEdit
+	err := db.Where("post_id = ?", postID).Order("id DESC").Find(&edits).Error
+	return edits, err
+}
+
+// GetDeletedPosts 获取用户回收站中since之后删除的动态，按删除时间降序排序
+func GetDeletedPosts(userID int64, since time.Time, offset, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	err := db.Unscoped().
+		Where("user_id = ? AND deleted_at >= ?", userID, since).
+		Order("deleted_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
 }
 
-func DeletePostByUser(userID int64) error {
+// GetDeletedPostByID 获取回收站中的动态
+func GetDeletedPostByID(postID int64) (*models.Post, error) {
 	var post models.Post
-	err := db.Where("user_id = ?", userID).Delete(&post).Error
+	err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", postID).First(&post).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorPostNotExist
+	}
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return &post, nil
+}
+
+// RestorePost 从回收站恢复动态
+func RestorePost(postID int64) error {
+	return db.Unscoped().Model(&models.Post{}).Where("id = ?", postID).Update("deleted_at", nil).Error
+}
+
+// GetExpiredDeletedPostIDs 获取before之前删除、需要彻底清除的动态ID
+func GetExpiredDeletedPostIDs(before time.Time, limit int) ([]int64, error) {
+	var ids []int64
+	err := db.Unscoped().Model(&models.Post{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// PurgePosts 彻底删除动态及其点赞、评论、可见名单、编辑历史、图片、话题与提及
+func PurgePosts(postIDs []int64) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		related := []interface{}{&models.PostLike{}, &models.Comment{}, &models.PostAudience{}, &models.PostEdit{}, &models.PostVisit{}, &models.PostImage{}, &models.PostTopic{}, &models.PostMention{}, &models.Bookmark{}}
+		for _, model := range related {
+			if err := tx.Where("post_id IN ?", postIDs).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := deletePostPolls(tx, postIDs); err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", postIDs).Delete(&models.Post{}).Error
+	})
+}
+
+// GetPostsByIDs 按ID批量获取未删除的动态
+func GetPostsByIDs(postIDs []int64) ([]models.Post, error) {
+	var posts []models.Post
+	if len(postIDs) == 0 {
+		return posts, nil
+	}
+	err := db.Where("id IN ?", postIDs).Find(&posts).Error
+	return posts, err
+}
+
+// CountReposts 批量统计动态被转发的次数(不含已删除的转发)
+func CountReposts(postIDs []int64) (map[int64]int64, error) {
+	var rows []struct {
+		RepostOfID int64
+		Count      int64
+	}
+	err := db.Model(&models.Post{}).
+		Select("repost_of_id, COUNT(*) AS count").
+		Where("repost_of_id IN ?", postIDs).
+		Group("repost_of_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int64]int64, len(rows))
+	for _, r := range rows {
+		counts[r.RepostOfID] = r.Count
 	}
-	return nil
+	return counts, nil
 }
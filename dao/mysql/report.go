@@ -0,0 +1,183 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gosocial/models"
+)
+
+// CreateReport 记录举报，并归入该对象未结案的审核工单(不存在时新建)
+// 工单的OpenKey唯一索引保证同一对象只有一个未结工单，举报的唯一索引保证同一用户不会重复举报
+func CreateReport(c *models.ModerationCase, report *models.Report) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(c).Error
+		if err != nil {
+			return err
+		}
+		// 工单已存在时插入被忽略，c.ID不可信，按OpenKey重新查询
+		var open models.ModerationCase
+		if err = tx.Where("open_key = ?", *c.OpenKey).First(&open).Error; err != nil {
+			return err
+		}
+		*c = open
+		report.CaseID = c.ID
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrorAlreadyReported
+		}
+		return tx.Model(&models.ModerationCase{}).Where("id = ?", c.ID).
+			Updates(map[string]interface{}{
+				"report_count":     gorm.Expr("report_count + 1"),
+				"last_reported_at": report.CreatedAt,
+			}).Error
+	})
+}
+
+// GetModerationCases 按状态获取审核工单，按最近举报时间降序排序，status为空时返回全部
+func GetModerationCases(status string, offset, limit int) ([]models.ModerationCase, error) {
+	var cases []models.ModerationCase
+	query := db.Model(&models.ModerationCase{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("last_reported_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&cases).Error
+	return cases, err
+}
+
+// GetModerationCaseByID 获取审核工单
+func GetModerationCaseByID(caseID int64) (*models.ModerationCase, error) {
+	var c models.ModerationCase
+	err := db.Where("id = ?", caseID).First(&c).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorCaseNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetCaseReports 获取工单下的举报记录，按举报时间升序排序
+func GetCaseReports(caseID int64) ([]models.Report, error) {
+	var reports []models.Report
+	err := db.Where("case_id = ?", caseID).Order("created_at, id").Find(&reports).Error
+	return reports, err
+}
+
+// ClaimModerationCase 认领待处理的工单，已被自己认领时视为成功
+func ClaimModerationCase(caseID, moderatorID int64) error {
+	result := db.Model(&models.ModerationCase{}).
+		Where("id = ? AND (status = ? OR (status = ? AND assignee_id = ?))",
+			caseID, models.CaseStatusPending, models.CaseStatusReviewing, moderatorID).
+		Updates(map[string]interface{}{"status": models.CaseStatusReviewing, "assignee_id": moderatorID})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	c, err := GetModerationCaseByID(caseID)
+	if err != nil {
+		return err
+	}
+	if c.Status == models.CaseStatusReviewing {
+		if c.AssigneeID == moderatorID {
+			return nil
+		}
+		return ErrorCaseClaimed
+	}
+	return ErrorCaseClosed
+}
+
+// CloseModerationCase 结案并写入审核日志，只有认领该工单的管理员可以结案
+func CloseModerationCase(c *models.ModerationCase, logs []models.ModerationLog) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ModerationCase{}).
+			Where("id = ? AND status = ? AND assignee_id = ?", c.ID, models.CaseStatusReviewing, c.AssigneeID).
+			Updates(map[string]interface{}{
+				"status":     c.Status,
+				"open_key":   nil,
+				"resolution": c.Resolution,
+				"note":       c.Note,
+				"closed_at":  c.ClosedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrorCaseClosed
+		}
+		if len(logs) == 0 {
+			return nil
+		}
+		return tx.Create(&logs).Error
+	})
+}
+
+// CreateModerationLog 写入审核日志
+func CreateModerationLog(log *models.ModerationLog) error {
+	return db.Create(log).Error
+}
+
+// GetModerationLogs 获取审核日志，按时间降序排序，moderatorID或targetUserID为0时不按该条件过滤
+func GetModerationLogs(moderatorID, targetUserID int64, offset, limit int) ([]models.ModerationLog, error) {
+	var logs []models.ModerationLog
+	query := db.Model(&models.ModerationLog{})
+	if moderatorID != 0 {
+		query = query.Where("moderator_id = ?", moderatorID)
+	}
+	if targetUserID != 0 {
+		query = query.Where("target_user_id = ?", targetUserID)
+	}
+	err := query.Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&logs).Error
+	return logs, err
+}
+
+// HidePost 管理员隐藏动态，动态仅作者本人可见
+func HidePost(postID int64) error {
+	return db.Model(&models.Post{}).Unscoped().
+		Where("id = ?", postID).
+		Update("visibility", models.PostVisibilityHidden).Error
+}
+
+// GetPostByIDUnscoped 获取动态，包括作者已删除到回收站的动态
+func GetPostByIDUnscoped(postID int64) (*models.Post, error) {
+	var post models.Post
+	err := db.Unscoped().Where("id = ?", postID).First(&post).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorPostNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// GetRecentPostsByUserID 获取用户最近发布的动态(不区分可见范围)，供管理员审核账号使用
+func GetRecentPostsByUserID(userID int64, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	err := db.Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
+
+// SuspendUser 封禁用户至指定时间
+func SuspendUser(userID int64, until time.Time) error {
+	return db.Model(&models.User{}).
+		Where("user_id = ?", userID).
+		Update("suspended_until", until).Error
+}
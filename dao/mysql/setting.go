@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gosocial/models"
+)
+
+// GetUserSetting 获取用户设置，用户未保存过设置时返回默认值
+func GetUserSetting(userID int64) (*models.UserSetting, error) {
+	var setting models.UserSetting
+	err := db.Where("user_id = ?", userID).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		setting = models.DefaultUserSetting(userID)
+		return &setting, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// UpdateUserSetting 更新用户设置，记录不存在时先按默认值创建
+func UpdateUserSetting(userID int64, updateData map[string]interface{}) error {
+	if len(updateData) == 0 {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		var setting models.UserSetting
+		if err := tx.Where(models.UserSetting{UserID: userID}).
+			FirstOrCreate(&setting).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.UserSetting{}).
+			Where("user_id = ?", userID).
+			Updates(updateData).Error
+	})
+}
+
+// GetUserSettings 批量获取用户设置，无记录的用户使用默认值
+func GetUserSettings(userIDs []int64) (map[int64]models.UserSetting, error) {
+	settings := make(map[int64]models.UserSetting, len(userIDs))
+	if len(userIDs) == 0 {
+		return settings, nil
+	}
+	var rows []models.UserSetting
+	if err := db.Where("user_id IN ?", userIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range userIDs {
+		settings[id] = models.DefaultUserSetting(id)
+	}
+	for _, r := range rows {
+		settings[r.UserID] = r
+	}
+	return settings, nil
+}
@@ -0,0 +1,96 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gosocial/models"
+)
+
+// savePostTopics 写入动态关联的话题，不存在的话题自动创建
+func savePostTopics(tx *gorm.DB, postID int64, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	topics := make([]models.Topic, 0, len(names))
+	for _, name := range names {
+		topics = append(topics, models.Topic{Name: name})
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&topics).Error; err != nil {
+		return err
+	}
+	var ids []int64
+	if err := tx.Model(&models.Topic{}).Where("name IN ?", names).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	links := make([]models.PostTopic, 0, len(ids))
+	for _, id := range ids {
+		links = append(links, models.PostTopic{TopicID: id, PostID: postID})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
+
+// savePostMentions 写入动态中提及的用户，已提及过的用户忽略
+func savePostMentions(tx *gorm.DB, postID int64, userIDs []int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	mentions := make([]models.PostMention, 0, len(userIDs))
+	for _, uid := range userIDs {
+		mentions = append(mentions, models.PostMention{PostID: postID, UserID: uid})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&mentions).Error
+}
+
+// GetPostMentions 批量获取动态中提及过的用户
+func GetPostMentions(postIDs []int64) ([]models.PostMention, error) {
+	var mentions []models.PostMention
+	if len(postIDs) == 0 {
+		return mentions, nil
+	}
+	err := db.Where("post_id IN ?", postIDs).Find(&mentions).Error
+	return mentions, err
+}
+
+// GetTopicByName 通过名称获取话题
+func GetTopicByName(name string) (*models.Topic, error) {
+	var topic models.Topic
+	err := db.Where("name = ?", name).First(&topic).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrorTopicNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &topic, nil
+}
+
+// topicVisibleCond 话题页的可见条件：自己的动态全部可见，好友的动态按可见范围过滤，
+// 其他用户仅公开动态可见，且双方不存在拉黑关系
+func topicVisibleCond(viewerID int64, friendIDs []int64) *gorm.DB {
+	cond := db.Where("posts.user_id = ?", viewerID)
+	if len(friendIDs) > 0 {
+		cond = cond.Or(db.Where("posts.user_id IN ?", friendIDs).
+			Where(friendVisibleSQL, friendVisibleArgs(viewerID)))
+	}
+	return cond.Or(db.Where("posts.visibility = ?", models.PostVisibilityPublic).
+		Where("posts.user_id NOT IN (SELECT blocked_id FROM blocks WHERE user_id = ?)", viewerID).
+		Where("posts.user_id NOT IN (SELECT user_id FROM blocks WHERE blocked_id = ?)", viewerID))
+}
+
+// GetTopicPosts 获取话题下查看者可见的、早于(beforeAt, beforeID)的动态，按发布时间降序排序
+func GetTopicPosts(viewerID int64, friendIDs []int64, topicID int64, beforeAt time.Time, beforeID int64, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	query := db.Joins("JOIN post_topics pt ON pt.post_id = posts.id AND pt.topic_id = ?", topicID).
+		Where(topicVisibleCond(viewerID, friendIDs))
+	if !beforeAt.IsZero() {
+		query = query.Where("posts.created_at < ? OR (posts.created_at = ? AND posts.id < ?)", beforeAt, beforeAt, beforeID)
+	}
+	err := query.
+		Order("posts.created_at DESC, posts.id DESC").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
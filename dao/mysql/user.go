@@ -3,7 +3,10 @@ package mysql
 import (
 	"errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gosocial/models"
+	"strings"
+	"time"
 )
 
 // CheckEmail 检查邮箱是否唯一
@@ -103,3 +106,88 @@ func UpdateAge(uid int64, age int) error {
 		Where("user_id = ?", uid).
 		Update("age", age).Error
 }
+
+// SearchUsers 全站搜索用户
+// 用户名支持完全/前缀/包含匹配，邮箱与UID仅支持完全匹配；
+// 结果遵循被搜索用户的可搜索设置，并排除与当前用户存在拉黑关系的用户
+func SearchUsers(userID int64, keyword string, uid int64, offset, limit int) ([]models.User, error) {
+	var users []models.User
+	like := escapeLike(keyword)
+	// 匹配优先级：3完全匹配 2前缀匹配 1包含匹配
+	rank := clause.Expr{
+		SQL: "CASE WHEN (users.username = ? AND COALESCE(s.allow_search_by_username, TRUE)) " +
+			"OR (users.email = ? AND COALESCE(s.allow_search_by_email, TRUE)) " +
+			"OR (users.user_id = ? AND COALESCE(s.allow_search_by_uid, TRUE)) THEN 3 " +
+			"WHEN users.username LIKE ? AND COALESCE(s.allow_search_by_username, TRUE) THEN 2 " +
+			"ELSE 1 END DESC, users.user_id",
+		Vars:               []interface{}{keyword, keyword, uid, like + "%"},
+		WithoutParentheses: true,
+	}
+	err := db.Model(&models.User{}).
+		Select("users.*").
+		Joins("LEFT JOIN user_settings s ON s.user_id = users.user_id").
+		Where("users.user_id <> ?", userID).
+		Where(db.Where("users.username LIKE ? AND COALESCE(s.allow_search_by_username, TRUE)", "%"+like+"%").
+			Or("users.email = ? AND COALESCE(s.allow_search_by_email, TRUE)", keyword).
+			Or("users.user_id = ? AND COALESCE(s.allow_search_by_uid, TRUE)", uid)).
+		Where("users.user_id NOT IN (?)", db.Model(&models.Block{}).Select("blocked_id").Where("user_id = ?", userID)).
+		Where("users.user_id NOT IN (?)", db.Model(&models.Block{}).Select("user_id").Where("blocked_id = ?", userID)).
+		Order(clause.OrderBy{Expression: rank}).
+		Offset(offset).
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+// escapeLike 转义LIKE查询中的通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// GetUsersByUIDs 批量获取用户信息
+func GetUsersByUIDs(uids []int64) ([]models.User, error) {
+	var users []models.User
+	if len(uids) == 0 {
+		return users, nil
+	}
+	err := db.Where("user_id IN ?", uids).Find(&users).Error
+	return users, err
+}
+
+// GetUserIDsByBirthday 获取生日为指定月日的用户ID
+func GetUserIDsByBirthday(month, day int) ([]int64, error) {
+	var ids []int64
+	err := db.Model(&models.User{}).
+		Where("MONTH(birthday) = ? AND DAY(birthday) = ?", month, day).
+		Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+// GetUserBirthdays 批量获取已填写生日的用户的生日
+func GetUserBirthdays(uids []int64) (map[int64]time.Time, error) {
+	birthdays := make(map[int64]time.Time, len(uids))
+	if len(uids) == 0 {
+		return birthdays, nil
+	}
+	var users []models.User
+	err := db.Select("user_id", "birthday").
+		Where("user_id IN ? AND birthday IS NOT NULL", uids).
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		birthdays[u.UserID] = *u.Birthday
+	}
+	return birthdays, nil
+}
+
+// GetUsersByUsernames 批量获取指定用户名的用户(用户名可能重复)
+func GetUsersByUsernames(names []string) ([]models.User, error) {
+	var users []models.User
+	if len(names) == 0 {
+		return users, nil
+	}
+	err := db.Where("username IN ?", names).Find(&users).Error
+	return users, err
+}
@@ -0,0 +1,48 @@
+package mysql
+
+import (
+	"gorm.io/gorm/clause"
+	"gosocial/models"
+	"time"
+)
+
+// RecordPostVisit 记录访客浏览动态，已有记录时更新最近浏览时间
+func RecordPostVisit(postID, viewerID int64, at time.Time) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "post_id"}, {Name: "viewer_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"visited_at"}),
+	}).Create(&models.PostVisit{PostID: postID, ViewerID: viewerID, VisitedAt: at}).Error
+}
+
+// visitorVisibleSQL 访客记录的读取条件：浏览者已开启不留访客记录、或与作者之间存在拉黑关系时不展示
+// 设置与拉黑可能在浏览之后才变化，因此在读取时过滤
+const visitorVisibleSQL = `NOT EXISTS (SELECT 1 FROM user_settings WHERE user_settings.user_id = post_visits.viewer_id AND user_settings.hide_visit_record = TRUE) ` +
+	`AND NOT EXISTS (SELECT 1 FROM blocks WHERE (blocks.user_id = @author AND blocks.blocked_id = post_visits.viewer_id) ` +
+	`OR (blocks.user_id = post_visits.viewer_id AND blocks.blocked_id = @author))`
+
+// GetPostVisitors 获取单条动态的访客，按最近浏览时间降序排序，authorID为动态作者
+func GetPostVisitors(postID, authorID int64, offset, limit int) ([]models.PostVisit, error) {
+	var visits []models.PostVisit
+	err := db.Preload("Viewer").
+		Where("post_id = ?", postID).
+		Where(visitorVisibleSQL, map[string]interface{}{"author": authorID}).
+		Order("visited_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&visits).Error
+	return visits, err
+}
+
+// GetUserPostVisitors 获取用户所有动态的访客记录，按最近浏览时间降序排序
+func GetUserPostVisitors(authorID int64, offset, limit int) ([]models.PostVisit, error) {
+	var visits []models.PostVisit
+	err := db.Preload("Viewer").
+		Joins("JOIN posts ON posts.id = post_visits.post_id").
+		Where("posts.user_id = ? AND posts.deleted_at IS NULL", authorID).
+		Where(visitorVisibleSQL, map[string]interface{}{"author": authorID}).
+		Order("post_visits.visited_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&visits).Error
+	return visits, err
+}
@@ -0,0 +1,26 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DailyJobPrefix = "daily_job:"   // 每日任务执行标记key前缀(STRING，后缀为任务名与日期)
+	DailyJobTTL    = 48 * time.Hour // 标记保留时间，覆盖当天即可
+)
+
+// MarkDailyJobDone 标记每日任务在指定日期已执行，返回false表示当天已有其他实例或此前的任务执行过
+func MarkDailyJobDone(ctx context.Context, job, date string) (bool, error) {
+	return rdb.SetNX(ctx, dailyJobKey(job, date), 1, DailyJobTTL).Result()
+}
+
+// UnmarkDailyJobDone 清除每日任务的执行标记，任务执行失败后允许重试
+func UnmarkDailyJobDone(ctx context.Context, job, date string) error {
+	return rdb.Del(ctx, dailyJobKey(job, date)).Err()
+}
+
+// dailyJobKey 生成每日任务执行标记key
+func dailyJobKey(job, date string) string {
+	return DailyJobPrefix + job + ":" + date
+}
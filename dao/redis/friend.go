@@ -0,0 +1,82 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	FriendCachePrefix = "friends:"     // 好友缓存key前缀(HASH，field为好友ID，value为备注)
+	FriendCacheTTL    = 24 * time.Hour // 好友缓存过期时间
+	friendCacheEmpty  = "-"            // 空好友列表占位，避免缓存穿透，不能是合法的用户ID
+)
+
+// GetFriendCache 获取用户的好友缓存(好友ID -> 备注)，缓存不存在时ok为false
+func GetFriendCache(ctx context.Context, userID int64) (remarks map[int64]string, ok bool, err error) {
+	result, err := rdb.HGetAll(ctx, friendCacheKey(userID)).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(result) == 0 {
+		return nil, false, nil
+	}
+	remarks = make(map[int64]string, len(result))
+	for k, v := range result {
+		if k == friendCacheEmpty {
+			continue
+		}
+		friendID, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid friendID format: %v", err)
+		}
+		remarks[friendID] = v
+	}
+	return remarks, true, nil
+}
+
+// SetFriendCache 写入用户的好友缓存
+func SetFriendCache(ctx context.Context, userID int64, remarks map[int64]string) error {
+	key := friendCacheKey(userID)
+	values := make(map[string]interface{}, len(remarks)+1)
+	values[friendCacheEmpty] = ""
+	for friendID, remark := range remarks {
+		values[strconv.FormatInt(friendID, 10)] = remark
+	}
+	pipe := rdb.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key, values)
+	pipe.Expire(ctx, key, FriendCacheTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// IsFriendCached 通过缓存判断是否为好友，缓存不存在时ok为false
+func IsFriendCached(ctx context.Context, userID, friendID int64) (isFriend, ok bool, err error) {
+	key := friendCacheKey(userID)
+	pipe := rdb.Pipeline()
+	existsCmd := pipe.Exists(ctx, key)
+	memberCmd := pipe.HExists(ctx, key, strconv.FormatInt(friendID, 10))
+	if _, err = pipe.Exec(ctx); err != nil {
+		return false, false, err
+	}
+	if existsCmd.Val() == 0 {
+		return false, false, nil
+	}
+	return memberCmd.Val(), true, nil
+}
+
+// DelFriendCache 删除用户的好友缓存
+func DelFriendCache(ctx context.Context, userIDs ...int64) error {
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = friendCacheKey(id)
+	}
+	return rdb.Del(ctx, keys...).Err()
+}
+
+// friendCacheKey 生成好友缓存key
+func friendCacheKey(userID int64) string {
+	return fmt.Sprintf("%s%d", FriendCachePrefix, userID)
+}
@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	InteractPendingKey = "interact:pending" // 待落库的最后互动时间(ZSET，member为好友对，score为时间戳)
+	IntimacyPendingKey = "intimacy:pending" // 待落库的亲密度增量(HASH，field为好友对)
+)
+
+// Interaction 一对好友在一个批次内的互动汇总
+type Interaction struct {
+	UserID   int64
+	FriendID int64
+	At       time.Time // 最后互动时间
+	Weight   float64   // 累计亲密度增量
+}
+
+// RecordInteraction 记录一次好友互动，等待批量落库
+func RecordInteraction(ctx context.Context, userID, friendID int64, at time.Time, weight float64) error {
+	member := pairKey(userID, friendID)
+	pipe := rdb.TxPipeline()
+	pipe.ZAdd(ctx, InteractPendingKey, &redis.Z{
+		Score:  float64(at.Unix()),
+		Member: member,
+	})
+	pipe.HIncrByFloat(ctx, IntimacyPendingKey, member, weight)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// PopInteractions 取出并清空所有待落库的互动记录
+func PopInteractions(ctx context.Context) ([]Interaction, error) {
+	pipe := rdb.TxPipeline()
+	atCmd := pipe.ZRangeWithScores(ctx, InteractPendingKey, 0, -1)
+	weightCmd := pipe.HGetAll(ctx, IntimacyPendingKey)
+	pipe.Del(ctx, InteractPendingKey, IntimacyPendingKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	weights := weightCmd.Val()
+	interactions := make([]Interaction, 0, len(atCmd.Val()))
+	for _, z := range atCmd.Val() {
+		member, _ := z.Member.(string)
+		userID, friendID, err := parsePairKey(member)
+		if err != nil {
+			return nil, err
+		}
+		weight, _ := strconv.ParseFloat(weights[member], 64)
+		interactions = append(interactions, Interaction{
+			UserID:   userID,
+			FriendID: friendID,
+			At:       time.Unix(int64(z.Score), 0),
+			Weight:   weight,
+		})
+	}
+	return interactions, nil
+}
+
+// requeueScript 将落库失败的互动记录放回待落库队列，期间有新的互动时保留较晚的时间并累加亲密度增量
+// ARGV依次为好友对、时间戳与亲密度增量
+var requeueScript = redis.NewScript(`
+for i = 1, #ARGV, 3 do
+	local at = redis.call("ZSCORE", KEYS[1], ARGV[i])
+	if not at or tonumber(at) < tonumber(ARGV[i + 1]) then
+		redis.call("ZADD", KEYS[1], ARGV[i + 1], ARGV[i])
+	end
+	redis.call("HINCRBYFLOAT", KEYS[2], ARGV[i], ARGV[i + 2])
+end
+return 0
+`)
+
+// RequeueInteractions 将落库失败的互动记录放回待落库队列，下次重试
+func RequeueInteractions(ctx context.Context, interactions []Interaction) error {
+	if len(interactions) == 0 {
+		return nil
+	}
+	args := make([]interface{}, 0, len(interactions)*3)
+	for _, it := range interactions {
+		args = append(args, pairKey(it.UserID, it.FriendID), it.At.Unix(), it.Weight)
+	}
+	return requeueScript.Run(ctx, rdb, []string{InteractPendingKey, IntimacyPendingKey}, args...).Err()
+}
+
+// pairKey 生成与顺序无关的好友对标识
+func pairKey(user1, user2 int64) string {
+	if user1 > user2 {
+		user1, user2 = user2, user1
+	}
+	return fmt.Sprintf("%d:%d", user1, user2)
+}
+
+// parsePairKey 解析好友对标识
+func parsePairKey(key string) (int64, int64, error) {
+	parts := strings.SplitN(key, ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid pair key: %s", key)
+	}
+	user1, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid pair key: %s", key)
+	}
+	user2, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid pair key: %s", key)
+	}
+	return user1, user2, nil
+}
@@ -0,0 +1,136 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	PostLikeCountPrefix = "post:like_count:"      // 动态点赞数key前缀
+	PostLikeDirtyKey    = "post:like_count:dirty" // 点赞数有变化、待回写MySQL的动态ID集合
+	PostLikeCountTTL    = 7 * 24 * time.Hour      // 点赞数缓存过期时间
+)
+
+// incrLikeCountScript 标记动态为待回写，计数已加载到缓存时才增减
+// 未加载时不增减，下次读取会从MySQL重新统计，回写任务也会重新统计该动态
+var incrLikeCountScript = redis.NewScript(`
+redis.call("SADD", KEYS[2], ARGV[2])
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("INCRBY", KEYS[1], ARGV[1])
+end
+return false
+`)
+
+// IncrPostLikeCount 增减动态点赞数并标记为待回写
+func IncrPostLikeCount(ctx context.Context, postID, delta int64) error {
+	err := incrLikeCountScript.Run(ctx, rdb,
+		[]string{postLikeCountKey(postID), PostLikeDirtyKey},
+		delta, postID).Err()
+	if err == redis.Nil {
+		return nil
+	}
+	return err
+}
+
+// GetPostLikeCounts 批量获取缓存中的动态点赞数，未命中的动态不在返回结果中
+func GetPostLikeCounts(ctx context.Context, postIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+	keys := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		keys[i] = postLikeCountKey(id)
+	}
+	values, err := rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if count, err := strconv.ParseInt(s, 10, 64); err == nil {
+			counts[postIDs[i]] = count
+		}
+	}
+	return counts, nil
+}
+
+// loadLikeCountsScript 逐个加载点赞数：已缓存的不覆盖，待回写的动态统计期间可能有并发点赞，
+// 不加载以免缓存旧值，由回写任务重新统计后加载
+// KEYS[1]为待回写集合，其余为点赞数key；ARGV[1]为过期秒数，之后依次为动态ID与点赞数
+var loadLikeCountsScript = redis.NewScript(`
+for i = 2, #KEYS do
+	local id = ARGV[i * 2 - 2]
+	if redis.call("SISMEMBER", KEYS[1], id) == 0 then
+		redis.call("SET", KEYS[i], ARGV[i * 2 - 1], "EX", ARGV[1], "NX")
+	end
+end
+return 0
+`)
+
+// SetPostLikeCounts 将从MySQL统计的点赞数加载到缓存(已存在或待回写的不加载)
+func SetPostLikeCounts(ctx context.Context, counts map[int64]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(counts)+1)
+	args := make([]interface{}, 0, len(counts)*2+1)
+	keys = append(keys, PostLikeDirtyKey)
+	args = append(args, int64(PostLikeCountTTL/time.Second))
+	for id, count := range counts {
+		keys = append(keys, postLikeCountKey(id))
+		args = append(args, id, count)
+	}
+	return loadLikeCountsScript.Run(ctx, rdb, keys, args...).Err()
+}
+
+// RefreshPostLikeCounts 用回写时从MySQL重新统计的点赞数覆盖缓存
+func RefreshPostLikeCounts(ctx context.Context, counts map[int64]int64) error {
+	pipe := rdb.Pipeline()
+	for id, count := range counts {
+		pipe.Set(ctx, postLikeCountKey(id), count, PostLikeCountTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// PopDirtyLikePosts 取出一批待回写点赞数的动态ID
+func PopDirtyLikePosts(ctx context.Context, count int64) ([]int64, error) {
+	members, err := rdb.SPopN(ctx, PostLikeDirtyKey, count).Result()
+	if err != nil {
+		return nil, err
+	}
+	postIDs := make([]int64, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			continue
+		}
+		postIDs = append(postIDs, id)
+	}
+	return postIDs, nil
+}
+
+// MarkDirtyLikePosts 将动态重新标记为待回写，用于回写失败时重试
+func MarkDirtyLikePosts(ctx context.Context, postIDs []int64) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+	members := make([]interface{}, len(postIDs))
+	for i, id := range postIDs {
+		members[i] = id
+	}
+	return rdb.SAdd(ctx, PostLikeDirtyKey, members...).Err()
+}
+
+// postLikeCountKey 生成动态点赞数key
+func postLikeCountKey(postID int64) string {
+	return fmt.Sprintf("%s%d", PostLikeCountPrefix, postID)
+}
@@ -151,3 +151,28 @@ func GetChatKey(user1, user2 int64) string {
 	}
 	return fmt.Sprintf("%s%d:%d", ChatKeyPrefix, user2, user1)
 }
+
+// GetChatMessages 按时间范围(秒)获取两人的聊天记录，供举报取证使用
+func GetChatMessages(ctx context.Context, from, to int64, start, end int64) ([]models.Message, error) {
+	return NewMessageDao(rdb).GetMessages(ctx, from, to, start, end)
+}
+
+// DeleteChatMessage 从聊天记录中删除指定私信，按发送者、发送时间与内容匹配
+func DeleteChatMessage(ctx context.Context, msg *models.Message) error {
+	key := GetChatKey(msg.From, msg.To)
+	score := fmt.Sprintf("%d", msg.CreatedAt.Unix())
+	results, err := rdb.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: score, Max: score}).Result()
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		var m models.Message
+		if err = json.Unmarshal([]byte(result), &m); err != nil {
+			continue
+		}
+		if m.From == msg.From && m.CreatedAt.Equal(msg.CreatedAt) && m.Content == msg.Content {
+			return rdb.ZRem(ctx, key, result).Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+)
+
+// PublishNotification 发布通知到接收者的频道
+func PublishNotification(ctx context.Context, userID int64, payload []byte) error {
+	channel := fmt.Sprintf("user:%d:notifications", userID)
+	return rdb.Publish(ctx, channel, payload).Err()
+}
@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"gosocial/models"
+)
+
+const (
+	ProfileCachePrefix = "profile:"     // 用户资料缓存key前缀(STRING，JSON格式的昵称与头像)
+	ProfileCacheTTL    = 24 * time.Hour // 用户资料缓存过期时间
+)
+
+// GetProfiles 一次往返批量获取用户资料缓存，返回命中的资料与未命中的用户ID
+func GetProfiles(ctx context.Context, userIDs []int64) (map[int64]models.UserProfile, []int64, error) {
+	profiles := make(map[int64]models.UserProfile, len(userIDs))
+	if len(userIDs) == 0 {
+		return profiles, nil, nil
+	}
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = profileCacheKey(id)
+	}
+	values, err := rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, userIDs, err
+	}
+	var missing []int64
+	for i, v := range values {
+		s, ok := v.(string)
+		var p models.UserProfile
+		if !ok || json.Unmarshal([]byte(s), &p) != nil {
+			missing = append(missing, userIDs[i])
+			continue
+		}
+		profiles[userIDs[i]] = p
+	}
+	return profiles, missing, nil
+}
+
+// SetProfiles 批量写入用户资料缓存
+func SetProfiles(ctx context.Context, profiles []models.UserProfile) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+	pipe := rdb.Pipeline()
+	for _, p := range profiles {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, profileCacheKey(p.UserID), data, ProfileCacheTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// DelProfile 删除用户资料缓存，用户修改昵称或头像后调用
+func DelProfile(ctx context.Context, userIDs ...int64) error {
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = profileCacheKey(id)
+	}
+	return rdb.Del(ctx, keys...).Err()
+}
+
+// profileCacheKey 生成用户资料缓存key
+func profileCacheKey(userID int64) string {
+	return ProfileCachePrefix + strconv.FormatInt(userID, 10)
+}
@@ -0,0 +1,66 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	RankedFeedPrefix = "feed:ranked:"   // 排序动态流快照key前缀(ZSET，member为动态ID，score为排序分)
+	RankedFeedTTL    = 30 * time.Minute // 快照过期时间，期间翻页顺序保持不变
+)
+
+// RankedEntry 排序快照中的一条动态
+type RankedEntry struct {
+	PostID int64
+	Score  float64
+}
+
+// SaveRankedFeed 保存用户的排序快照，snapshot为快照生成时间(毫秒)
+func SaveRankedFeed(ctx context.Context, userID, snapshot int64, entries []RankedEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	key := rankedFeedKey(userID, snapshot)
+	members := make([]*redis.Z, len(entries))
+	for i, e := range entries {
+		members[i] = &redis.Z{Score: e.Score, Member: e.PostID}
+	}
+	pipe := rdb.TxPipeline()
+	pipe.ZAdd(ctx, key, members...)
+	pipe.Expire(ctx, key, RankedFeedTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ReadRankedFeed 按排序分降序读取快照中从offset开始的动态ID
+// exists为false表示快照已过期，more表示是否还有下一页
+func ReadRankedFeed(ctx context.Context, userID, snapshot int64, offset, limit int) (ids []int64, more, exists bool, err error) {
+	key := rankedFeedKey(userID, snapshot)
+	pipe := rdb.Pipeline()
+	cardCmd := pipe.ZCard(ctx, key)
+	rangeCmd := pipe.ZRevRange(ctx, key, int64(offset), int64(offset+limit-1))
+	if _, err = pipe.Exec(ctx); err != nil {
+		return nil, false, false, err
+	}
+	total := cardCmd.Val()
+	if total == 0 {
+		return nil, false, false, nil
+	}
+	for _, member := range rangeCmd.Val() {
+		id, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, int64(offset+limit) < total, true, nil
+}
+
+func rankedFeedKey(userID, snapshot int64) string {
+	return fmt.Sprintf("%s%d:%d", RankedFeedPrefix, userID, snapshot)
+}
@@ -0,0 +1,39 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	SuspendedKeyPrefix = "suspended:"     // 封禁状态key前缀(STRING)，封禁时值为截止时间戳、过期时间即封禁截止时间，未封禁时值为0
+	NotSuspendedTTL    = 10 * time.Minute // 未封禁状态的缓存时间
+)
+
+// SetSuspended 缓存用户的封禁状态，供鉴权中间件快速判断，until不晚于当前时间表示未封禁
+func SetSuspended(ctx context.Context, userID int64, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return rdb.Set(ctx, suspendedKey(userID), 0, NotSuspendedTTL).Err()
+	}
+	return rdb.Set(ctx, suspendedKey(userID), until.Unix(), ttl).Err()
+}
+
+// GetSuspended 读取缓存的封禁状态，缓存不存在时ok为false
+func GetSuspended(ctx context.Context, userID int64) (suspended, ok bool, err error) {
+	value, err := rdb.Get(ctx, suspendedKey(userID)).Result()
+	if err == redis.Nil {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return value != "0", true, nil
+}
+
+func suspendedKey(userID int64) string {
+	return SuspendedKeyPrefix + strconv.FormatInt(userID, 10)
+}
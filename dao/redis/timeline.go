@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	TimelinePrefix   = "timeline:"             // 用户动态时间线key前缀(ZSET，member为动态ID，score为发布时间毫秒)
+	TimelinePullKey  = "timeline:pull_authors" // 采用读扩散的大账号ID集合
+	TimelineMaxLen   = 1000                    // 每条时间线保留的动态数，更早的动态回源MySQL
+	TimelineTTL      = 7 * 24 * time.Hour      // 时间线过期时间，读取时续期
+	timelineBuilt    = "0"                     // 时间线已构建的占位成员，score为-1，始终排在最低位
+	timelineTieSlack = 16                      // 同一毫秒内多条动态时额外读取的条数
+)
+
+// TimelineEntry 时间线中的一条动态
+type TimelineEntry struct {
+	PostID int64
+	At     int64 // 发布时间(毫秒)
+}
+
+// timelinePushScript 仅向已构建的时间线写入动态，并裁剪到最大长度(保留占位成员)
+var timelinePushScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	redis.call("ZADD", KEYS[1], ARGV[1], ARGV[2])
+	redis.call("ZREMRANGEBYRANK", KEYS[1], 1, -(tonumber(ARGV[3]) + 1))
+end
+return 0
+`)
+
+// TimelineExists 判断用户的时间线是否已构建
+func TimelineExists(ctx context.Context, userID int64) (bool, error) {
+	n, err := rdb.Exists(ctx, timelineKey(userID)).Result()
+	return n > 0, err
+}
+
+// BuildTimeline 用给定动态重建用户的时间线
+func BuildTimeline(ctx context.Context, userID int64, entries []TimelineEntry) error {
+	key := timelineKey(userID)
+	members := make([]*redis.Z, 0, len(entries)+1)
+	members = append(members, &redis.Z{Score: -1, Member: timelineBuilt})
+	for _, e := range entries {
+		members = append(members, &redis.Z{Score: float64(e.At), Member: e.PostID})
+	}
+	pipe := rdb.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.ZAdd(ctx, key, members...)
+	pipe.Expire(ctx, key, TimelineTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// PushToTimelines 将动态写入多个用户的时间线(写扩散)，未构建的时间线跳过，读取时再从MySQL构建
+func PushToTimelines(ctx context.Context, userIDs []int64, entry TimelineEntry) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	pipe := rdb.Pipeline()
+	for _, uid := range userIDs {
+		timelinePushScript.Eval(ctx, pipe, []string{timelineKey(uid)}, entry.At, entry.PostID, TimelineMaxLen)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ReadTimeline 读取时间线中早于(beforeAt, beforeID)的动态，beforeAt为0时从最新开始
+// more表示时间线中是否还有更早的动态
+func ReadTimeline(ctx context.Context, userID, beforeAt, beforeID int64, limit int) (entries []TimelineEntry, more bool, err error) {
+	key := timelineKey(userID)
+	max := "+inf"
+	if beforeAt > 0 {
+		max = strconv.FormatInt(beforeAt, 10)
+	}
+	count := int64(limit + timelineTieSlack)
+	pipe := rdb.Pipeline()
+	rangeCmd := pipe.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Max: max, Min: "(0", Count: count})
+	pipe.Expire(ctx, key, TimelineTTL)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return nil, false, err
+	}
+	result := rangeCmd.Val()
+	entries = make([]TimelineEntry, 0, len(result))
+	for _, z := range result {
+		id, err := strconv.ParseInt(fmt.Sprint(z.Member), 10, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid postID format: %v", err)
+		}
+		at := int64(z.Score)
+		// 同一毫秒内的动态按ID排序，跳过游标位置及之前已返回的动态
+		if beforeAt > 0 && at == beforeAt && id >= beforeID {
+			continue
+		}
+		entries = append(entries, TimelineEntry{PostID: id, At: at})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].At != entries[j].At {
+			return entries[i].At > entries[j].At
+		}
+		return entries[i].PostID > entries[j].PostID
+	})
+	more = int64(len(result)) == count || len(entries) > limit
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, more, nil
+}
+
+// DelTimeline 删除用户的时间线，下次读取时重新构建
+func DelTimeline(ctx context.Context, userIDs ...int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = timelineKey(id)
+	}
+	return rdb.Del(ctx, keys...).Err()
+}
+
+// AddPullAuthor 将账号标记为读扩散账号
+func AddPullAuthor(ctx context.Context, userID int64) error {
+	return rdb.SAdd(ctx, TimelinePullKey, userID).Err()
+}
+
+// GetPullAuthors 获取所有读扩散账号ID
+func GetPullAuthors(ctx context.Context) (map[int64]bool, error) {
+	members, err := rdb.SMembers(ctx, TimelinePullKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	authors := make(map[int64]bool, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid userID format: %v", err)
+		}
+		authors[id] = true
+	}
+	return authors, nil
+}
+
+func timelineKey(userID int64) string {
+	return fmt.Sprintf("%s%d", TimelinePrefix, userID)
+}
@@ -0,0 +1,104 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	PostViewedPrefix      = "post:viewed:"         // 用户浏览过某条动态的标记key前缀(STRING)，格式为post:viewed:<动态ID>:<用户ID>
+	PostViewPendingKey    = "post:view:pending"    // 待回写MySQL的浏览量增量(HASH，field为动态ID)
+	PostViewProcessingKey = "post:view:processing" // 正在回写MySQL的浏览量增量，回写成功后删除(HASH)
+	PostViewWindow        = 24 * time.Hour         // 同一用户在上次计数后的该时长内重复浏览不再计数
+	PhotoViewedPrefix     = "photo:viewed:"        // 用户浏览过某张照片的标记key前缀(STRING)，有效期同PostViewWindow
+)
+
+// RecordPostView 记录一次动态浏览，距该用户上次计数不足PostViewWindow的重复浏览不计数
+// 计数时累加待回写的浏览量并返回true
+func RecordPostView(ctx context.Context, postID, viewerID int64) (bool, error) {
+	key := fmt.Sprintf("%s%d:%d", PostViewedPrefix, postID, viewerID)
+	first, err := rdb.SetNX(ctx, key, 1, PostViewWindow).Result()
+	if err != nil || !first {
+		return false, err
+	}
+	return true, rdb.HIncrBy(ctx, PostViewPendingKey, strconv.FormatInt(postID, 10), 1).Err()
+}
+
+// GetPendingPostViews 批量获取尚未回写MySQL的浏览量增量，包括正在回写的部分
+func GetPendingPostViews(ctx context.Context, postIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+	fields := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		fields[i] = strconv.FormatInt(id, 10)
+	}
+	pipe := rdb.Pipeline()
+	pendingCmd := pipe.HMGet(ctx, PostViewPendingKey, fields...)
+	processingCmd := pipe.HMGet(ctx, PostViewProcessingKey, fields...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	for _, values := range [][]interface{}{pendingCmd.Val(), processingCmd.Val()} {
+		for i, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				continue
+			}
+			if count, err := strconv.ParseInt(s, 10, 64); err == nil {
+				counts[postIDs[i]] += count
+			}
+		}
+	}
+	return counts, nil
+}
+
+// takePendingScript 回写中的key不存在时将待回写的增量改名为回写中的key，返回回写中的全部增量
+var takePendingScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[2]) == 0 and redis.call("EXISTS", KEYS[1]) == 1 then
+	redis.call("RENAME", KEYS[1], KEYS[2])
+end
+return redis.call("HGETALL", KEYS[2])
+`)
+
+// TakePendingPostViews 将待回写的浏览量增量移入回写中的key并返回
+// 上次回写失败留下的增量尚未确认时，先返回这部分增量重新回写
+func TakePendingPostViews(ctx context.Context) (map[int64]int64, error) {
+	result, err := takePendingScript.Run(ctx, rdb, []string{PostViewPendingKey, PostViewProcessingKey}).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(result)/2)
+	for i := 0; i+1 < len(result); i += 2 {
+		values[result[i]] = result[i+1]
+	}
+	counts := make(map[int64]int64, len(values))
+	for k, v := range values {
+		postID, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid postID format: %v", err)
+		}
+		count, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid view count format: %v", err)
+		}
+		counts[postID] = count
+	}
+	return counts, nil
+}
+
+// AckPendingPostViews 回写MySQL成功后删除回写中的浏览量增量
+func AckPendingPostViews(ctx context.Context) error {
+	return rdb.Del(ctx, PostViewProcessingKey).Err()
+}
+
+// RecordPhotoView 记录一次照片浏览，距该用户上次计数不足PostViewWindow的重复浏览不计数，计数时返回true
+func RecordPhotoView(ctx context.Context, photoID, viewerID int64) (bool, error) {
+	key := fmt.Sprintf("%s%d:%d", PhotoViewedPrefix, photoID, viewerID)
+	return rdb.SetNX(ctx, key, 1, PostViewWindow).Result()
+}
@@ -24,56 +24,71 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/login": {
-            "post": {
+        "/admin/audit-logs": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "用户选择uid或者邮箱两种方式并输入自己的密码进行登录，返回用户信息及token",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "按时间倒序获取管理员的操作记录(每次20条)，可按操作人或被处置用户筛选",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "管理"
                 ],
-                "summary": "用户登录接口",
+                "summary": "审核日志",
                 "parameters": [
                     {
-                        "description": "登录参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ParamLogin"
-                        }
+                        "type": "string",
+                        "description": "操作的管理员ID",
+                        "name": "moderator_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "被处置用户ID",
+                        "name": "target_user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "登录成功，返回用户信息和令牌",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamModerationLog"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "参数格式错误（具体错误字段提示）",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "密码错误",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "用户不存在",
+                    "403": {
+                        "description": "无权限",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -87,113 +102,141 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/messages": {
+        "/admin/cases": {
             "get": {
-                "description": "获取与指定好友的聊天记录",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "管理员按最近举报时间倒序获取审核工单(每次20条)，可按状态筛选",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "消息"
+                    "管理"
                 ],
-                "summary": "获取聊天记录",
+                "summary": "审核队列",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer 用户令牌",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "好友ID",
-                        "name": "friend_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "开始时间戳(默认7天前)",
-                        "name": "start_time",
+                        "description": "工单状态：pending/reviewing/resolved/dismissed，不传则返回全部",
+                        "name": "status",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "结束时间戳(默认当前时间)",
-                        "name": "end_time",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "是否标记为已读",
-                        "name": "mark_read",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "是否获取全部历史消息",
-                        "name": "history",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"messages\":[{\"from\":发送者ID,\"to\":接收者ID,\"direct\":消息发送方向 ,\"created_at\":时间,\"content\":\"内容\",\"avatar_url\":\"头像URL\",\"hide_time\":是否隐藏时间}]}",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamModerationCase"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "错误信息",
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "向指定好友发送文本消息(图片和文件消息请使用/messages/image和/messages/file路由)",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admin/cases/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "获取工单的举报记录、举报时的内容快照、被举报内容的当前状态(含已删除到回收站的动态)、作者信息与此前受到的处置",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "消息"
+                    "管理"
                 ],
-                "summary": "发送文本消息",
+                "summary": "审核工单详情",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer 用户令牌",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "工单ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "文本消息参数",
-                        "name": "object",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ParamTextReq"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"timestamp\":时间戳,\"receiver_id\":接收者ID,\"status\":\"sent\"}",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamModerationCaseDetail"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "错误信息",
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "审核工单不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -201,46 +244,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/messages/file": {
-            "post": {
-                "description": "向指定好友发送文件消息",
-                "consumes": [
-                    "application/json"
+        "/admin/cases/{id}/claim": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "认领待处理的工单，认领后其他管理员不能处理；重复认领自己的工单视为成功",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "消息"
+                    "管理"
                 ],
-                "summary": "发送文件消息",
+                "summary": "认领审核工单",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer 用户令牌",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "工单ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "文件消息参数",
-                        "name": "object",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ParamFileReq"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"timestamp\":时间戳,\"receiver_id\":接收者ID,\"status\":\"sent\"}",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "错误信息",
+                        "description": "参数错误/工单已结案/已被其他管理员认领",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "审核工单不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -248,9 +302,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/messages/image": {
+        "/admin/cases/{id}/decision": {
             "post": {
-                "description": "向指定好友发送图片消息",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "对被举报内容执行隐藏(仅动态)或删除，对作者执行警告或封禁，并结案；内容与账号均不处置时驳回举报。未认领的工单自动认领，每项操作写入审核日志并通知作者",
                 "consumes": [
                     "application/json"
                 ],
@@ -258,36 +317,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "消息"
+                    "管理"
                 ],
-                "summary": "发送图片消息",
+                "summary": "处理审核工单",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer 用户令牌",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "工单ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "图片消息参数",
-                        "name": "object",
+                        "description": "处置方式",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ParamImageReq"
+                            "$ref": "#/definitions/models.ParamModerationDecisionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"timestamp\":时间戳,\"receiver_id\":接收者ID,\"status\":\"sent\"}",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "错误信息",
+                        "description": "参数错误/处置方式无效/工单已结案/已被其他管理员认领",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "审核工单不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -295,37 +372,126 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/messages/unread": {
+        "/albums": {
             "get": {
-                "description": "获取所有好友的未读消息数",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "获取指定用户的相册列表，第一项为自动生成的动态相册(id为0，包含发布动态时上传的图片)，其余相册只返回当前用户可见的",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "消息"
+                    "相册"
                 ],
-                "summary": "获取未读消息数",
+                "summary": "相册列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer 用户令牌",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "用户ID，不传则为自己",
+                        "name": "user_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"counts\":{\"好友ID\":未读数量}}",
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamAlbumItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "存在拉黑关系",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建相册，可见范围为public(所有人)、friends(仅好友，默认)或private(仅自己)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "相册"
+                ],
+                "summary": "创建相册",
+                "parameters": [
+                    {
+                        "description": "相册信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamCreateAlbumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamAlbumItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
-                        "description": "错误信息",
+                        "description": "参数错误/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -333,25 +499,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/posts": {
+        "/albums/post/photos": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "按时间倒序获取所有好友的动态(每次10条)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "获取自动生成的动态相册中的图片(每次20张)，按动态发布时间倒序排列，只包含当前用户可见的、未删除的动态中的图片；post_id为图片所属动态",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "动态"
+                    "相册"
                 ],
-                "summary": "获取好友动态列表",
+                "summary": "动态相册照片列表",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID，不传则为自己",
+                        "name": "user_id",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "偏移量(默认0)",
@@ -361,7 +530,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功获取动态列表",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -373,7 +542,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                                                "$ref": "#/definitions/models.ParamPhotoItem"
                                             }
                                         }
                                     }
@@ -381,8 +550,14 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "存在拉黑关系",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -394,45 +569,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/albums/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "创建用户动态(支持纯文本、纯图片、图文混合)",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "获取相册信息、封面与照片数，不可见的相册视为不存在",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "动态"
+                    "相册"
                 ],
-                "summary": "创建用户动态",
+                "summary": "相册详情",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "文字内容(不超过500字)",
-                        "name": "content",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "file"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "图片文件(最多9张,支持jpg/jpeg/png)",
-                        "name": "images",
-                        "in": "formData"
+                        "type": "integer",
+                        "description": "相册ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "动态创建成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -442,7 +607,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Post"
+                                            "$ref": "#/definitions/models.ParamAlbumItem"
                                         }
                                     }
                                 }
@@ -450,19 +615,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "参数错误/图片格式错误/图片过多",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "413": {
-                        "description": "文件过大",
+                    "404": {
+                        "description": "相册不存在",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -474,16 +633,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/posts/{id}": {
-            "delete": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "删除用户自己的动态",
+                "description": "修改自己相册的名称、描述、可见范围或封面，未传字段保持不变；封面须为该相册中的照片，cover_photo_id为\"0\"时使用最新上传的照片",
                 "consumes": [
                     "application/json"
                 ],
@@ -491,33 +648,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "动态"
+                    "相册"
                 ],
-                "summary": "删除动态",
+                "summary": "修改相册",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "动态ID",
+                        "description": "相册ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "相册信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamUpdateAlbumRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "修改成功",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "参数错误/内容包含违规词语",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "404": {
+                        "description": "相册或封面照片不存在",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -529,30 +695,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/posts/{id}/view": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "每次浏览动态时增加1次浏览量",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "删除自己的相册及其中的全部照片，删除后不可恢复",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "动态"
+                    "相册"
                 ],
-                "summary": "增加动态浏览量",
+                "summary": "删除相册",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "动态ID",
+                        "description": "相册ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -560,7 +721,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "操作成功",
+                        "description": "删除成功",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -571,8 +732,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "404": {
+                        "description": "相册不存在",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -586,29 +747,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/posts/{user_id}": {
+        "/albums/{id}/photos": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "按时间倒序获取指定用户的动态(每次10条)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "按上传时间倒序获取相册中的照片(每次20张)，不可见的相册视为不存在",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "动态"
+                    "相册"
                 ],
-                "summary": "获取指定用户动态列表",
+                "summary": "相册照片列表",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "用户ID",
-                        "name": "user_id",
+                        "description": "相册ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
@@ -621,7 +779,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功获取用户动态",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -633,7 +791,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                                                "$ref": "#/definitions/models.ParamPhotoItem"
                                             }
                                         }
                                     }
@@ -647,14 +805,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "非好友关系",
+                    "404": {
+                        "description": "相册不存在",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -666,52 +818,76 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/register": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "用户通过邮箱、用户名和密码进行注册，返回生成的唯一UID",
+                "description": "上传照片到自己的相册(每次最多9张，单张不超过10MB)，按文件内容校验真实格式并去除元数据，同时生成中图与缩略图",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "相册"
                 ],
-                "summary": "用户注册接口",
+                "summary": "上传照片",
                 "parameters": [
                     {
-                        "description": "注册参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ParamRegister"
-                        }
+                        "type": "integer",
+                        "description": "相册ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "照片(可多张)",
+                        "name": "photos",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "照片说明(应用于本次上传的全部照片，最多255字)",
+                        "name": "caption",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "用户uid",
+                        "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamPhotoItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "参数格式错误（具体错误字段提示）",
+                        "description": "参数错误/图片格式不正确/最多上传9张图片",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "422": {
-                        "description": "该邮箱已被注册",
+                    "404": {
+                        "description": "相册不存在",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -725,14 +901,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/info": {
-            "get": {
+        "/api/v1/login": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取当前登录用户的个人信息",
+                "description": "用户选择uid或者邮箱两种方式并输入自己的密码进行登录，返回用户信息及token",
                 "consumes": [
                     "application/json"
                 ],
@@ -742,16 +918,39 @@ const docTemplate = `{
                 "tags": [
                     "用户管理"
                 ],
-                "summary": "获取用户信息接口",
+                "summary": "用户登录接口",
+                "parameters": [
+                    {
+                        "description": "登录参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamLogin"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "成功获取用户信息",
+                        "description": "登录成功，返回用户信息和令牌",
                         "schema": {
-                            "$ref": "#/definitions/models.ParamUserInfoResponse"
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误（具体错误字段提示）",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "401": {
-                        "description": "未授权",
+                        "description": "密码错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -765,14 +964,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/update_info": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "更新当前登录用户的个人信息",
+        "/api/v1/messages": {
+            "get": {
+                "description": "获取与指定好友的聊天记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -780,56 +974,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "消息"
                 ],
-                "summary": "更新用户信息接口",
+                "summary": "获取聊天记录",
                 "parameters": [
                     {
-                        "description": "更新参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ParamUpdateUserInfoRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "好友ID",
+                        "name": "friend_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "开始时间戳(默认7天前)",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "结束时间戳(默认当前时间)",
+                        "name": "end_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否标记为已读",
+                        "name": "mark_read",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否获取全部历史消息",
+                        "name": "history",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "{\"messages\":[{\"from\":发送者ID,\"to\":接收者ID,\"direct\":消息发送方向 ,\"created_at\":时间,\"content\":\"内容\",\"avatar_url\":\"头像URL\",\"hide_time\":是否隐藏时间}]}",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "参数格式错误",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "错误信息",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/user/update_password": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "更新当前登录用户的密码",
+            },
+            "post": {
+                "description": "向指定好友发送文本消息(图片和文件消息请使用/messages/image和/messages/file路由)",
                 "consumes": [
                     "application/json"
                 ],
@@ -837,41 +1041,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "消息"
                 ],
-                "summary": "更新密码接口",
+                "summary": "发送文本消息",
                 "parameters": [
                     {
-                        "description": "更新密码参数",
-                        "name": "body",
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "文本消息参数",
+                        "name": "object",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ParamUpdatePasswordRequest"
+                            "$ref": "#/definitions/models.ParamTextReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "密码更新成功",
+                        "description": "{\"timestamp\":时间戳,\"receiver_id\":接收者ID,\"status\":\"sent\"}",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "参数格式错误",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "未授权或旧密码错误",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "错误信息/内容包含违规词语",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -879,48 +1078,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/upload_avatar": {
+        "/api/v1/messages/file": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "上传用户头像文件并更新用户信息",
+                "description": "向指定好友发送文件消息",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "消息"
                 ],
-                "summary": "上传头像接口",
+                "summary": "发送文件消息",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "头像文件",
-                        "name": "avatar",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "文件消息参数",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamFileReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "上传成功",
+                        "description": "{\"timestamp\":时间戳,\"receiver_id\":接收者ID,\"status\":\"sent\"}",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "错误信息",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -928,106 +1125,84 @@ const docTemplate = `{
                 }
             }
         },
-        "/friends": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
+        "/api/v1/messages/image": {
+            "post": {
+                "description": "向指定好友发送图片消息",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "按最后互动时间排序的好友列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "好友管理"
+                    "消息"
                 ],
-                "summary": "获取好友列表",
+                "summary": "发送图片消息",
                 "parameters": [
                     {
-                        "description": "好友列表请求参数",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ParamFriendItem"
-                        }
-                    }
-                ],
-                "responses": {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "图片消息参数",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamImageReq"
+                        }
+                    }
+                ],
+                "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "{\"timestamp\":时间戳,\"receiver_id\":接收者ID,\"status\":\"sent\"}",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ParamFriendItem"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "400": {
+                        "description": "错误信息",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
+            }
+        },
+        "/api/v1/messages/unread": {
+            "get": {
+                "description": "获取所有好友的未读消息数",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "删除好友关系",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "好友管理"
+                    "消息"
                 ],
-                "summary": "删除好友",
+                "summary": "获取未读消息数",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "好友ID",
-                        "name": "uid",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "{\"counts\":{\"好友ID\":未读数量}}",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "参数格式错误",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "不是好友关系",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "错误信息",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1035,52 +1210,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/friends/remark": {
-            "put": {
+        "/api/v1/posts": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "更新好友备注信息",
+                "description": "获取所有好友的动态及关注用户的公开动态(每次10条)，使用游标分页。默认按时间倒序；mode=ranked时按发布时间衰减、点赞、评论、浏览量与好友亲密度综合排序，首页生成的排序快照保留30分钟，期间翻页顺序不变，过期后需从首页重新加载",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "好友管理"
+                    "动态"
                 ],
-                "summary": "更新好友备注",
+                "summary": "获取好友动态列表",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "好友ID",
-                        "name": "uid",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "排序方式(latest:按时间倒序 ranked:按热度排序，默认latest)",
+                        "name": "mode",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "新备注",
-                        "name": "remark",
-                        "in": "query",
-                        "required": true
+                        "description": "分页游标(取上一页返回的next_cursor，首页为空)",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "备注更新成功",
+                        "description": "成功获取动态列表",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamPostPage"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "参数格式错误",
+                        "description": "参数错误/排序已过期",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "403": {
-                        "description": "不是好友关系",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1092,35 +1280,91 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/friends/search": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "根据备注或用户名搜索好友，按匹配优先级排序",
+                "description": "创建用户动态(支持纯文本、纯图片、图文混合)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "好友管理"
+                    "动态"
                 ],
-                "summary": "搜索好友",
+                "summary": "创建用户动态",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "搜索关键字",
-                        "name": "keyword",
-                        "in": "query",
-                        "required": true
+                        "description": "文字内容(不超过500字，支持 #话题# 与 @用户名)",
+                        "name": "content",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "file"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "图片文件(最多9张,单张不超过10MB,支持jpg/png/gif/webp,上传后去除EXIF/GPS信息并生成中图与缩略图)",
+                        "name": "images",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "可见范围(public:公开 friends:仅好友 private:仅自己 allow:部分可见 deny:不给谁看，默认friends)",
+                        "name": "visibility",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "visibility为allow/deny时的好友ID，多个用逗号分隔",
+                        "name": "user_ids",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "visibility为allow/deny时的好友分组ID，多个用逗号分隔",
+                        "name": "group_ids",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "投票选项(2~10个，每项不超过50字)，不传则不附带投票",
+                        "name": "poll_options",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "投票是否多选(默认单选)",
+                        "name": "poll_multiple",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否匿名投票(默认实名)",
+                        "name": "poll_anonymous",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "投票截止时间(RFC3339)，不传则不截止",
+                        "name": "poll_deadline",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "动态创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1130,10 +1374,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ParamFriendItem"
-                                            }
+                                            "$ref": "#/definitions/models.Post"
                                         }
                                     }
                                 }
@@ -1141,7 +1382,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "参数格式错误",
+                        "description": "参数错误/图片格式错误/图片过多/投票无效/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "文件过大",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1155,14 +1408,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/friends/{friendID}": {
-            "get": {
+        "/api/v1/posts/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取当前好友的个人信息",
+                "description": "删除用户自己的动态，删除后进入回收站，30天内可恢复",
                 "consumes": [
                     "application/json"
                 ],
@@ -1170,27 +1423,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "动态"
                 ],
-                "summary": "获取好友信息接口",
+                "summary": "删除动态",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "好友ID",
-                        "name": "friendID",
+                        "description": "动态ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功获取用户信息",
+                        "description": "删除成功",
                         "schema": {
-                            "$ref": "#/definitions/models.ParamFriendInfoResponse"
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "该用户不是您的好友",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1201,6 +1454,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "403": {
+                        "description": "只能删除自己的动态",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -1208,14 +1473,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/posts/{id}/view": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "添加好友关系",
+                "description": "浏览动态时调用，同一用户24小时内重复浏览只计一次，并记录访客(可在设置中关闭)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1223,36 +1490,121 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "好友管理"
+                    "动态"
+                ],
+                "summary": "增加动态浏览量",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "添加好友",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "操作成功",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "参数格式错误",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "401": {
-                        "description": "不能添加自己为好友",
+                        "description": "未授权",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
-                        "description": "好友不存在",
+                        "description": "动态不存在",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "409": {
-                        "description": "已经是好友关系",
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/posts/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按时间倒序获取指定用户的动态(每次10条)，非好友仅能查看公开动态",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "获取指定用户动态列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功获取用户动态",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "存在拉黑关系",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1265,39 +1617,6387 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.ParamFileReq": {
+        },
+        "/api/v1/register": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "用户通过邮箱、用户名和密码进行注册，返回生成的唯一UID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "用户注册接口",
+                "parameters": [
+                    {
+                        "description": "注册参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamRegister"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "用户uid",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误（具体错误字段提示）/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "该邮箱已被注册",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/upload": {
+            "post": {
+                "description": "上传图片或文件",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "上传"
+                ],
+                "summary": "上传文件",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "上传的文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"url\":\"文件访问URL\"}",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "错误信息",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/info": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取当前登录用户的个人信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户信息接口",
+                "responses": {
+                    "200": {
+                        "description": "成功获取用户信息",
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamUserInfoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/update_info": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新当前登录用户的个人信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新用户信息接口",
+                "parameters": [
+                    {
+                        "description": "更新参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamUpdateUserInfoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/update_password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新当前登录用户的密码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新密码接口",
+                "parameters": [
+                    {
+                        "description": "更新密码参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamUpdatePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "密码更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权或旧密码错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/upload_avatar": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "上传用户头像文件并更新用户信息",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "上传头像接口",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "头像文件",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "上传成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/blocks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按拉黑时间倒序获取黑名单",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "黑名单"
+                ],
+                "summary": "获取黑名单列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamBlockItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/blocks/{uid}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将指定用户加入黑名单，拉黑后双方无法互相搜索和添加好友",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "黑名单"
+                ],
+                "summary": "拉黑用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "拉黑成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误/不能拉黑自己",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "已将该用户拉黑",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将指定用户移出黑名单",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "黑名单"
+                ],
+                "summary": "取消拉黑",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取消拉黑成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "该用户不在黑名单中",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookmarks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按收藏时间倒序获取收藏的动态(每次10条)，使用游标分页；每次读取都重新校验可见范围，作者已删除或当前用户已不可见的动态不会返回",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "收藏"
+                ],
+                "summary": "收藏列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "收藏夹ID，0为未分类，不传则返回全部收藏",
+                        "name": "collection_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分页游标(取上一页返回的next_cursor，首页为空)",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamBookmarkPage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "收藏夹不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookmarks/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将已收藏的动态移动到指定收藏夹，collection_id为空或0时移回未分类",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "收藏"
+                ],
+                "summary": "移动收藏",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "目标收藏夹",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamBookmarkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "移动成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "未收藏该动态/收藏夹不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取当前用户的全部收藏夹及收藏数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "收藏"
+                ],
+                "summary": "收藏夹列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamCollectionItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建收藏夹，收藏夹仅自己可见",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "收藏"
+                ],
+                "summary": "创建收藏夹",
+                "parameters": [
+                    {
+                        "description": "收藏夹名称",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamCollectionItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "修改自己的收藏夹名称",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "收藏"
+                ],
+                "summary": "重命名收藏夹",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "收藏夹ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "收藏夹名称",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "收藏夹不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除自己的收藏夹，其中的收藏移回未分类",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "收藏"
+                ],
+                "summary": "删除收藏夹",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "收藏夹ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "收藏夹不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/comments/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除自己的评论，或删除自己动态下的任意评论；删除一级评论时其回复一并删除",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "评论"
+                ],
+                "summary": "删除评论",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "评论ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权删除",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "评论不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drafts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按更新时间倒序获取自己的草稿与定时动态(每次10条)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "草稿"
+                ],
+                "summary": "草稿箱",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamDraftItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "保存动态草稿，图片在保存时即上传处理，之后可继续编辑、定时发布或立即发布",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "草稿"
+                ],
+                "summary": "保存草稿",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "文字内容(不超过500字，支持 #话题# 与 @用户名)",
+                        "name": "content",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "file"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "图片文件(最多9张,单张不超过10MB,支持jpg/png/gif/webp)",
+                        "name": "images",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "可见范围(public/friends/private/allow/deny，默认friends)",
+                        "name": "visibility",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "visibility为allow/deny时的好友ID，多个用逗号分隔",
+                        "name": "user_ids",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "visibility为allow/deny时的好友分组ID，多个用逗号分隔",
+                        "name": "group_ids",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "保存成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamDraftItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/图片格式错误/图片过多/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drafts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取草稿内容、图片与定时发布状态，用于继续编辑",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "草稿"
+                ],
+                "summary": "草稿详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamDraftItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "草稿不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "修改草稿的内容与可见范围，定时动态修改后仍按原时间发布",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "草稿"
+                ],
+                "summary": "修改草稿",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "草稿内容",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamDraftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/草稿正在发布或已发布/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "草稿不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除草稿及其图片，定时动态删除后不再发布",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "草稿"
+                ],
+                "summary": "删除草稿",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "草稿正在发布或已发布",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "草稿不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drafts/{id}/images": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "为草稿上传图片，草稿图片总数不超过9张",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "草稿"
+                ],
+                "summary": "草稿追加图片",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "file"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "图片文件(单张不超过10MB,支持jpg/png/gif/webp)",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "上传成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamDraftItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/图片格式错误/图片过多",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "草稿不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drafts/{id}/images/{imageID}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "草稿"
+                ],
+                "summary": "删除草稿图片",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "图片ID",
+                        "name": "imageID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "草稿不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drafts/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "立即发布草稿或定时动态，发布后与直接创建的动态相同",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "草稿"
+                ],
+                "summary": "立即发布草稿",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "发布成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Post"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "内容为空/可见名单无效/草稿正在发布或已发布",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "草稿不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/drafts/{id}/schedule": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "设置或修改草稿的定时发布时间，到时间后由后台任务发布，发布失败会通知作者",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "草稿"
+                ],
+                "summary": "定时发布",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "发布时间",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamScheduleDraftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/发布时间无效/内容为空/可见名单无效",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "草稿不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "取消定时发布，动态回到草稿箱",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "草稿"
+                ],
+                "summary": "取消定时发布",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "草稿ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取消成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "草稿正在发布或已发布",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "草稿不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/{token}/{format}": {
+            "get": {
+                "description": "免登录获取令牌所属用户最新20条公开动态的订阅源，支持RSS 2.0(rss)、Atom 1.0(atom)与JSON Feed 1.1(json)；图片作为附件输出(RSS仅第一张图片作为附件，全部图片包含在正文中)\n响应带有ETag与Last-Modified，请求携带If-None-Match或If-Modified-Since且内容未变化时返回304；令牌不存在或已吊销时返回404，站点未配置外部访问地址时返回503",
+                "produces": [
+                    "text/xml",
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅源"
+                ],
+                "summary": "订阅源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "订阅令牌",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rss",
+                            "atom",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "订阅源格式",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "订阅源内容",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "内容未变化",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "订阅链接不存在或已失效",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "订阅功能未启用",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/followers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按关注时间倒序获取指定用户的粉丝列表(每次20条)，不传uid时获取自己的",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "关注"
+                ],
+                "summary": "获取粉丝列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "uid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamFollowItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/following": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按关注时间倒序获取指定用户的关注列表(每次20条)，不传uid时获取自己的",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "关注"
+                ],
+                "summary": "获取关注列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "uid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamFollowItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/follows/count": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取指定用户的关注数与粉丝数，不传uid时获取自己的",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "关注"
+                ],
+                "summary": "获取关注数与粉丝数",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "uid",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamFollowCount"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/follows/requests": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按申请时间倒序获取待审核的关注申请(每次20条)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "关注"
+                ],
+                "summary": "获取关注申请列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamFollowItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/follows/requests/{uid}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "通过指定用户的关注申请",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "关注"
+                ],
+                "summary": "通过关注申请",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "申请者ID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "已通过关注申请",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "关注申请不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "拒绝指定用户的关注申请",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "关注"
+                ],
+                "summary": "拒绝关注申请",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "申请者ID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "已拒绝关注申请",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "关注申请不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/follows/{uid}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "关注指定用户，对方开启关注审核时返回pending状态",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "关注"
+                ],
+                "summary": "关注用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":\"accepted/pending\"}",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误/不能关注自己",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "已经关注该用户/存在拉黑关系",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "取消关注指定用户或撤回关注申请",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "关注"
+                ],
+                "summary": "取消关注",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "取消关注成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "未关注该用户",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/friend-groups": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取当前用户的全部好友分组及成员",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "好友分组"
+                ],
+                "summary": "获取好友分组列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamFriendGroupItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建好友分组，可用于设置动态的部分可见/不给谁看名单",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "好友分组"
+                ],
+                "summary": "创建好友分组",
+                "parameters": [
+                    {
+                        "description": "分组信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamFriendGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamFriendGroupItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/成员不是好友",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/friend-groups/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "修改分组名称并整体替换分组成员，使用该分组的动态可见范围随之生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "好友分组"
+                ],
+                "summary": "修改好友分组",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "分组ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "分组信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamFriendGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/成员不是好友",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分组不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除好友分组，动态可见名单中对该分组的引用一并移除",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "好友分组"
+                ],
+                "summary": "删除好友分组",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "分组ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分组不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/friends": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按最后互动时间或亲密度排序的好友列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "好友管理"
+                ],
+                "summary": "获取好友列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "排序方式(last_interact:最后互动时间 intimacy:亲密度，默认last_interact)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分页游标(取上一页返回的next_cursor，首页不传)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量(默认20，最大100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamFriendPage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除好友关系",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "好友管理"
+                ],
+                "summary": "删除好友",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "好友ID",
+                        "name": "uid",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "不是好友关系",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/friends/birthdays": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取包括今天在内的days天内过生日的好友，按距离生日的天数升序排序；2月29日出生的好友在平年按2月28日提醒。隐藏生日的好友不返回，隐藏出生年份的好友不返回岁数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "好友管理"
+                ],
+                "summary": "好友生日提醒",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "查询天数(默认7，最大366)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamBirthdayItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/friends/remark": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新好友备注信息",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "好友管理"
+                ],
+                "summary": "更新好友备注",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "好友ID",
+                        "name": "uid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "新备注",
+                        "name": "remark",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "备注更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "不是好友关系",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/friends/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据备注或用户名搜索好友，支持全拼、拼音首字母与容错匹配，按匹配优先级排序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "好友管理"
+                ],
+                "summary": "搜索好友",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "搜索关键字",
+                        "name": "keyword",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamFriendItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/friends/{friendID}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取当前好友的个人信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取好友信息接口",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "好友ID",
+                        "name": "friendID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功获取用户信息",
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamFriendInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "该用户不是您的好友",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "添加好友关系",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "好友管理"
+                ],
+                "summary": "添加好友",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "不能添加自己为好友",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "好友不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "已经是好友关系/存在拉黑关系",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/memories": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取往年今天(最多回溯20年)自己发布的动态与添加的好友，按年份倒序排列，已删除的动态不返回；平年的2月28日同时展示往年2月29日的回忆。在设置中关闭那年今日后返回空结果",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "那年今日",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamMemories"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按时间倒序获取站内通知(每次20条)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "通知"
+                ],
+                "summary": "获取通知列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamNotificationItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将当前用户的全部通知标记为已读",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "通知"
+                ],
+                "summary": "标记通知已读",
+                "responses": {
+                    "200": {
+                        "description": "已全部标记为已读",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unread": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取当前用户的未读通知数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "通知"
+                ],
+                "summary": "获取未读通知数",
+                "responses": {
+                    "200": {
+                        "description": "{\"count\":未读数量}",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/photos/move": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将自己的照片批量移动到自己的另一个相册(每次最多100张)，他人的照片会被忽略；原相册以被移走的照片为封面时恢复为使用最新上传的照片",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "相册"
+                ],
+                "summary": "移动照片",
+                "parameters": [
+                    {
+                        "description": "照片与目标相册",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamMovePhotosRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "移动成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "相册或照片不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/photos/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "查看相册照片，他人查看时浏览量加一(同一用户24小时内重复查看只计一次)；所属相册不可见时视为照片不存在",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "相册"
+                ],
+                "summary": "照片详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "照片ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamPhotoItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "照片不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "修改自己照片的说明，传空字符串清除说明",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "相册"
+                ],
+                "summary": "修改照片说明",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "照片ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "照片说明",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamPhotoCaptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "照片不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除自己的照片，删除后不可恢复；动态相册中的图片随动态删除",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "相册"
+                ],
+                "summary": "删除照片",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "照片ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "照片不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按删除时间倒序获取30天内删除的动态(每次10条)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "获取回收站动态列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamTrashPostItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/visitors": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按最近浏览时间倒序获取自己所有动态的访客记录(每次20条)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "谁看过我",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamVisitorItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "编辑自己动态的文字内容，编辑前的内容保存到编辑历史",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "编辑动态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新的文字内容",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamUpdatePostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "编辑成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "只能修改自己的动态",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/bookmark": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "收藏自己可见的动态，可指定收藏夹(默认未分类)；重复收藏不会改变所属收藏夹",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "收藏"
+                ],
+                "summary": "收藏动态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "收藏夹",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamBookmarkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "收藏成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态或收藏夹不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "取消收藏指定动态，动态已删除或已不可见时同样可以取消；未收藏时同样返回成功",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "收藏"
+                ],
+                "summary": "取消收藏",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "已取消收藏",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按评论时间升序获取动态的评论(每次20条)，仅展示当前用户的好友、动态作者及自己的评论",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "评论"
+                ],
+                "summary": "获取评论列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamCommentItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "评论指定动态，传入reply_to时表示回复该评论",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "评论"
+                ],
+                "summary": "发表评论",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "评论内容",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamCreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamCommentItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态或评论不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按编辑时间倒序获取动态每次编辑前的内容",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "获取动态编辑历史",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamPostEditItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/like": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "点赞指定动态，重复点赞不会重复计数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "点赞动态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "点赞成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "取消对指定动态的点赞，未点赞时同样返回成功",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "取消点赞",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "已取消点赞",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/likes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按点赞时间倒序获取动态的点赞用户(每次20条)，仅展示当前用户的好友",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "获取点赞用户列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamLikerItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/poll/voters": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按投票时间倒序获取实名投票的投票人(每次20条)，需已投票或投票已截止；匿名投票不公开投票人",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "投票"
+                ],
+                "summary": "投票人列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "选项ID，不传则返回全部投票人",
+                        "name": "option_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamPollVoter"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/选项无效/未投票/匿名投票",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态或投票不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/poll/votes": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "对自己可见的动态中的投票进行投票，每人只能投一次且不可修改；投票后返回包含结果的最新投票信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "投票"
+                ],
+                "summary": "投票",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "选择的选项ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamPollVoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamPoll"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/选项无效/投票已截止/已经投过票",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态或投票不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "彻底删除回收站中的动态，删除后不可恢复",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "彻底删除动态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "彻底删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/repost": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "转发自己可见的动态并可附带评语，转发的转发会引用最初的原动态；原动态非公开时转发不能设置为公开，\n查看转发的用户需能看到原动态才会展示原文，否则显示\"内容无法查看\"占位",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "转发动态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "原动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "转发评语与可见范围",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamRepostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "转发成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Post"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/可见名单无效/不能扩大可见范围/内容包含违规词语",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "从回收站恢复自己删除的动态",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "恢复动态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "恢复成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/visibility": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "作者修改已发布动态的可见范围，allow/deny时需同时提供好友或好友分组名单",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "修改动态可见范围",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "可见范围",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamUpdatePostVisibilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/可见名单无效",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "只能修改自己的动态/动态已被管理员隐藏",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/visitors": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "作者按最近浏览时间倒序查看某条动态的访客(每次20条)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "动态"
+                ],
+                "summary": "获取动态访客列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "动态ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamVisitorItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "举报自己可见的动态、别人发给自己的私信或其他账号，同一对象的举报归入同一个审核工单，每人只能举报一次；举报时保存内容快照，私信额外保存前后的聊天记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "举报"
+                ],
+                "summary": "举报动态、私信或账号",
+                "parameters": [
+                    {
+                        "description": "举报对象与原因；举报私信时target_id为发送者ID，message_at为私信的发送时间",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamReportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误/不能举报自己/已经举报过",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "动态、私信或用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{name}/posts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按时间倒序获取话题下当前用户可见的动态(每次10条)，使用游标分页；动态内容中的 #话题# 会自动关联话题",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "话题"
+                ],
+                "summary": "话题动态列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "话题名称(不含#)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "分页游标(取上一页返回的next_cursor，首页为空)",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamTopicPage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "话题不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/feed-token": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取当前用户的订阅令牌及RSS、Atom、JSON Feed订阅地址，订阅源只包含公开动态；尚未生成时返回订阅链接不存在；站点未配置外部访问地址时不支持订阅",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅源"
+                ],
+                "summary": "获取订阅地址",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamFeedToken"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "尚未生成订阅链接",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "生成新的订阅令牌并返回订阅地址，已有的订阅地址立即失效；站点未配置外部访问地址时不支持订阅",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅源"
+                ],
+                "summary": "生成订阅地址",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ParamFeedToken"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "吊销订阅令牌，所有订阅地址立即失效；未生成时同样返回成功",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅源"
+                ],
+                "summary": "关闭订阅地址",
+                "responses": {
+                    "200": {
+                        "description": "已关闭订阅",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取当前登录用户的隐私与偏好设置",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户设置",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserSetting"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新当前登录用户的隐私与偏好设置，未传字段保持不变",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新用户设置",
+                "parameters": [
+                    {
+                        "description": "设置参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ParamUpdateUserSettingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按用户名(完全/前缀/包含)、邮箱(仅完全匹配)或UID(仅完全匹配)搜索用户，按匹配优先级排序(每次10条)，并标记已是好友的用户",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "全站搜索用户",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "搜索关键字",
+                        "name": "keyword",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "偏移量(默认0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ParamUserSearchItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.ParamAlbumItem": {
+            "type": "object",
+            "properties": {
+                "auto": {
+                    "description": "是否为自动生成的动态相册",
+                    "type": "boolean"
+                },
+                "cover_url": {
+                    "description": "封面缩略图，相册为空时为空",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "动态相册为最新一张图片的上传时间",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "动态相册为0",
+                    "type": "string",
+                    "example": "0"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "photo_count": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "visibility": {
+                    "description": "动态相册为空，其中每张图片的可见范围同所属动态",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamBirthdayItem": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "即将年满的岁数，好友隐藏出生年份时不返回",
+                    "type": "integer"
+                },
+                "avatar": {
+                    "description": "好友当前头像",
+                    "type": "string"
+                },
+                "birthday": {
+                    "description": "生日，格式: 01-02",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "即将到来的生日日期，格式: 2006-01-02(2月29日出生的好友在平年为2月28日)",
+                    "type": "string"
+                },
+                "days_until": {
+                    "description": "距离生日的天数，0表示今天",
+                    "type": "integer"
+                },
+                "nickname": {
+                    "description": "好友备注或当前用户名",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "models.ParamBlockItem": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamBookmarkItem": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "description": "自定义可见名单(仅作者本人可见)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPostAudience"
+                        }
+                    ]
+                },
+                "avatar": {
+                    "description": "作者当前头像",
+                    "type": "string"
+                },
+                "bookmarked": {
+                    "description": "当前用户是否已收藏",
+                    "type": "boolean"
+                },
+                "bookmarked_at": {
+                    "description": "收藏时间",
+                    "type": "string"
+                },
+                "collection_id": {
+                    "description": "所属收藏夹ID，0为未分类",
+                    "type": "string",
+                    "example": "0"
+                },
+                "comment_count": {
+                    "description": "当前用户可见的评论数",
+                    "type": "integer"
+                },
+                "content": {
+                    "description": "文字内容(提及已替换为当前用户名)",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "发布时间",
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "最后编辑时间，未编辑为空",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "image_list": {
+                    "description": "图片详情(原图、中图、缩略图及尺寸)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamPostImage"
+                    }
+                },
+                "images": {
+                    "description": "缩略图URL，多个用逗号分隔",
+                    "type": "string"
+                },
+                "like_count": {
+                    "description": "点赞数",
+                    "type": "integer"
+                },
+                "liked": {
+                    "description": "当前用户是否已点赞",
+                    "type": "boolean"
+                },
+                "mentions": {
+                    "description": "内容中提及的用户",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamMention"
+                    }
+                },
+                "nickname": {
+                    "description": "作者的好友备注或当前用户名",
+                    "type": "string"
+                },
+                "poll": {
+                    "description": "动态附带的投票",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPoll"
+                        }
+                    ]
+                },
+                "repost_count": {
+                    "description": "被转发次数",
+                    "type": "integer"
+                },
+                "repost_of": {
+                    "description": "转发的原动态",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                        }
+                    ]
+                },
+                "repost_of_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "unavailable": {
+                    "description": "原动态已删除或当前用户不可见，仅用于RepostOf",
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "description": "作者ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "view_count": {
+                    "description": "浏览量",
+                    "type": "integer"
+                },
+                "visibility": {
+                    "description": "可见范围",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamBookmarkPage": {
+            "type": "object",
+            "properties": {
+                "bookmarks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamBookmarkItem"
+                    }
+                },
+                "next_cursor": {
+                    "description": "下一页游标，为空表示没有更多",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamBookmarkRequest": {
+            "type": "object",
+            "properties": {
+                "collection_id": {
+                    "description": "收藏夹ID，为空或0表示未分类",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamCollectionItem": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "收藏数(含作者已删除或设为不可见的动态)",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamCollectionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "models.ParamCommentItem": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "description": "优先显示备注，否则显示昵称",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "post_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "reply_to_id": {
+                    "description": "回复的评论ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "reply_to_name": {
+                    "type": "string"
+                },
+                "reply_to_user_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "root_id": {
+                    "description": "所属一级评论ID，一级评论为0",
+                    "type": "string",
+                    "example": "0"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "models.ParamCreateAlbumRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "visibility": {
+                    "description": "可见范围: public/friends/private，默认friends",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamCreateCommentRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "reply_to": {
+                    "description": "回复的评论ID，为空表示直接评论动态",
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "models.ParamDraftImage": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "medium_url": {
+                    "description": "中图",
+                    "type": "string"
+                },
+                "thumb_url": {
+                    "description": "缩略图",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "原图",
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ParamDraftItem": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "description": "自定义可见名单",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPostAudience"
+                        }
+                    ]
+                },
+                "content": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamDraftImage"
+                    }
+                },
+                "last_error": {
+                    "description": "定时发布失败原因",
+                    "type": "string"
+                },
+                "post_id": {
+                    "description": "发布后的动态ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "scheduled_at": {
+                    "description": "定时发布时间，未定时为空",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "draft/scheduled/publishing/failed",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamDraftRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "group_ids": {
+                    "description": "visibility为allow/deny时的好友分组ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_ids": {
+                    "description": "visibility为allow/deny时的好友ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "visibility": {
+                    "description": "默认friends",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "friends",
+                        "private",
+                        "allow",
+                        "deny"
+                    ]
+                }
+            }
+        },
+        "models.ParamFeedToken": {
+            "type": "object",
+            "properties": {
+                "atom_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "json_url": {
+                    "type": "string"
+                },
+                "rss_url": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamFileReq": {
+            "type": "object",
+            "required": [
+                "content",
+                "to"
+            ],
+            "properties": {
+                "content": {
+                    "description": "文件URL",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "文件名",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "文件大小(字节)",
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "type": {
+                    "description": "文件类型",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamFollowCount": {
+            "type": "object",
+            "properties": {
+                "followers": {
+                    "description": "粉丝数",
+                    "type": "integer"
+                },
+                "following": {
+                    "description": "关注数",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ParamFollowItem": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "关注时间",
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamFriendGroupItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "member_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamFriendGroupRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "member_ids": {
+                    "description": "成员好友ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "models.ParamFriendInfoResponse": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "avatar_url": {
+                    "type": "string"
+                },
+                "birthday": {
+                    "description": "格式: xx-xx",
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "last_login": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamFriendItem": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "description": "优先显示备注，否则显示昵称",
+                    "type": "string"
+                },
+                "friend_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "intimacy": {
+                    "description": "当前亲密度",
+                    "type": "number"
+                },
+                "last_interact_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamFriendPage": {
+            "type": "object",
+            "properties": {
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamFriendItem"
+                    }
+                },
+                "next_cursor": {
+                    "description": "下一页游标，为空表示没有更多数据",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamImageReq": {
+            "type": "object",
+            "required": [
+                "content",
+                "to"
+            ],
+            "properties": {
+                "content": {
+                    "description": "图片URL",
+                    "type": "string"
+                },
+                "height": {
+                    "description": "图片高度(像素)",
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "width": {
+                    "description": "图片宽度(像素)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ParamLikerItem": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "点赞时间",
+                    "type": "string"
+                },
+                "display_name": {
+                    "description": "优先显示备注，否则显示昵称",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "models.ParamLogin": {
+            "type": "object",
+            "required": [
+                "identifier",
+                "password"
+            ],
+            "properties": {
+                "identifier": {
+                    "description": "登录标识（邮箱或用户ID）",
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamMemories": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "今天的日期，格式: 2006-01-02",
+                    "type": "string"
+                },
+                "years": {
+                    "description": "按年份倒序排列，没有回忆的年份不返回",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamMemoryYear"
+                    }
+                }
+            }
+        },
+        "models.ParamMemoryFriend": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "成为好友的时间",
+                    "type": "string"
+                },
+                "nickname": {
+                    "description": "好友备注或当前用户名",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "models.ParamMemoryYear": {
+            "type": "object",
+            "properties": {
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamMemoryFriend"
+                    }
+                },
+                "posts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                    }
+                },
+                "year": {
+                    "type": "integer"
+                },
+                "years_ago": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ParamMention": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "username": {
+                    "description": "当前用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamModerationCase": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "last_reported_at": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "report_count": {
+                    "type": "integer"
+                },
+                "resolution": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "target_type": {
+                    "type": "string"
+                },
+                "target_user_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "target_username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamModerationCaseDetail": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "被举报内容的作者",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamModerationUser"
+                        }
+                    ]
+                },
+                "case": {
+                    "$ref": "#/definitions/models.ParamModerationCase"
+                },
+                "history": {
+                    "description": "该作者此前受到的处置",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamModerationLog"
+                    }
+                },
+                "post": {
+                    "description": "被举报动态的当前状态(含作者已删除的动态)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                        }
+                    ]
+                },
+                "recent_posts": {
+                    "description": "举报账号时，该账号最近的动态",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                    }
+                },
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamReportItem"
+                    }
+                },
+                "snapshot": {
+                    "description": "首次举报时的内容快照",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ReportSnapshot"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.ParamModerationDecisionRequest": {
+            "type": "object",
+            "properties": {
+                "content_action": {
+                    "description": "内容处置：隐藏仅适用于动态",
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "hide",
+                        "delete"
+                    ]
+                },
+                "note": {
+                    "description": "处理备注",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "suspend_days": {
+                    "description": "封禁天数，user_action为suspend时必填",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                },
+                "user_action": {
+                    "description": "账号处置",
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "warn",
+                        "suspend"
+                    ]
+                }
+            }
+        },
+        "models.ParamModerationLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "case_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "moderator_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "snapshot": {
+                    "$ref": "#/definitions/models.ReportSnapshot"
+                },
+                "target_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "target_type": {
+                    "type": "string"
+                },
+                "target_user_id": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "models.ParamModerationUser": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "suspended_until": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamMovePhotosRequest": {
+            "type": "object",
+            "required": [
+                "album_id",
+                "photo_ids"
+            ],
+            "properties": {
+                "album_id": {
+                    "description": "目标相册ID",
+                    "type": "string"
+                },
+                "photo_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ParamNotificationItem": {
+            "type": "object",
+            "properties": {
+                "actor_avatar": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "actor_name": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "is_read": {
+                    "type": "boolean"
+                },
+                "target_id": {
+                    "description": "关联对象ID(如动态ID)",
+                    "type": "string",
+                    "example": "0"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamPhotoCaptionRequest": {
+            "type": "object",
+            "properties": {
+                "caption": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "models.ParamPhotoItem": {
+            "type": "object",
+            "properties": {
+                "album_id": {
+                    "description": "动态相册的图片为0",
+                    "type": "string",
+                    "example": "0"
+                },
+                "caption": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "medium_url": {
+                    "type": "string"
+                },
+                "post_id": {
+                    "description": "动态相册的图片所属动态ID，相册照片为0",
+                    "type": "string",
+                    "example": "0"
+                },
+                "thumb_url": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "view_count": {
+                    "type": "integer"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ParamPoll": {
+            "type": "object",
+            "properties": {
+                "anonymous": {
+                    "description": "是否匿名投票",
+                    "type": "boolean"
+                },
+                "closed": {
+                    "description": "是否已截止",
+                    "type": "boolean"
+                },
+                "deadline": {
+                    "description": "截止时间，为空表示不截止",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "multiple": {
+                    "description": "是否多选",
+                    "type": "boolean"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamPollOption"
+                    }
+                },
+                "result_visible": {
+                    "description": "是否可以查看结果",
+                    "type": "boolean"
+                },
+                "voted": {
+                    "description": "当前用户是否已投票",
+                    "type": "boolean"
+                },
+                "voter_count": {
+                    "description": "投票人数，结果不可见时为0",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ParamPollOption": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "vote_count": {
+                    "description": "得票数，结果不可见时为0",
+                    "type": "integer"
+                },
+                "voted": {
+                    "description": "当前用户是否选择了该选项",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ParamPollVoteRequest": {
+            "type": "object",
+            "required": [
+                "option_ids"
+            ],
+            "properties": {
+                "option_ids": {
+                    "description": "选择的选项ID，单选投票只能选一个",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ParamPollVoter": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "description": "好友备注或用户名",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "voted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamPostAudience": {
+            "type": "object",
+            "properties": {
+                "group_ids": {
+                    "description": "好友分组ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_ids": {
+                    "description": "好友ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ParamPostEditItem": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "编辑前的内容",
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "编辑时间",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamPostImage": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "medium_url": {
+                    "description": "中图",
+                    "type": "string"
+                },
+                "thumb_url": {
+                    "description": "缩略图",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "原图",
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ParamPostPage": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "下一页游标，为空表示没有更多",
+                    "type": "string"
+                },
+                "posts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                    }
+                }
+            }
+        },
+        "models.ParamPostWithUserInfo": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "description": "自定义可见名单(仅作者本人可见)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPostAudience"
+                        }
+                    ]
+                },
+                "avatar": {
+                    "description": "作者当前头像",
+                    "type": "string"
+                },
+                "bookmarked": {
+                    "description": "当前用户是否已收藏",
+                    "type": "boolean"
+                },
+                "comment_count": {
+                    "description": "当前用户可见的评论数",
+                    "type": "integer"
+                },
+                "content": {
+                    "description": "文字内容(提及已替换为当前用户名)",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "发布时间",
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "最后编辑时间，未编辑为空",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "image_list": {
+                    "description": "图片详情(原图、中图、缩略图及尺寸)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamPostImage"
+                    }
+                },
+                "images": {
+                    "description": "缩略图URL，多个用逗号分隔",
+                    "type": "string"
+                },
+                "like_count": {
+                    "description": "点赞数",
+                    "type": "integer"
+                },
+                "liked": {
+                    "description": "当前用户是否已点赞",
+                    "type": "boolean"
+                },
+                "mentions": {
+                    "description": "内容中提及的用户",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamMention"
+                    }
+                },
+                "nickname": {
+                    "description": "作者的好友备注或当前用户名",
+                    "type": "string"
+                },
+                "poll": {
+                    "description": "动态附带的投票",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPoll"
+                        }
+                    ]
+                },
+                "repost_count": {
+                    "description": "被转发次数",
+                    "type": "integer"
+                },
+                "repost_of": {
+                    "description": "转发的原动态",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                        }
+                    ]
+                },
+                "repost_of_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "unavailable": {
+                    "description": "原动态已删除或当前用户不可见，仅用于RepostOf",
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "description": "作者ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "view_count": {
+                    "description": "浏览量",
+                    "type": "integer"
+                },
+                "visibility": {
+                    "description": "可见范围",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamRegister": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "re_password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 6
+                },
+                "re_password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamReportItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reporter_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "reporter_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamReportRequest": {
+            "type": "object",
+            "required": [
+                "reason",
+                "target_id",
+                "target_type"
+            ],
+            "properties": {
+                "detail": {
+                    "description": "补充说明",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "message_at": {
+                    "description": "举报私信时为私信的发送时间(与聊天记录中的created_at一致)",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "举报原因",
+                    "type": "string",
+                    "enum": [
+                        "spam",
+                        "harassment",
+                        "hate",
+                        "violence",
+                        "sexual",
+                        "illegal",
+                        "misinformation",
+                        "impersonation",
+                        "other"
+                    ]
+                },
+                "target_id": {
+                    "description": "动态ID/用户ID/私信发送者ID",
+                    "type": "string"
+                },
+                "target_type": {
+                    "description": "举报对象类型",
+                    "type": "string",
+                    "enum": [
+                        "post",
+                        "message",
+                        "user"
+                    ]
+                }
+            }
+        },
+        "models.ParamRepostRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "转发评语，可为空",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "group_ids": {
+                    "description": "visibility为allow/deny时的好友分组ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_ids": {
+                    "description": "visibility为allow/deny时的好友ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "visibility": {
+                    "description": "默认friends",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "friends",
+                        "private",
+                        "allow",
+                        "deny"
+                    ]
+                }
+            }
+        },
+        "models.ParamScheduleDraftRequest": {
+            "type": "object",
+            "required": [
+                "scheduled_at"
+            ],
+            "properties": {
+                "scheduled_at": {
+                    "description": "发布时间(RFC3339)，须在1分钟后且30天以内",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamTextReq": {
+            "type": "object",
+            "required": [
+                "content",
+                "to"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "models.ParamTopicPage": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "下一页游标，为空表示没有更多",
+                    "type": "string"
+                },
+                "posts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                    }
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamTrashPostItem": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "description": "自定义可见名单(仅作者本人可见)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPostAudience"
+                        }
+                    ]
+                },
+                "avatar": {
+                    "description": "作者当前头像",
+                    "type": "string"
+                },
+                "bookmarked": {
+                    "description": "当前用户是否已收藏",
+                    "type": "boolean"
+                },
+                "comment_count": {
+                    "description": "当前用户可见的评论数",
+                    "type": "integer"
+                },
+                "content": {
+                    "description": "文字内容(提及已替换为当前用户名)",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "发布时间",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "删除时间",
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "最后编辑时间，未编辑为空",
+                    "type": "string"
+                },
+                "expire_at": {
+                    "description": "彻底清除时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "image_list": {
+                    "description": "图片详情(原图、中图、缩略图及尺寸)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamPostImage"
+                    }
+                },
+                "images": {
+                    "description": "缩略图URL，多个用逗号分隔",
+                    "type": "string"
+                },
+                "like_count": {
+                    "description": "点赞数",
+                    "type": "integer"
+                },
+                "liked": {
+                    "description": "当前用户是否已点赞",
+                    "type": "boolean"
+                },
+                "mentions": {
+                    "description": "内容中提及的用户",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParamMention"
+                    }
+                },
+                "nickname": {
+                    "description": "作者的好友备注或当前用户名",
+                    "type": "string"
+                },
+                "poll": {
+                    "description": "动态附带的投票",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPoll"
+                        }
+                    ]
+                },
+                "repost_count": {
+                    "description": "被转发次数",
+                    "type": "integer"
+                },
+                "repost_of": {
+                    "description": "转发的原动态",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParamPostWithUserInfo"
+                        }
+                    ]
+                },
+                "repost_of_id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "unavailable": {
+                    "description": "原动态已删除或当前用户不可见，仅用于RepostOf",
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "description": "作者ID",
+                    "type": "string",
+                    "example": "0"
+                },
+                "view_count": {
+                    "description": "浏览量",
+                    "type": "integer"
+                },
+                "visibility": {
+                    "description": "可见范围",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamUpdateAlbumRequest": {
+            "type": "object",
+            "properties": {
+                "cover_photo_id": {
+                    "description": "封面照片ID(须为该相册中的照片)，\"0\"为使用最新的照片",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 1
+                },
+                "visibility": {
+                    "description": "可见范围: public/friends/private",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParamUpdatePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 6
+                },
+                "old_password": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 6
+                }
+            }
+        },
+        "models.ParamUpdatePostRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "models.ParamUpdatePostVisibilityRequest": {
+            "type": "object",
+            "required": [
+                "visibility"
+            ],
+            "properties": {
+                "group_ids": {
+                    "description": "visibility为allow/deny时的好友分组ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_ids": {
+                    "description": "visibility为allow/deny时的好友ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "friends",
+                        "private",
+                        "allow",
+                        "deny"
+                    ]
+                }
+            }
+        },
+        "models.ParamUpdateUserInfoRequest": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "birthday": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female"
+                    ]
+                },
+                "signature": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 2
+                }
+            }
+        },
+        "models.ParamUpdateUserSettingRequest": {
             "type": "object",
-            "required": [
-                "content",
-                "to"
-            ],
             "properties": {
-                "content": {
-                    "description": "文件URL",
-                    "type": "string"
+                "allow_search_by_email": {
+                    "type": "boolean"
                 },
-                "name": {
-                    "description": "文件名",
-                    "type": "string"
+                "allow_search_by_uid": {
+                    "type": "boolean"
                 },
-                "size": {
-                    "description": "文件大小(字节)",
-                    "type": "integer"
+                "allow_search_by_username": {
+                    "type": "boolean"
                 },
-                "to": {
-                    "type": "string",
-                    "example": "0"
+                "disable_memories": {
+                    "type": "boolean"
                 },
-                "type": {
-                    "description": "文件类型",
-                    "type": "string"
+                "follow_need_approval": {
+                    "type": "boolean"
+                },
+                "hide_birth_year": {
+                    "type": "boolean"
+                },
+                "hide_birthday": {
+                    "type": "boolean"
+                },
+                "hide_visit_record": {
+                    "type": "boolean"
+                },
+                "memory_notification": {
+                    "type": "boolean"
                 }
             }
         },
-        "models.ParamFriendInfoResponse": {
+        "models.ParamUserInfoResponse": {
             "type": "object",
             "properties": {
                 "age": {
@@ -1316,9 +8016,6 @@ const docTemplate = `{
                 "last_login": {
                     "type": "string"
                 },
-                "remark": {
-                    "type": "string"
-                },
                 "signature": {
                     "type": "string"
                 },
@@ -1331,244 +8028,215 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ParamFriendItem": {
+        "models.ParamUserSearchItem": {
             "type": "object",
             "properties": {
                 "avatar_url": {
                     "type": "string"
                 },
-                "display_name": {
-                    "description": "优先显示备注，否则显示昵称",
+                "is_friend": {
+                    "description": "是否已是好友",
+                    "type": "boolean"
+                },
+                "signature": {
                     "type": "string"
                 },
-                "friend_id": {
+                "user_id": {
                     "type": "string",
                     "example": "0"
                 },
-                "last_interact_at": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "models.ParamImageReq": {
+        "models.ParamVisitorItem": {
             "type": "object",
-            "required": [
-                "content",
-                "to"
-            ],
             "properties": {
-                "content": {
-                    "description": "图片URL",
+                "avatar_url": {
                     "type": "string"
                 },
-                "height": {
-                    "description": "图片高度(像素)",
-                    "type": "integer"
+                "display_name": {
+                    "description": "优先显示备注，否则显示昵称",
+                    "type": "string"
                 },
-                "to": {
+                "post_id": {
+                    "description": "浏览的动态ID",
                     "type": "string",
                     "example": "0"
                 },
-                "width": {
-                    "description": "图片宽度(像素)",
-                    "type": "integer"
-                }
-            }
-        },
-        "models.ParamLogin": {
-            "type": "object",
-            "required": [
-                "identifier",
-                "password"
-            ],
-            "properties": {
-                "identifier": {
-                    "description": "登录标识（邮箱或用户ID）",
-                    "type": "string"
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
                 },
-                "password": {
+                "visited_at": {
+                    "description": "最近浏览时间",
                     "type": "string"
                 }
             }
         },
-        "models.ParamPostWithUserInfo": {
+        "models.Poll": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "description": "用户头像",
-                    "type": "string"
-                },
-                "content": {
-                    "description": "文字内容",
-                    "type": "string"
+                "anonymous": {
+                    "type": "boolean"
                 },
                 "created_at": {
-                    "description": "发布时间",
-                    "type": "string"
-                },
-                "images": {
-                    "description": "图片URL，多个用逗号分隔",
                     "type": "string"
                 },
-                "nickname": {
-                    "description": "用户备注或用户名",
-                    "type": "string"
-                },
-                "view_count": {
-                    "description": "浏览量",
-                    "type": "integer"
-                }
-            }
-        },
-        "models.ParamRegister": {
-            "type": "object",
-            "required": [
-                "email",
-                "password",
-                "re_password",
-                "username"
-            ],
-            "properties": {
-                "email": {
+                "deadline": {
                     "type": "string"
                 },
-                "password": {
+                "id": {
                     "type": "string",
-                    "maxLength": 20,
-                    "minLength": 6
+                    "example": "0"
                 },
-                "re_password": {
-                    "type": "string"
+                "multiple": {
+                    "type": "boolean"
                 },
-                "username": {
-                    "type": "string"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PollOption"
+                    }
                 }
             }
         },
-        "models.ParamTextReq": {
+        "models.PollOption": {
             "type": "object",
-            "required": [
-                "content",
-                "to"
-            ],
             "properties": {
                 "content": {
                     "type": "string"
                 },
-                "to": {
+                "id": {
                     "type": "string",
                     "example": "0"
                 }
             }
         },
-        "models.ParamUpdatePasswordRequest": {
+        "models.Post": {
             "type": "object",
-            "required": [
-                "new_password",
-                "old_password"
-            ],
             "properties": {
-                "new_password": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 6
+                "content": {
+                    "description": "文字内容",
+                    "type": "string"
                 },
-                "old_password": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 6
-                }
-            }
-        },
-        "models.ParamUpdateUserInfoRequest": {
-            "type": "object",
-            "properties": {
-                "avatar_url": {
+                "created_at": {
+                    "description": "发布时间",
                     "type": "string"
                 },
-                "birthday": {
+                "edited_at": {
+                    "description": "最后编辑时间，未编辑为空",
                     "type": "string"
                 },
-                "gender": {
-                    "type": "string",
-                    "enum": [
-                        "male",
-                        "female"
+                "image_list": {
+                    "description": "动态图片(按Sort排序)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PostImage"
+                    }
+                },
+                "images": {
+                    "description": "旧版图片URL，多个用逗号分隔，新动态的图片见ImageList",
+                    "type": "string"
+                },
+                "like_count": {
+                    "description": "点赞数(由Redis定期回写)",
+                    "type": "integer"
+                },
+                "poll": {
+                    "description": "动态附带的投票",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Poll"
+                        }
                     ]
                 },
-                "signature": {
+                "repost_of_id": {
+                    "description": "转发的原动态ID，0表示原创",
                     "type": "string",
-                    "maxLength": 255
+                    "example": "0"
                 },
-                "username": {
+                "user_id": {
+                    "description": "发布用户ID",
                     "type": "string",
-                    "maxLength": 20,
-                    "minLength": 2
+                    "example": "0"
+                },
+                "view_count": {
+                    "description": "浏览量",
+                    "type": "integer"
+                },
+                "visibility": {
+                    "description": "可见范围",
+                    "type": "string"
                 }
             }
         },
-        "models.ParamUserInfoResponse": {
+        "models.PostImage": {
             "type": "object",
             "properties": {
-                "age": {
+                "format": {
+                    "type": "string"
+                },
+                "height": {
                     "type": "integer"
                 },
-                "avatar_url": {
+                "medium_url": {
                     "type": "string"
                 },
-                "birthday": {
-                    "description": "格式: xx-xx",
-                    "type": "string"
+                "size": {
+                    "type": "integer"
                 },
-                "gender": {
-                    "type": "string"
+                "sort": {
+                    "type": "integer"
                 },
-                "last_login": {
+                "thumb_url": {
                     "type": "string"
                 },
-                "signature": {
+                "url": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "0"
-                },
-                "username": {
-                    "type": "string"
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Post": {
+        "models.ReportSnapshot": {
             "type": "object",
             "properties": {
                 "avatar_url": {
-                    "description": "发布用户头像",
+                    "description": "账号头像",
                     "type": "string"
                 },
                 "content": {
-                    "description": "文字内容",
+                    "description": "动态内容",
                     "type": "string"
                 },
                 "created_at": {
-                    "description": "发布时间",
+                    "description": "内容发布时间",
                     "type": "string"
                 },
                 "images": {
-                    "description": "图片URL，多个用逗号分隔",
-                    "type": "string"
+                    "description": "动态图片",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "user_id": {
-                    "description": "发布用户ID",
-                    "type": "string",
-                    "example": "0"
+                "messages": {
+                    "description": "被举报私信及其前后的聊天记录",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SnapshotMessage"
+                    }
                 },
-                "username": {
-                    "description": "发布用户昵称",
+                "signature": {
+                    "description": "账号签名",
                     "type": "string"
                 },
-                "view_count": {
-                    "description": "浏览量",
-                    "type": "integer"
+                "username": {
+                    "description": "账号用户名",
+                    "type": "string"
                 }
             }
         },
@@ -1589,6 +8257,74 @@ const docTemplate = `{
                     "example": "success"
                 }
             }
+        },
+        "models.SnapshotMessage": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "reported": {
+                    "description": "是否为被举报的私信",
+                    "type": "boolean"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "type": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UserSetting": {
+            "type": "object",
+            "properties": {
+                "allow_search_by_email": {
+                    "type": "boolean"
+                },
+                "allow_search_by_uid": {
+                    "type": "boolean"
+                },
+                "allow_search_by_username": {
+                    "type": "boolean"
+                },
+                "disable_memories": {
+                    "type": "boolean"
+                },
+                "follow_need_approval": {
+                    "type": "boolean"
+                },
+                "hide_birth_year": {
+                    "type": "boolean"
+                },
+                "hide_birthday": {
+                    "type": "boolean"
+                },
+                "hide_visit_record": {
+                    "type": "boolean"
+                },
+                "memory_notification": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,439 @@
+package logic
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
+	"gosocial/models"
+)
+
+const (
+	albumPhotoDir  = "static/images/albums"
+	albumPageSize  = 20     // 照片列表每页数量
+	postAlbumName  = "动态相册" // 自动生成的动态相册名称
+	postAlbumIntro = "发布动态时上传的图片"
+)
+
+// CreateAlbum 创建相册
+func CreateAlbum(userID int64, name, description, visibility string) (*models.ParamAlbumItem, error) {
+	album := models.Album{UserID: userID, Name: name, Description: description, Visibility: visibility}
+	if err := mysql.CreateAlbum(&album); err != nil {
+		return nil, err
+	}
+	return toParamAlbumItem(&album, 0, ""), nil
+}
+
+// GetAlbums 获取用户的相册列表，第一项为自动生成的动态相册，其余相册按可见范围过滤
+// 存在拉黑关系时返回ErrorBlocked
+func GetAlbums(viewerID, ownerID int64) ([]models.ParamAlbumItem, error) {
+	isFriend, err := albumRelation(viewerID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	visibilities := []string{models.PostVisibilityPublic}
+	switch {
+	case viewerID == ownerID:
+		visibilities = append(visibilities, models.PostVisibilityFriends, models.PostVisibilityPrivate)
+	case isFriend:
+		visibilities = append(visibilities, models.PostVisibilityFriends)
+	}
+	albums, err := mysql.GetAlbumsByUserID(ownerID, visibilities)
+	if err != nil {
+		return nil, err
+	}
+	albumIDs := make([]int64, len(albums))
+	for i, a := range albums {
+		albumIDs[i] = a.ID
+	}
+	counts, err := mysql.CountAlbumPhotos(albumIDs)
+	if err != nil {
+		return nil, err
+	}
+	covers, err := getAlbumCovers(albums)
+	if err != nil {
+		return nil, err
+	}
+	postAlbum, err := getPostAlbum(viewerID, ownerID, isFriend)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.ParamAlbumItem, 0, len(albums)+1)
+	result = append(result, *postAlbum)
+	for i := range albums {
+		result = append(result, *toParamAlbumItem(&albums[i], counts[albums[i].ID], covers[albums[i].ID]))
+	}
+	return result, nil
+}
+
+// GetAlbum 获取相册详情，不可见的相册视为不存在
+func GetAlbum(viewerID, albumID int64) (*models.ParamAlbumItem, error) {
+	album, err := getVisibleAlbum(viewerID, albumID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := mysql.CountAlbumPhotos([]int64{album.ID})
+	if err != nil {
+		return nil, err
+	}
+	covers, err := getAlbumCovers([]models.Album{*album})
+	if err != nil {
+		return nil, err
+	}
+	return toParamAlbumItem(album, counts[album.ID], covers[album.ID]), nil
+}
+
+// UpdateAlbum 修改自己相册的名称、描述、可见范围或封面，参数为nil时保持不变
+// 封面须为该相册中的照片，coverPhotoID为0时恢复为使用最新的照片
+func UpdateAlbum(userID, albumID int64, name, description, visibility *string, coverPhotoID *int64) error {
+	if _, err := getOwnAlbum(userID, albumID); err != nil {
+		return err
+	}
+	updates := make(map[string]interface{})
+	if name != nil {
+		updates["name"] = *name
+	}
+	if description != nil {
+		updates["description"] = *description
+	}
+	if visibility != nil {
+		updates["visibility"] = *visibility
+	}
+	if coverPhotoID != nil {
+		if *coverPhotoID != 0 {
+			photo, err := mysql.GetPhotoByID(*coverPhotoID)
+			if err != nil {
+				return err
+			}
+			if photo.AlbumID != albumID {
+				return mysql.ErrorPhotoNotExist
+			}
+		}
+		updates["cover_photo_id"] = *coverPhotoID
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	return mysql.UpdateAlbum(albumID, updates)
+}
+
+// DeleteAlbum 删除自己的相册及其中的全部照片与图片文件
+func DeleteAlbum(userID, albumID int64) error {
+	if _, err := getOwnAlbum(userID, albumID); err != nil {
+		return err
+	}
+	photos, err := mysql.DeleteAlbum(albumID)
+	if err != nil {
+		return err
+	}
+	for _, p := range photos {
+		removeImageFiles(p.URL, p.MediumURL, p.ThumbURL)
+	}
+	return nil
+}
+
+// UploadPhotos 处理并保存照片(去除元数据、生成中图与缩略图)到自己的相册，caption为本次上传照片的说明
+func UploadPhotos(userID, albumID int64, caption string, files [][]byte) ([]models.ParamPhotoItem, error) {
+	if _, err := getOwnAlbum(userID, albumID); err != nil {
+		return nil, err
+	}
+	images, err := saveImages(albumPhotoDir, files)
+	if err != nil {
+		return nil, err
+	}
+	photos := make([]models.Photo, 0, len(images))
+	for _, img := range images {
+		photos = append(photos, models.Photo{
+			UserID:    userID,
+			AlbumID:   albumID,
+			URL:       img.URL,
+			MediumURL: img.MediumURL,
+			ThumbURL:  img.ThumbURL,
+			Width:     img.Width,
+			Height:    img.Height,
+			Size:      img.Size,
+			Format:    img.Format,
+			Caption:   caption,
+		})
+	}
+	if err = mysql.CreatePhotos(photos); err != nil {
+		for _, p := range photos {
+			removeImageFiles(p.URL, p.MediumURL, p.ThumbURL)
+		}
+		return nil, err
+	}
+	result := make([]models.ParamPhotoItem, 0, len(photos))
+	for i := range photos {
+		result = append(result, toParamPhotoItem(&photos[i]))
+	}
+	return result, nil
+}
+
+// GetAlbumPhotos 分页获取相册中的照片，按上传时间倒序排序
+func GetAlbumPhotos(viewerID, albumID int64, offset int) ([]models.ParamPhotoItem, error) {
+	if _, err := getVisibleAlbum(viewerID, albumID); err != nil {
+		return nil, err
+	}
+	photos, err := mysql.GetAlbumPhotos(albumID, offset, albumPageSize)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamPhotoItem, 0, len(photos))
+	for i := range photos {
+		result = append(result, toParamPhotoItem(&photos[i]))
+	}
+	return result, nil
+}
+
+// GetPostPhotos 分页获取动态相册中的图片，只包含查看者可见的、未删除的动态中的图片
+func GetPostPhotos(viewerID, ownerID int64, offset int) ([]models.ParamPhotoItem, error) {
+	isFriend, err := albumRelation(viewerID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	images, err := mysql.GetPostPhotos(viewerID, ownerID, isFriend, offset, albumPageSize)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamPhotoItem, 0, len(images))
+	for _, img := range images {
+		result = append(result, models.ParamPhotoItem{
+			ID:        img.ID,
+			PostID:    img.PostID,
+			URL:       img.URL,
+			MediumURL: img.MediumURL,
+			ThumbURL:  img.ThumbURL,
+			Width:     img.Width,
+			Height:    img.Height,
+			CreatedAt: img.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// GetPhoto 查看照片详情，他人查看时记录浏览量，同一用户24小时内重复查看只计一次
+func GetPhoto(viewerID, photoID int64) (*models.ParamPhotoItem, error) {
+	photo, err := mysql.GetPhotoByID(photoID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = getVisibleAlbum(viewerID, photo.AlbumID); err != nil {
+		if errors.Is(err, mysql.ErrorAlbumNotExist) {
+			return nil, mysql.ErrorPhotoNotExist
+		}
+		return nil, err
+	}
+	if viewerID != photo.UserID {
+		counted, err := redis.RecordPhotoView(context.Background(), photoID, viewerID)
+		if err != nil {
+			zap.L().Error("redis.RecordPhotoView failed", zap.Int64("photo_id", photoID), zap.Error(err))
+		}
+		if counted {
+			if err = mysql.IncrementPhotoViewCount(photoID); err != nil {
+				return nil, err
+			}
+			photo.ViewCount++
+		}
+	}
+	item := toParamPhotoItem(photo)
+	return &item, nil
+}
+
+// UpdatePhotoCaption 修改自己照片的说明
+func UpdatePhotoCaption(userID, photoID int64, caption string) error {
+	if _, err := getOwnPhoto(userID, photoID); err != nil {
+		return err
+	}
+	return mysql.UpdatePhotoCaption(photoID, caption)
+}
+
+// MovePhotos 将自己的照片移动到自己的另一个相册，他人的照片忽略；没有照片被移动时返回ErrorPhotoNotExist
+func MovePhotos(userID int64, photoIDs []int64, albumID int64) error {
+	if _, err := getOwnAlbum(userID, albumID); err != nil {
+		return err
+	}
+	matched, err := mysql.MovePhotos(userID, uniqueIDs(photoIDs), albumID)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return mysql.ErrorPhotoNotExist
+	}
+	return nil
+}
+
+// DeletePhoto 删除自己的照片及图片文件
+func DeletePhoto(userID, photoID int64) error {
+	photo, err := getOwnPhoto(userID, photoID)
+	if err != nil {
+		return err
+	}
+	if err = mysql.DeletePhoto(photo); err != nil {
+		return err
+	}
+	removeImageFiles(photo.URL, photo.MediumURL, photo.ThumbURL)
+	return nil
+}
+
+// albumRelation 判断查看者与相册主人是否为好友，存在拉黑关系时返回ErrorBlocked
+func albumRelation(viewerID, ownerID int64) (isFriend bool, err error) {
+	if viewerID == ownerID {
+		return false, nil
+	}
+	err = IsFriend(viewerID, ownerID)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, mysql.ErrorIsNotFriend) {
+		return false, err
+	}
+	blocked, err := mysql.IsBlockedBetween(viewerID, ownerID)
+	if err != nil {
+		return false, err
+	}
+	if blocked {
+		return false, mysql.ErrorBlocked
+	}
+	return false, nil
+}
+
+// getVisibleAlbum 获取查看者可见的相册，不可见或存在拉黑关系时视为不存在
+func getVisibleAlbum(viewerID, albumID int64) (*models.Album, error) {
+	album, err := mysql.GetAlbumByID(albumID)
+	if err != nil {
+		return nil, err
+	}
+	if album.UserID == viewerID {
+		return album, nil
+	}
+	if album.Visibility == models.PostVisibilityPrivate {
+		return nil, mysql.ErrorAlbumNotExist
+	}
+	isFriend, err := albumRelation(viewerID, album.UserID)
+	if errors.Is(err, mysql.ErrorBlocked) {
+		return nil, mysql.ErrorAlbumNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	if album.Visibility == models.PostVisibilityFriends && !isFriend {
+		return nil, mysql.ErrorAlbumNotExist
+	}
+	return album, nil
+}
+
+// getOwnAlbum 获取当前用户自己的相册，他人的相册视为不存在
+func getOwnAlbum(userID, albumID int64) (*models.Album, error) {
+	album, err := mysql.GetAlbumByID(albumID)
+	if err != nil {
+		return nil, err
+	}
+	if album.UserID != userID {
+		return nil, mysql.ErrorAlbumNotExist
+	}
+	return album, nil
+}
+
+// getOwnPhoto 获取当前用户自己的照片，他人的照片视为不存在
+func getOwnPhoto(userID, photoID int64) (*models.Photo, error) {
+	photo, err := mysql.GetPhotoByID(photoID)
+	if err != nil {
+		return nil, err
+	}
+	if photo.UserID != userID {
+		return nil, mysql.ErrorPhotoNotExist
+	}
+	return photo, nil
+}
+
+// getAlbumCovers 获取相册封面缩略图，设置了封面的使用封面照片，否则使用最新上传的照片
+func getAlbumCovers(albums []models.Album) (map[int64]string, error) {
+	var albumIDs, coverIDs []int64
+	for _, a := range albums {
+		albumIDs = append(albumIDs, a.ID)
+		if a.CoverPhotoID != 0 {
+			coverIDs = append(coverIDs, a.CoverPhotoID)
+		}
+	}
+	latest, err := mysql.GetLatestAlbumPhotos(albumIDs)
+	if err != nil {
+		return nil, err
+	}
+	photos, err := mysql.GetPhotosByIDs(coverIDs)
+	if err != nil {
+		return nil, err
+	}
+	coverPhotos := make(map[int64]models.Photo, len(photos))
+	for _, p := range photos {
+		coverPhotos[p.ID] = p
+	}
+	covers := make(map[int64]string, len(albums))
+	for _, a := range albums {
+		if p, ok := coverPhotos[a.CoverPhotoID]; ok && p.AlbumID == a.ID {
+			covers[a.ID] = p.ThumbURL
+		} else if p, ok := latest[a.ID]; ok {
+			covers[a.ID] = p.ThumbURL
+		}
+	}
+	return covers, nil
+}
+
+// getPostAlbum 生成动态相册，封面为查看者可见的最新一张动态图片
+func getPostAlbum(viewerID, ownerID int64, isFriend bool) (*models.ParamAlbumItem, error) {
+	count, err := mysql.CountPostPhotos(viewerID, ownerID, isFriend)
+	if err != nil {
+		return nil, err
+	}
+	album := &models.ParamAlbumItem{
+		UserID:      ownerID,
+		Name:        postAlbumName,
+		Description: postAlbumIntro,
+		Auto:        true,
+		PhotoCount:  count,
+	}
+	if count == 0 {
+		return album, nil
+	}
+	images, err := mysql.GetPostPhotos(viewerID, ownerID, isFriend, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(images) > 0 {
+		album.CoverURL = images[0].ThumbURL
+		album.CreatedAt = images[0].CreatedAt
+	}
+	return album, nil
+}
+
+// toParamAlbumItem 封装相册列表项
+func toParamAlbumItem(album *models.Album, count int64, coverURL string) *models.ParamAlbumItem {
+	return &models.ParamAlbumItem{
+		ID:          album.ID,
+		UserID:      album.UserID,
+		Name:        album.Name,
+		Description: album.Description,
+		Visibility:  album.Visibility,
+		CoverURL:    coverURL,
+		PhotoCount:  count,
+		CreatedAt:   album.CreatedAt,
+	}
+}
+
+// toParamPhotoItem 封装照片列表项
+func toParamPhotoItem(photo *models.Photo) models.ParamPhotoItem {
+	return models.ParamPhotoItem{
+		ID:        photo.ID,
+		AlbumID:   photo.AlbumID,
+		URL:       photo.URL,
+		MediumURL: photo.MediumURL,
+		ThumbURL:  photo.ThumbURL,
+		Width:     photo.Width,
+		Height:    photo.Height,
+		Caption:   photo.Caption,
+		ViewCount: photo.ViewCount,
+		CreatedAt: photo.CreatedAt,
+	}
+}
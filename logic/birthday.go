@@ -0,0 +1,146 @@
+package logic
+
+import (
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+const BirthdayMaxDays = 366 // 生日提醒最多查询的天数
+
+// GetUpcomingBirthdays 获取包括今天在内的days天内过生日的好友，按距离生日的天数升序排序
+// 隐藏生日的好友不返回，隐藏出生年份的好友不返回岁数
+func GetUpcomingBirthdays(userID int64, days int) ([]models.ParamBirthdayItem, error) {
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return nil, err
+	}
+	friendIDs := make([]int64, 0, len(remarks))
+	for friendID := range remarks {
+		friendIDs = append(friendIDs, friendID)
+	}
+	birthdays, err := mysql.GetUserBirthdays(friendIDs)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := mysql.GetUserSettings(friendIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	today := dateOf(time.Now())
+	result := make([]models.ParamBirthdayItem, 0)
+	for friendID, birthday := range birthdays {
+		if settings[friendID].HideBirthday {
+			continue
+		}
+		next := nextBirthday(birthday, today)
+		daysUntil := int(next.Sub(today).Hours() / 24)
+		if daysUntil >= days {
+			continue
+		}
+		item := models.ParamBirthdayItem{
+			UserID:    friendID,
+			Birthday:  birthday.Format("01-02"),
+			Date:      next.Format("2006-01-02"),
+			DaysUntil: daysUntil,
+		}
+		if !settings[friendID].HideBirthYear {
+			item.Age = next.Year() - birthday.Year()
+		}
+		result = append(result, item)
+	}
+
+	// 昵称与头像从资料缓存读取，优先显示好友备注
+	ids := make([]int64, len(result))
+	for i, item := range result {
+		ids[i] = item.UserID
+	}
+	profiles, err := getProfiles(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Nickname = displayName(result[i].UserID, profiles, remarks)
+		result[i].Avatar = profiles[result[i].UserID].AvatarURL
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].DaysUntil != result[j].DaysUntil {
+			return result[i].DaysUntil < result[j].DaysUntil
+		}
+		return result[i].UserID < result[j].UserID
+	})
+	return result, nil
+}
+
+// NotifyBirthdays 通知今天过生日的用户的全部好友，隐藏生日的用户不通知
+// 平年的2月28日同时提醒2月29日出生的用户
+func NotifyBirthdays(now time.Time) error {
+	today := dateOf(now)
+	userIDs, err := mysql.GetUserIDsByBirthday(int(today.Month()), today.Day())
+	if err != nil {
+		return err
+	}
+	if today.Month() == time.February && today.Day() == 28 && !isLeapYear(today.Year()) {
+		leapIDs, err := mysql.GetUserIDsByBirthday(2, 29)
+		if err != nil {
+			return err
+		}
+		userIDs = append(userIDs, leapIDs...)
+	}
+	settings, err := mysql.GetUserSettings(userIDs)
+	if err != nil {
+		return err
+	}
+	for _, uid := range userIDs {
+		if settings[uid].HideBirthday {
+			continue
+		}
+		friendIDs, err := GetFriendIDs(uid)
+		if err != nil {
+			zap.L().Error("GetFriendIDs failed", zap.Int64("user_id", uid), zap.Error(err))
+			continue
+		}
+		for _, friendID := range friendIDs {
+			Notify(friendID, uid, models.NotificationTypeBirthday, uid, "今天过生日，送上你的祝福吧")
+		}
+	}
+	return nil
+}
+
+// StartBirthdayNotifier 启动生日提醒任务，每天9点后发送一次当天的生日提醒
+func StartBirthdayNotifier() {
+	runDailyJob("birthday", NotifyBirthdays)
+}
+
+// nextBirthday 计算today当天或之后的下一个生日
+func nextBirthday(birthday, today time.Time) time.Time {
+	next := birthdayIn(birthday, today.Year())
+	if next.Before(today) {
+		next = birthdayIn(birthday, today.Year()+1)
+	}
+	return next
+}
+
+// birthdayIn 计算指定年份的生日日期，2月29日出生的用户在平年按2月28日计算
+// 日期统一使用UTC零点表示，避免夏令时影响天数计算
+func birthdayIn(birthday time.Time, year int) time.Time {
+	month, day := birthday.Month(), birthday.Day()
+	if month == time.February && day == 29 && !isLeapYear(year) {
+		day = 28
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// dateOf 取本地时间的日期部分，以UTC零点表示
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// isLeapYear 判断是否为闰年
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
@@ -0,0 +1,61 @@
+package logic
+
+import (
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+// BlockUser 拉黑用户
+func BlockUser(userID, blockedID int64) error {
+	// 不能拉黑自己
+	if userID == blockedID {
+		return mysql.ErrorCannotBlockSelf
+	}
+	// 判断用户是否存在
+	if err := mysql.IsUserExist(blockedID); err != nil {
+		return err
+	}
+	// 判断是否已经拉黑
+	blocked, err := mysql.HasBlocked(userID, blockedID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return mysql.ErrorIsBlocked
+	}
+	if err = mysql.BlockUser(userID, blockedID); err != nil {
+		return err
+	}
+	// 拉黑后解除双方的关注关系
+	return mysql.DeleteFollowBetween(userID, blockedID)
+}
+
+// UnblockUser 取消拉黑
+func UnblockUser(userID, blockedID int64) error {
+	blocked, err := mysql.HasBlocked(userID, blockedID)
+	if err != nil {
+		return err
+	}
+	if !blocked {
+		return mysql.ErrorIsNotBlocked
+	}
+	return mysql.UnblockUser(userID, blockedID)
+}
+
+// GetBlockList 获取黑名单列表
+func GetBlockList(userID int64) ([]models.ParamBlockItem, error) {
+	blocks, err := mysql.GetBlockList(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamBlockItem, 0, len(blocks))
+	for _, b := range blocks {
+		result = append(result, models.ParamBlockItem{
+			UserID:    b.BlockedID,
+			Username:  b.Blocked.Username,
+			AvatarURL: b.Blocked.AvatarURL,
+			CreatedAt: b.CreatedAt,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,201 @@
+package logic
+
+import (
+	"time"
+
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+// bookmarkCursor 收藏列表分页游标，指向上一页最后一条收藏
+type bookmarkCursor struct {
+	At time.Time `json:"t"` // 收藏时间
+	ID int64     `json:"i"` // 收藏记录ID
+}
+
+// BookmarkPost 收藏自己可见的动态，collectionID为0时放入未分类；已收藏时视为成功，不改变所属收藏夹
+func BookmarkPost(userID, postID, collectionID int64) error {
+	if _, err := getVisiblePost(userID, postID); err != nil {
+		return err
+	}
+	if collectionID != 0 {
+		if _, err := getOwnCollection(userID, collectionID); err != nil {
+			return err
+		}
+	}
+	_, err := mysql.CreateBookmark(&models.Bookmark{UserID: userID, PostID: postID, CollectionID: collectionID})
+	return err
+}
+
+// UnbookmarkPost 取消收藏，未收藏时视为成功
+// 动态已删除或已不可见时同样可以取消收藏，因此不校验可见性
+func UnbookmarkPost(userID, postID int64) error {
+	_, err := mysql.DeleteBookmark(userID, postID)
+	return err
+}
+
+// MoveBookmark 将收藏移动到指定收藏夹，collectionID为0时移回未分类
+func MoveBookmark(userID, postID, collectionID int64) error {
+	if collectionID != 0 {
+		if _, err := getOwnCollection(userID, collectionID); err != nil {
+			return err
+		}
+	}
+	moved, err := mysql.MoveBookmark(userID, postID, collectionID)
+	if err != nil {
+		return err
+	}
+	if !moved {
+		return mysql.ErrorPostNotExist
+	}
+	return nil
+}
+
+// GetBookmarks 游标分页获取收藏的动态，collectionID为nil时返回全部收藏
+// 每次读取都重新校验可见范围，作者删除动态或收紧可见范围后，该动态不再出现在列表中
+func GetBookmarks(userID int64, collectionID *int64, cursor string) (*models.ParamBookmarkPage, error) {
+	var c bookmarkCursor
+	if err := decodeCursor(cursor, &c); err != nil {
+		return nil, err
+	}
+	if collectionID != nil && *collectionID != 0 {
+		if _, err := getOwnCollection(userID, *collectionID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 逐批读取收藏记录并过滤不可见的动态，直到凑满一页或没有更多收藏
+	var bookmarks []models.Bookmark
+	var posts []models.Post
+	hasMore := true
+	for round := 0; round < feedMaxRounds && len(posts) < feedPageSize; round++ {
+		need := feedPageSize - len(posts)
+		batch, err := mysql.GetBookmarks(userID, collectionID, c.At, c.ID, need*2)
+		if err != nil {
+			return nil, err
+		}
+		visible, err := getVisibleBookmarkedPosts(userID, batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range batch {
+			c = bookmarkCursor{At: b.CreatedAt, ID: b.ID}
+			post, ok := visible[b.PostID]
+			if !ok {
+				continue
+			}
+			bookmarks = append(bookmarks, b)
+			posts = append(posts, post)
+			if len(posts) == feedPageSize {
+				break
+			}
+		}
+		if len(batch) < need*2 && len(posts) < feedPageSize {
+			hasMore = false
+			break
+		}
+	}
+
+	items, err := decoratePosts(userID, posts)
+	if err != nil {
+		return nil, err
+	}
+	page := &models.ParamBookmarkPage{Bookmarks: make([]models.ParamBookmarkItem, 0, len(items))}
+	for i, item := range items {
+		page.Bookmarks = append(page.Bookmarks, models.ParamBookmarkItem{
+			ParamPostWithUserInfo: item,
+			CollectionID:          bookmarks[i].CollectionID,
+			BookmarkedAt:          bookmarks[i].CreatedAt,
+		})
+	}
+	if hasMore {
+		page.NextCursor = encodeCursor(c)
+	}
+	return page, nil
+}
+
+// getVisibleBookmarkedPosts 获取收藏记录对应的、当前用户仍可见的动态
+func getVisibleBookmarkedPosts(userID int64, bookmarks []models.Bookmark) (map[int64]models.Post, error) {
+	postIDs := make([]int64, len(bookmarks))
+	for i, b := range bookmarks {
+		postIDs[i] = b.PostID
+	}
+	posts, err := mysql.GetPostsByIDs(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	visible := make(map[int64]models.Post, len(posts))
+	for i := range posts {
+		ok, err := canViewPost(userID, &posts[i])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			visible[posts[i].ID] = posts[i]
+		}
+	}
+	return visible, nil
+}
+
+// CreateCollection 创建收藏夹
+func CreateCollection(userID int64, name string) (*models.ParamCollectionItem, error) {
+	collection := models.Collection{UserID: userID, Name: name}
+	if err := mysql.CreateCollection(&collection); err != nil {
+		return nil, err
+	}
+	return &models.ParamCollectionItem{
+		ID:        collection.ID,
+		Name:      collection.Name,
+		CreatedAt: collection.CreatedAt,
+	}, nil
+}
+
+// GetCollections 获取当前用户的收藏夹及各收藏夹的收藏数
+func GetCollections(userID int64) ([]models.ParamCollectionItem, error) {
+	collections, err := mysql.GetCollections(userID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := mysql.CountCollectionBookmarks(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamCollectionItem, 0, len(collections))
+	for _, c := range collections {
+		result = append(result, models.ParamCollectionItem{
+			ID:        c.ID,
+			Name:      c.Name,
+			Count:     counts[c.ID],
+			CreatedAt: c.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// RenameCollection 修改收藏夹名称
+func RenameCollection(userID, collectionID int64, name string) error {
+	if _, err := getOwnCollection(userID, collectionID); err != nil {
+		return err
+	}
+	return mysql.RenameCollection(collectionID, name)
+}
+
+// DeleteCollection 删除收藏夹，其中的收藏移回未分类
+func DeleteCollection(userID, collectionID int64) error {
+	if _, err := getOwnCollection(userID, collectionID); err != nil {
+		return err
+	}
+	return mysql.DeleteCollection(collectionID)
+}
+
+// getOwnCollection 获取当前用户自己的收藏夹，他人的收藏夹视为不存在
+func getOwnCollection(userID, collectionID int64) (*models.Collection, error) {
+	collection, err := mysql.GetCollectionByID(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection.UserID != userID {
+		return nil, mysql.ErrorCollectionNotExist
+	}
+	return collection, nil
+}
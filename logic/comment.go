@@ -0,0 +1,131 @@
+package logic
+
+import (
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+// CreateComment 发表评论，replyTo不为0时表示回复该评论
+func CreateComment(userID, postID, replyTo int64, content string) (*models.ParamCommentItem, error) {
+	// 1. 只能评论自己可见的动态
+	post, err := getVisiblePost(userID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := models.Comment{
+		PostID:  postID,
+		UserID:  userID,
+		Content: content,
+	}
+	// 2. 回复评论时，被回复的评论必须属于同一条动态、对当前用户可见，且双方没有拉黑关系
+	var parent *models.Comment
+	if replyTo != 0 {
+		parent, err = mysql.GetVisibleCommentByID(replyTo, userID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.PostID != postID {
+			return nil, mysql.ErrorCommentNotExist
+		}
+		blocked, err := mysql.IsBlockedBetween(userID, parent.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, mysql.ErrorCommentNotExist
+		}
+		comment.RootID = parent.RootID
+		if comment.RootID == 0 {
+			comment.RootID = parent.ID
+		}
+		comment.ReplyToID = parent.ID
+		comment.ReplyToUserID = parent.UserID
+	}
+
+	// 3. 保存评论
+	if err = mysql.CreateComment(&comment); err != nil {
+		return nil, err
+	}
+
+	// 4. 通知动态作者与被回复者
+	Notify(post.UserID, userID, models.NotificationTypeComment, postID, "评论了你的动态")
+	if parent != nil && parent.UserID != post.UserID {
+		Notify(parent.UserID, userID, models.NotificationTypeReply, postID, "回复了你的评论")
+	}
+
+	items, err := toParamCommentItems(userID, []models.Comment{comment})
+	if err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// DeleteComment 删除评论，评论者本人或动态作者可删除
+func DeleteComment(userID, commentID int64) error {
+	comment, err := mysql.GetCommentByID(commentID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != userID {
+		post, err := mysql.GetPostByID(comment.PostID)
+		if err != nil {
+			return err
+		}
+		if post.UserID != userID {
+			return mysql.ErrorCannotDeleteComment
+		}
+	}
+	return mysql.DeleteComment(comment)
+}
+
+// GetPostComments 获取动态的评论列表，仅展示当前用户好友、动态作者及自己的评论
+func GetPostComments(userID, postID int64, offset int) ([]models.ParamCommentItem, error) {
+	if _, err := getVisiblePost(userID, postID); err != nil {
+		return nil, err
+	}
+	comments, err := mysql.GetVisibleComments(postID, userID, offset, 20)
+	if err != nil {
+		return nil, err
+	}
+	return toParamCommentItems(userID, comments)
+}
+
+// toParamCommentItems 封装评论列表，昵称优先显示当前用户的好友备注
+func toParamCommentItems(viewerID int64, comments []models.Comment) ([]models.ParamCommentItem, error) {
+	remarks, err := getFriendRemarks(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]int64, 0, len(comments)*2)
+	for _, c := range comments {
+		userIDs = append(userIDs, c.UserID)
+		if c.ReplyToUserID != 0 {
+			userIDs = append(userIDs, c.ReplyToUserID)
+		}
+	}
+	profiles, err := getProfiles(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamCommentItem, 0, len(comments))
+	for _, c := range comments {
+		item := models.ParamCommentItem{
+			ID:            c.ID,
+			PostID:        c.PostID,
+			UserID:        c.UserID,
+			DisplayName:   displayName(c.UserID, profiles, remarks),
+			AvatarURL:     profiles[c.UserID].AvatarURL,
+			RootID:        c.RootID,
+			ReplyToID:     c.ReplyToID,
+			ReplyToUserID: c.ReplyToUserID,
+			Content:       c.Content,
+			CreatedAt:     c.CreatedAt,
+		}
+		if c.ReplyToUserID != 0 {
+			item.ReplyToName = displayName(c.ReplyToUserID, profiles, remarks)
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
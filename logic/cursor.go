@@ -0,0 +1,31 @@
+package logic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"gosocial/dao/mysql"
+)
+
+// encodeCursor 将分页位置编码为对前端不透明的游标字符串
+func encodeCursor(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor 解析游标字符串，空游标表示从第一页开始
+func decodeCursor(cursor string, v interface{}) error {
+	if cursor == "" {
+		return nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return mysql.ErrorInvalidCursor
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return mysql.ErrorInvalidCursor
+	}
+	return nil
+}
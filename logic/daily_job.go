@@ -0,0 +1,43 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gosocial/dao/redis"
+)
+
+const (
+	dailyJobHour        = 9                // 每日任务在每天9点后执行
+	dailyJobCheckPeriod = 10 * time.Minute // 每日任务的检查间隔
+)
+
+// runDailyJob 每天9点后执行一次任务
+// 执行前在Redis中标记日期，服务重启或多实例部署时同一天不会重复执行；执行失败时清除标记，下次检查时重试
+func runDailyJob(job string, fn func(now time.Time) error) {
+	ticker := time.NewTicker(dailyJobCheckPeriod)
+	defer ticker.Stop()
+	for range ticker.C {
+		now := time.Now()
+		if now.Hour() < dailyJobHour {
+			continue
+		}
+		ctx := context.Background()
+		date := now.Format("2006-01-02")
+		ok, err := redis.MarkDailyJobDone(ctx, job, date)
+		if err != nil {
+			zap.L().Error("redis.MarkDailyJobDone failed", zap.String("job", job), zap.Error(err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err = fn(now); err != nil {
+			zap.L().Error("daily job failed", zap.String("job", job), zap.Error(err))
+			if err = redis.UnmarkDailyJobDone(ctx, job, date); err != nil {
+				zap.L().Error("redis.UnmarkDailyJobDone failed", zap.String("job", job), zap.Error(err))
+			}
+		}
+	}
+}
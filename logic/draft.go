@@ -0,0 +1,389 @@
+package logic
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+const (
+	maxPostImages         = 9           // 单条动态最多图片数
+	draftPageSize         = 10          // 草稿列表每页条数
+	scheduleMinDelay      = time.Minute // 定时发布时间至少在当前时间之后
+	scheduleMaxDelay      = 30 * 24 * time.Hour
+	draftPublishPeriod    = 30 * time.Second // 定时发布任务扫描间隔
+	draftPublishBatch     = 100
+	draftPublishStaleTime = 10 * time.Minute // 发布中超过该时间视为中断
+)
+
+// CreateDraft 保存草稿，图片在保存时即完成处理，发布时直接复用
+func CreateDraft(userID int64, content, visibility string, userIDs, groupIDs []int64, files [][]byte) (*models.ParamDraftItem, error) {
+	if len(files) > maxPostImages {
+		return nil, mysql.ErrorTooManyImages
+	}
+	images, err := SavePostImages(userID, files)
+	if err != nil {
+		return nil, err
+	}
+	draft := &models.PostDraft{
+		UserID:          userID,
+		Content:         content,
+		Visibility:      visibility,
+		AudienceUserIDs: joinIDs(userIDs),
+		AudienceGroups:  joinIDs(groupIDs),
+		Status:          models.DraftStatusDraft,
+	}
+	if err = mysql.CreateDraft(draft, images); err != nil {
+		removePostImageFiles(images)
+		return nil, err
+	}
+	return GetDraft(userID, draft.ID)
+}
+
+// UpdateDraft 修改草稿的内容与可见范围，定时动态修改后仍按原时间发布
+func UpdateDraft(userID, draftID int64, content, visibility string, userIDs, groupIDs []int64) error {
+	if _, err := getEditableDraft(userID, draftID); err != nil {
+		return err
+	}
+	return mysql.UpdateDraft(draftID, map[string]interface{}{
+		"content":           content,
+		"visibility":        visibility,
+		"audience_user_ids": joinIDs(userIDs),
+		"audience_groups":   joinIDs(groupIDs),
+	})
+}
+
+// AddDraftImages 为草稿追加图片
+func AddDraftImages(userID, draftID int64, files [][]byte) error {
+	if _, err := getEditableDraft(userID, draftID); err != nil {
+		return err
+	}
+	existing, err := mysql.GetDraftImages([]int64{draftID})
+	if err != nil {
+		return err
+	}
+	if len(existing)+len(files) > maxPostImages {
+		return mysql.ErrorTooManyImages
+	}
+	images, err := SavePostImages(userID, files)
+	if err != nil {
+		return err
+	}
+	sort := 0
+	if n := len(existing); n > 0 {
+		sort = existing[n-1].Sort + 1
+	}
+	for i := range images {
+		images[i].Sort = sort + i
+	}
+	if err = mysql.AddDraftImages(draftID, images); err != nil {
+		removePostImageFiles(images)
+		return err
+	}
+	return nil
+}
+
+// DeleteDraftImage 删除草稿中的图片
+func DeleteDraftImage(userID, draftID, imageID int64) error {
+	if _, err := getEditableDraft(userID, draftID); err != nil {
+		return err
+	}
+	image, err := mysql.DeleteDraftImage(draftID, imageID)
+	if err != nil {
+		return err
+	}
+	removePostImageFiles([]models.PostImage{*image})
+	return nil
+}
+
+// GetDrafts 获取自己未发布的草稿与定时动态
+func GetDrafts(userID int64, offset int) ([]models.ParamDraftItem, error) {
+	drafts, err := mysql.GetDrafts(userID, offset, draftPageSize)
+	if err != nil {
+		return nil, err
+	}
+	return toParamDraftItems(drafts)
+}
+
+// GetDraft 获取自己的草稿详情，用于继续编辑
+func GetDraft(userID, draftID int64) (*models.ParamDraftItem, error) {
+	draft, err := getOwnDraft(userID, draftID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := toParamDraftItems([]models.PostDraft{*draft})
+	if err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// DeleteDraft 删除草稿，定时动态删除即取消发布
+func DeleteDraft(userID, draftID int64) error {
+	if _, err := getEditableDraft(userID, draftID); err != nil {
+		return err
+	}
+	images, err := mysql.GetDraftImages([]int64{draftID})
+	if err != nil {
+		return err
+	}
+	if err = mysql.DeleteDraft(draftID); err != nil {
+		return err
+	}
+	removePostImageFiles(images)
+	return nil
+}
+
+// ScheduleDraft 设置或修改草稿的定时发布时间
+func ScheduleDraft(userID, draftID int64, at time.Time) error {
+	draft, err := getEditableDraft(userID, draftID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if at.Before(now.Add(scheduleMinDelay)) || at.After(now.Add(scheduleMaxDelay)) {
+		return mysql.ErrorInvalidScheduleTime
+	}
+	// 提前校验内容与可见名单，避免到时间才发现无法发布
+	if err = checkDraftPublishable(draft); err != nil {
+		return err
+	}
+	return mysql.UpdateDraft(draftID, map[string]interface{}{
+		"status":       models.DraftStatusScheduled,
+		"scheduled_at": at,
+		"last_error":   "",
+	})
+}
+
+// CancelDraftSchedule 取消定时发布，动态回到草稿箱
+func CancelDraftSchedule(userID, draftID int64) error {
+	draft, err := getEditableDraft(userID, draftID)
+	if err != nil {
+		return err
+	}
+	if draft.Status == models.DraftStatusDraft {
+		return nil
+	}
+	return mysql.UpdateDraft(draftID, map[string]interface{}{
+		"status":       models.DraftStatusDraft,
+		"scheduled_at": nil,
+		"last_error":   "",
+	})
+}
+
+// PublishDraft 立即发布草稿
+func PublishDraft(userID, draftID int64) (*models.Post, error) {
+	draft, err := getEditableDraft(userID, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkDraftPublishable(draft); err != nil {
+		return nil, err
+	}
+	// 抢占草稿，避免与定时任务重复发布
+	ok, err := mysql.ClaimDraft(draftID, []string{draft.Status})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, mysql.ErrorDraftNotEditable
+	}
+	post, err := publishDraft(draft)
+	if err != nil {
+		if e := mysql.SetDraftStatus(draftID, draft.Status, draft.LastError); e != nil {
+			zap.L().Error("mysql.SetDraftStatus failed", zap.Int64("draft_id", draftID), zap.Error(e))
+		}
+		return nil, err
+	}
+	return post, nil
+}
+
+// PublishDueDrafts 发布到达时间的定时动态，发布失败的动态标记为失败并通知作者
+func PublishDueDrafts() error {
+	now := time.Now()
+	if err := mysql.FailStaleDrafts(now.Add(-draftPublishStaleTime), "发布中断，请重新发布"); err != nil {
+		return err
+	}
+	ids, err := mysql.GetDueDraftIDs(now, draftPublishBatch)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		ok, err := mysql.ClaimDraft(id, []string{models.DraftStatusScheduled})
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		draft, err := mysql.GetDraftByID(id)
+		if err != nil {
+			return err
+		}
+		if err = checkDraftPublishable(draft); err == nil {
+			_, err = publishDraft(draft)
+		}
+		if err != nil {
+			zap.L().Error("publish scheduled draft failed", zap.Int64("draft_id", id), zap.Error(err))
+			if e := mysql.SetDraftStatus(id, models.DraftStatusFailed, draftErrorText(err)); e != nil {
+				zap.L().Error("mysql.SetDraftStatus failed", zap.Int64("draft_id", id), zap.Error(e))
+			}
+			Notify(draft.UserID, 0, models.NotificationTypeScheduleFailed, draft.ID, "定时动态发布失败："+draftErrorText(err))
+		}
+	}
+	return nil
+}
+
+// StartScheduledPostPublisher 启动定时动态发布任务，任务状态保存在MySQL中，服务重启后继续发布
+func StartScheduledPostPublisher() {
+	ticker := time.NewTicker(draftPublishPeriod)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := PublishDueDrafts(); err != nil {
+			zap.L().Error("PublishDueDrafts failed", zap.Error(err))
+		}
+	}
+}
+
+// publishDraft 发布草稿(写入时间线、通知提及的用户)，保存动态与标记草稿已发布在同一事务中完成
+func publishDraft(draft *models.PostDraft) (*models.Post, error) {
+	images, err := mysql.GetDraftImages([]int64{draft.ID})
+	if err != nil {
+		return nil, err
+	}
+	post := &models.Post{
+		UserID:     draft.UserID,
+		Content:    draft.Content,
+		ImageList:  images,
+		Visibility: draft.Visibility,
+		DraftID:    draft.ID,
+	}
+	if err = publishPost(post, splitIDs(draft.AudienceUserIDs), splitIDs(draft.AudienceGroups)); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// checkDraftPublishable 校验草稿是否可以发布：内容与图片不能同时为空，自定义可见名单有效
+func checkDraftPublishable(draft *models.PostDraft) error {
+	if strings.TrimSpace(draft.Content) == "" {
+		images, err := mysql.GetDraftImages([]int64{draft.ID})
+		if err != nil {
+			return err
+		}
+		if len(images) == 0 {
+			return mysql.ErrorEmptyPost
+		}
+	}
+	_, err := buildPostAudiences(draft.UserID, draft.Visibility, splitIDs(draft.AudienceUserIDs), splitIDs(draft.AudienceGroups))
+	return err
+}
+
+// getOwnDraft 获取自己的草稿
+func getOwnDraft(userID, draftID int64) (*models.PostDraft, error) {
+	draft, err := mysql.GetDraftByID(draftID)
+	if err != nil {
+		return nil, err
+	}
+	if draft.UserID != userID {
+		return nil, mysql.ErrorDraftNotExist
+	}
+	return draft, nil
+}
+
+// getEditableDraft 获取自己的、尚未进入发布流程的草稿
+func getEditableDraft(userID, draftID int64) (*models.PostDraft, error) {
+	draft, err := getOwnDraft(userID, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status == models.DraftStatusPublishing || draft.Status == models.DraftStatusPublished {
+		return nil, mysql.ErrorDraftNotEditable
+	}
+	return draft, nil
+}
+
+// toParamDraftItems 转换草稿列表并批量补充图片
+func toParamDraftItems(drafts []models.PostDraft) ([]models.ParamDraftItem, error) {
+	ids := make([]int64, len(drafts))
+	for i, d := range drafts {
+		ids[i] = d.ID
+	}
+	images, err := mysql.GetDraftImages(ids)
+	if err != nil {
+		return nil, err
+	}
+	imageMap := make(map[int64][]models.ParamDraftImage, len(drafts))
+	for _, img := range images {
+		imageMap[img.DraftID] = append(imageMap[img.DraftID], models.ParamDraftImage{
+			ID: img.ID,
+			ParamPostImage: models.ParamPostImage{
+				URL:       img.URL,
+				MediumURL: img.MediumURL,
+				ThumbURL:  img.ThumbURL,
+				Width:     img.Width,
+				Height:    img.Height,
+			},
+		})
+	}
+	result := make([]models.ParamDraftItem, 0, len(drafts))
+	for _, d := range drafts {
+		item := models.ParamDraftItem{
+			ID:          d.ID,
+			Content:     d.Content,
+			Visibility:  d.Visibility,
+			Images:      imageMap[d.ID],
+			Status:      d.Status,
+			ScheduledAt: d.ScheduledAt,
+			LastError:   d.LastError,
+			PostID:      d.PostID,
+			UpdatedAt:   d.UpdatedAt,
+		}
+		if item.Images == nil {
+			item.Images = []models.ParamDraftImage{}
+		}
+		if hasAudience(d.Visibility) {
+			item.Audience = &models.ParamPostAudience{
+				UserIDs:  formatIDs(splitIDs(d.AudienceUserIDs)),
+				GroupIDs: formatIDs(splitIDs(d.AudienceGroups)),
+			}
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// draftErrorText 定时发布失败的原因，只向用户展示业务错误
+func draftErrorText(err error) string {
+	for _, e := range []error{mysql.ErrorEmptyPost, mysql.ErrorInvalidAudience, mysql.ErrorUserNotExist} {
+		if errors.Is(err, e) {
+			return e.Error()
+		}
+	}
+	return "服务繁忙"
+}
+
+// joinIDs 将ID列表保存为逗号分隔的字符串
+func joinIDs(ids []int64) string {
+	return strings.Join(formatIDs(uniqueIDs(ids)), ",")
+}
+
+// splitIDs 解析逗号分隔的ID字符串，忽略无效的ID
+func splitIDs(s string) []int64 {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	ids := make([]int64, 0, len(parts))
+	for _, p := range parts {
+		if id, err := strconv.ParseInt(p, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,207 @@
+package logic
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"mime"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/models"
+	"gosocial/pkg/feed"
+)
+
+const (
+	feedItemCount      = 20 // 订阅源包含的最新公开动态数
+	feedTitleMaxRunes  = 50 // 条目标题最多截取的字数
+	feedTokenByteCount = 32 // 订阅令牌的随机字节数
+)
+
+// 订阅源格式
+const (
+	FeedFormatRSS  = "rss"
+	FeedFormatAtom = "atom"
+	FeedFormatJSON = "json"
+)
+
+// GetFeedToken 获取当前用户的订阅令牌及订阅地址，未生成时返回ErrorFeedTokenNotExist
+func GetFeedToken(userID int64, baseURL string) (*models.ParamFeedToken, error) {
+	token, err := mysql.GetFeedTokenByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return newParamFeedToken(token, baseURL), nil
+}
+
+// ResetFeedToken 生成新的订阅令牌，旧的订阅地址立即失效
+func ResetFeedToken(userID int64, baseURL string) (*models.ParamFeedToken, error) {
+	buf := make([]byte, feedTokenByteCount)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	token := models.FeedToken{UserID: userID, Token: hex.EncodeToString(buf)}
+	if err := mysql.ResetFeedToken(&token); err != nil {
+		return nil, err
+	}
+	return newParamFeedToken(&token, baseURL), nil
+}
+
+// RevokeFeedToken 吊销订阅令牌，之后所有订阅地址均不可访问
+func RevokeFeedToken(userID int64) error {
+	return mysql.DeleteFeedToken(userID)
+}
+
+// touchFeed 用户的订阅源内容可能变化时更新最后变化时间，失败只记录日志
+func touchFeed(userID int64) {
+	if err := mysql.TouchFeedToken(userID, time.Now()); err != nil {
+		zap.L().Error("mysql.TouchFeedToken failed", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
+
+// GetUserFeed 根据订阅令牌生成该用户的订阅源，只包含最新的公开动态
+// baseURL为站点的外部访问地址，用于生成图片等资源的绝对地址；feedURL为订阅源自身的地址
+func GetUserFeed(token, baseURL, feedURL string) (*feed.Feed, error) {
+	t, err := mysql.GetFeedTokenByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	user, err := mysql.GetUserByUID(t.UserID)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := mysql.GetPublicPostsByUserID(user.UserID, 0, feedItemCount)
+	if err != nil {
+		return nil, err
+	}
+	postIDs := make([]int64, len(posts))
+	for i, p := range posts {
+		postIDs[i] = p.ID
+	}
+	images, err := getPostImageMap(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	names, err := mentionedNames(postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	host := strings.TrimPrefix(strings.TrimPrefix(baseURL, "https://"), "http://")
+	f := &feed.Feed{
+		ID:          fmt.Sprintf("tag:%s,%s:user:%d", host, user.CreatedAt.Format("2006-01-02"), user.UserID),
+		Title:       user.Username + "的动态",
+		Link:        baseURL + "/",
+		FeedURL:     feedURL,
+		Description: user.Signature,
+		Author:      user.Username,
+		Updated:     t.ModifiedAt,
+		Items:       make([]feed.Item, 0, len(posts)),
+	}
+	if f.Description == "" {
+		f.Description = user.Username + "公开发布的动态"
+	}
+	if user.AvatarURL != "" {
+		f.Icon = absoluteURL(baseURL, user.AvatarURL)
+	}
+	for _, p := range posts {
+		content, _ := renderMentions(p.Content, names[p.ID])
+		item := feed.Item{
+			ID:          fmt.Sprintf("tag:%s,%s:post:%d", host, p.CreatedAt.Format("2006-01-02"), p.ID),
+			ContentText: content,
+			Published:   p.CreatedAt,
+			Updated:     p.CreatedAt,
+			Enclosures:  postEnclosures(baseURL, &p, images[p.ID]),
+		}
+		if p.EditedAt != nil {
+			item.Updated = *p.EditedAt
+		}
+		item.Title = feedItemTitle(content, len(item.Enclosures))
+		item.ContentHTML = feedItemHTML(content, item.Enclosures)
+		f.Items = append(f.Items, item)
+	}
+	return f, nil
+}
+
+// newParamFeedToken 生成订阅令牌对应的各格式订阅地址
+func newParamFeedToken(token *models.FeedToken, baseURL string) *models.ParamFeedToken {
+	prefix := baseURL + "/api/v1/feeds/" + token.Token + "/"
+	return &models.ParamFeedToken{
+		Token:     token.Token,
+		RSSURL:    prefix + FeedFormatRSS,
+		AtomURL:   prefix + FeedFormatAtom,
+		JSONURL:   prefix + FeedFormatJSON,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+// postEnclosures 将动态图片转换为订阅源附件，未使用图片表的旧动态使用Images字段中的图片URL
+func postEnclosures(baseURL string, post *models.Post, images []models.PostImage) []feed.Enclosure {
+	var enclosures []feed.Enclosure
+	if len(images) > 0 {
+		for _, img := range images {
+			enclosures = append(enclosures, feed.Enclosure{
+				URL:    absoluteURL(baseURL, img.URL),
+				Type:   "image/" + img.Format,
+				Length: img.Size,
+			})
+		}
+		return enclosures
+	}
+	for _, u := range strings.Split(post.Images, ",") {
+		u = strings.TrimSpace(u)
+		if u == "" {
+			continue
+		}
+		typ := mime.TypeByExtension(strings.ToLower(path.Ext(u)))
+		if typ == "" {
+			typ = "application/octet-stream"
+		}
+		enclosures = append(enclosures, feed.Enclosure{URL: absoluteURL(baseURL, u), Type: typ})
+	}
+	return enclosures
+}
+
+// feedItemTitle 取动态第一行的前若干字作为标题，没有文字时以图片数作为标题
+func feedItemTitle(content string, imageCount int) string {
+	title := strings.TrimSpace(strings.SplitN(strings.TrimSpace(content), "\n", 2)[0])
+	if title == "" {
+		if imageCount > 0 {
+			return fmt.Sprintf("分享了%d张图片", imageCount)
+		}
+		return "动态"
+	}
+	if utf8.RuneCountInString(title) > feedTitleMaxRunes {
+		title = string([]rune(title)[:feedTitleMaxRunes]) + "…"
+	}
+	return title
+}
+
+// feedItemHTML 将动态文字转义为HTML并附上全部图片
+func feedItemHTML(content string, enclosures []feed.Enclosure) string {
+	var b strings.Builder
+	if content != "" {
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(content), "\n", "<br>"))
+		b.WriteString("</p>")
+	}
+	for _, e := range enclosures {
+		b.WriteString(`<p><img src="`)
+		b.WriteString(html.EscapeString(e.URL))
+		b.WriteString(`" alt=""></p>`)
+	}
+	return b.String()
+}
+
+// absoluteURL 将站内相对地址转换为绝对地址，已是绝对地址时原样返回
+func absoluteURL(baseURL, u string) string {
+	if strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://") {
+		return u
+	}
+	return baseURL + "/" + strings.TrimPrefix(u, "/")
+}
@@ -0,0 +1,147 @@
+package logic
+
+import (
+	"errors"
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+// Follow 关注用户，对方开启关注审核时进入待审核状态
+func Follow(userID, followeeID int64) (status string, err error) {
+	// 不能关注自己
+	if userID == followeeID {
+		return "", mysql.ErrorCannotFollowSelf
+	}
+	// 判断用户是否存在
+	if err = mysql.IsUserExist(followeeID); err != nil {
+		return "", err
+	}
+	// 存在拉黑关系时不能关注
+	blocked, err := mysql.IsBlockedBetween(userID, followeeID)
+	if err != nil {
+		return "", err
+	}
+	if blocked {
+		return "", mysql.ErrorBlocked
+	}
+	// 判断是否已经关注(或已申请)
+	if _, err = mysql.GetFollow(userID, followeeID); err == nil {
+		return "", mysql.ErrorIsFollowing
+	} else if !errors.Is(err, mysql.ErrorIsNotFollowing) {
+		return "", err
+	}
+	// 根据对方设置决定是否需要审核
+	setting, err := mysql.GetUserSetting(followeeID)
+	if err != nil {
+		return "", err
+	}
+	status = models.FollowStatusAccepted
+	if setting.FollowNeedApproval {
+		status = models.FollowStatusPending
+	}
+	err = mysql.CreateFollow(&models.Follow{
+		FollowerID: userID,
+		FolloweeID: followeeID,
+		Status:     status,
+	})
+	if err == nil && status == models.FollowStatusAccepted {
+		invalidateTimeline(userID)
+	}
+	return status, err
+}
+
+// Unfollow 取消关注或撤回关注申请
+func Unfollow(userID, followeeID int64) error {
+	if _, err := mysql.GetFollow(userID, followeeID); err != nil {
+		return err
+	}
+	return mysql.DeleteFollow(userID, followeeID)
+}
+
+// AcceptFollowRequest 通过关注申请
+func AcceptFollowRequest(userID, followerID int64) error {
+	follow, err := mysql.GetFollow(followerID, userID)
+	if err != nil || follow.Status != models.FollowStatusPending {
+		return mysql.ErrorFollowRequestNotExist
+	}
+	if err = mysql.UpdateFollowStatus(followerID, userID, models.FollowStatusAccepted); err != nil {
+		return err
+	}
+	invalidateTimeline(followerID)
+	return nil
+}
+
+// RejectFollowRequest 拒绝关注申请
+func RejectFollowRequest(userID, followerID int64) error {
+	follow, err := mysql.GetFollow(followerID, userID)
+	if err != nil || follow.Status != models.FollowStatusPending {
+		return mysql.ErrorFollowRequestNotExist
+	}
+	return mysql.DeleteFollow(followerID, userID)
+}
+
+// GetFollowers 获取粉丝列表
+func GetFollowers(userID int64, offset int) ([]models.ParamFollowItem, error) {
+	follows, err := mysql.GetFollowers(userID, models.FollowStatusAccepted, offset, 20)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamFollowItem, 0, len(follows))
+	for _, f := range follows {
+		result = append(result, toFollowItem(f.Follower, f))
+	}
+	return result, nil
+}
+
+// GetFollowRequests 获取待审核的关注申请
+func GetFollowRequests(userID int64, offset int) ([]models.ParamFollowItem, error) {
+	follows, err := mysql.GetFollowers(userID, models.FollowStatusPending, offset, 20)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamFollowItem, 0, len(follows))
+	for _, f := range follows {
+		result = append(result, toFollowItem(f.Follower, f))
+	}
+	return result, nil
+}
+
+// GetFollowing 获取关注列表
+func GetFollowing(userID int64, offset int) ([]models.ParamFollowItem, error) {
+	follows, err := mysql.GetFollowing(userID, offset, 20)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamFollowItem, 0, len(follows))
+	for _, f := range follows {
+		result = append(result, toFollowItem(f.Followee, f))
+	}
+	return result, nil
+}
+
+// GetFollowCount 获取关注数与粉丝数
+func GetFollowCount(userID int64) (*models.ParamFollowCount, error) {
+	followers, err := mysql.CountFollowers(userID)
+	if err != nil {
+		return nil, err
+	}
+	following, err := mysql.CountFollowing(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ParamFollowCount{
+		Followers: followers,
+		Following: following,
+	}, nil
+}
+
+// toFollowItem 转换关注关系到前端需要的列表项
+func toFollowItem(u models.User, f models.Follow) models.ParamFollowItem {
+	return models.ParamFollowItem{
+		UserID:    u.UserID,
+		Username:  u.Username,
+		AvatarURL: u.AvatarURL,
+		Signature: u.Signature,
+		CreatedAt: f.CreatedAt,
+	}
+}
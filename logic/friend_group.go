@@ -0,0 +1,120 @@
+package logic
+
+import (
+	"gosocial/dao/mysql"
+	"gosocial/models"
+	"strconv"
+)
+
+// CreateFriendGroup 创建好友分组，成员必须是当前用户的好友
+func CreateFriendGroup(userID int64, name string, memberIDs []int64) (*models.ParamFriendGroupItem, error) {
+	memberIDs, err := checkFriendIDs(userID, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+	group := models.FriendGroup{UserID: userID, Name: name}
+	if err = mysql.CreateFriendGroup(&group, memberIDs); err != nil {
+		return nil, err
+	}
+	return &models.ParamFriendGroupItem{
+		ID:        group.ID,
+		Name:      group.Name,
+		MemberIDs: formatIDs(memberIDs),
+		CreatedAt: group.CreatedAt,
+	}, nil
+}
+
+// GetFriendGroups 获取当前用户的好友分组及成员
+func GetFriendGroups(userID int64) ([]models.ParamFriendGroupItem, error) {
+	groups, err := mysql.GetFriendGroups(userID)
+	if err != nil {
+		return nil, err
+	}
+	groupIDs := make([]int64, len(groups))
+	for i, g := range groups {
+		groupIDs[i] = g.ID
+	}
+	members, err := mysql.GetFriendGroupMembers(groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamFriendGroupItem, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, models.ParamFriendGroupItem{
+			ID:        g.ID,
+			Name:      g.Name,
+			MemberIDs: formatIDs(members[g.ID]),
+			CreatedAt: g.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// UpdateFriendGroup 修改好友分组名称与成员
+func UpdateFriendGroup(userID, groupID int64, name string, memberIDs []int64) error {
+	if _, err := getOwnFriendGroup(userID, groupID); err != nil {
+		return err
+	}
+	memberIDs, err := checkFriendIDs(userID, memberIDs)
+	if err != nil {
+		return err
+	}
+	return mysql.UpdateFriendGroup(groupID, name, memberIDs)
+}
+
+// DeleteFriendGroup 删除好友分组
+func DeleteFriendGroup(userID, groupID int64) error {
+	if _, err := getOwnFriendGroup(userID, groupID); err != nil {
+		return err
+	}
+	return mysql.DeleteFriendGroup(groupID)
+}
+
+// getOwnFriendGroup 获取当前用户自己的分组，他人的分组视为不存在
+func getOwnFriendGroup(userID, groupID int64) (*models.FriendGroup, error) {
+	group, err := mysql.GetFriendGroupByID(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.UserID != userID {
+		return nil, mysql.ErrorFriendGroupNotExist
+	}
+	return group, nil
+}
+
+// checkFriendIDs 去重并检查给定用户均为userID的好友
+func checkFriendIDs(userID int64, ids []int64) ([]int64, error) {
+	ids = uniqueIDs(ids)
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if _, ok := remarks[id]; !ok {
+			return nil, mysql.ErrorIsNotFriend
+		}
+	}
+	return ids, nil
+}
+
+// uniqueIDs 对ID列表去重并保持原有顺序
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// formatIDs 将ID列表转换为字符串，避免前端处理大整数时丢失精度
+func formatIDs(ids []int64) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = strconv.FormatInt(id, 10)
+	}
+	return result
+}
@@ -1,25 +1,63 @@
 package logic
 
 import (
+	"context"
 	"errors"
+	"go.uber.org/zap"
 	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
 	"gosocial/models"
+	"gosocial/pkg/matcher"
 	"sort"
-	"strings"
+	"time"
 )
 
-// GetFriendList 获取好友列表
-func GetFriendList(userID int64) (friendList []models.ParamFriendItem, err error) {
-	// 查询数据库
-	friendships, err := mysql.GetFriendList(userID)
+// 好友列表排序方式
+const (
+	FriendSortLastInteract = "last_interact" // 按最后互动时间
+	FriendSortIntimacy     = "intimacy"      // 按亲密度
+)
+
+// friendCursor 好友列表分页游标
+type friendCursor struct {
+	At     time.Time `json:"t,omitempty"` // 上一页最后一条的最后互动时间
+	ID     int64     `json:"i,omitempty"` // 上一页最后一条的主键
+	Offset int       `json:"o,omitempty"` // 按亲密度排序时的偏移量
+}
+
+// GetFriendList 游标分页获取好友列表
+func GetFriendList(userID int64, sortBy, cursor string, limit int) (*models.ParamFriendPage, error) {
+	var c friendCursor
+	if err := decodeCursor(cursor, &c); err != nil {
+		return nil, err
+	}
+	// 多查一条用于判断是否还有下一页
+	var friendships []models.Friendship
+	var err error
+	if sortBy == FriendSortIntimacy {
+		friendships, err = mysql.GetFriendPageByIntimacy(userID, c.Offset, limit+1)
+	} else {
+		friendships, err = mysql.GetFriendPage(userID, c.At, c.ID, limit+1)
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	page := &models.ParamFriendPage{Friends: make([]models.ParamFriendItem, 0, limit)}
+	if len(friendships) > limit {
+		friendships = friendships[:limit]
+		last := friendships[limit-1]
+		if sortBy == FriendSortIntimacy {
+			page.NextCursor = encodeCursor(friendCursor{Offset: c.Offset + limit})
+		} else {
+			page.NextCursor = encodeCursor(friendCursor{At: last.LastInteractAt, ID: last.ID})
+		}
+	}
 	// 转换数据格式
 	for _, f := range friendships {
-		friendList = append(friendList, ToFriendItem(f))
+		page.Friends = append(page.Friends, ToFriendItem(f))
 	}
-	return
+	return page, nil
 }
 
 // ToFriendItem 转换 Friendship 到前端需要的 FriendItem
@@ -29,6 +67,7 @@ func ToFriendItem(f models.Friendship) models.ParamFriendItem {
 		DisplayName:    ToNickname(f),
 		AvatarURL:      f.Friend.AvatarURL,
 		LastInteractAt: f.LastInteractAt,
+		Intimacy:       f.CurrentIntimacy(time.Now()),
 	}
 }
 
@@ -42,18 +81,31 @@ func AddFriend(userID, friendID int64) error {
 	if err := mysql.IsUserExist(friendID); err != nil {
 		return err
 	}
+	// 存在拉黑关系时不能添加好友
+	if blocked, err := mysql.IsBlockedBetween(userID, friendID); err != nil {
+		return err
+	} else if blocked {
+		return mysql.ErrorBlocked
+	}
 	// 判断是否已经是好友
-	if err := mysql.IsFriend(userID, friendID); err != nil {
+	if ok, err := isFriend(userID, friendID); err != nil {
 		return err
+	} else if ok {
+		return mysql.ErrorIsFriend
 	}
 	// 调用dao层添加好友
-	return mysql.AddFriend(userID, friendID)
+	if err := mysql.AddFriend(userID, friendID); err != nil {
+		return err
+	}
+	invalidateFriendCache(userID, friendID)
+	invalidateTimeline(userID, friendID)
+	return nil
 }
 
 // SearchFriend 搜索好友
 func SearchFriend(userID int64, keyword string) ([]models.ParamFriendItem, error) {
 	// 获取好友列表
-	friendships, err := mysql.GetFriendList(userID)
+	friendships, err := getFriendships(userID)
 	if err != nil {
 		return nil, err
 	}
@@ -61,26 +113,14 @@ func SearchFriend(userID int64, keyword string) ([]models.ParamFriendItem, error
 	// 定义匹配优先级
 	type matchResult struct {
 		friend models.ParamFriendItem
-		score  int // 0:不匹配 1:包含 2:前缀 3:完全匹配
+		score  int // 见matcher包中的得分定义，完全 > 前缀 > 包含 > 拼音 > 容错
 	}
 
 	var results []matchResult
 	for _, f := range friendships {
-		displayName := f.Remark
-		if displayName == "" {
-			displayName = f.Friend.Username
-		}
-
-		// 检查匹配情况
-		var score int
-		switch {
-		case displayName == keyword:
-			score = 3 // 完全匹配
-		case len(displayName) >= len(keyword) && displayName[:len(keyword)] == keyword:
-			score = 2 // 前缀匹配
-		case strings.Contains(displayName, keyword):
-			score = 1 // 包含匹配
-		default:
+		// 备注与用户名均参与匹配，取较高得分
+		score := max(matcher.Score(f.Remark, keyword), matcher.Score(f.Friend.Username, keyword))
+		if score == matcher.ScoreNone {
 			continue // 不匹配则跳过
 		}
 
@@ -90,8 +130,8 @@ func SearchFriend(userID int64, keyword string) ([]models.ParamFriendItem, error
 		})
 	}
 
-	// 按匹配优先级排序
-	sort.Slice(results, func(i, j int) bool {
+	// 按匹配优先级排序，同分保持最后互动时间顺序
+	sort.SliceStable(results, func(i, j int) bool {
 		return results[i].score > results[j].score
 	})
 
@@ -106,8 +146,7 @@ func SearchFriend(userID int64, keyword string) ([]models.ParamFriendItem, error
 
 // GetFriendInfo 获取好友列表
 func GetFriendInfo(userID int64) (friendList []models.Friendship, err error) {
-	// 查询数据库
-	friendships, err := mysql.GetFriendList(userID)
+	friendships, err := getFriendships(userID)
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +156,27 @@ func GetFriendInfo(userID int64) (friendList []models.Friendship, err error) {
 	return friendships, nil
 }
 
+// getFriendships 获取全部好友关系，好友的昵称与头像从资料缓存批量获取
+func getFriendships(userID int64) ([]models.Friendship, error) {
+	friendships, err := mysql.GetFriendList(userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(friendships))
+	for i, f := range friendships {
+		ids[i] = f.FriendID
+	}
+	profiles, err := getProfiles(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range friendships {
+		p := profiles[friendships[i].FriendID]
+		friendships[i].Friend = models.User{UserID: p.UserID, Username: p.Username, AvatarURL: p.AvatarURL}
+	}
+	return friendships, nil
+}
+
 // GetFriendInfoLogic 获取好友信息
 func GetFriendInfoLogic(userID int64) (*models.ParamFriendInfoResponse, error) {
 	// 从数据库获取用户信息
@@ -127,14 +187,20 @@ func GetFriendInfoLogic(userID int64) (*models.ParamFriendInfoResponse, error) {
 		}
 		return nil, mysql.ErrorSystem
 	}
-	// 格式化生日为xx-xx格式
+	setting, err := mysql.GetUserSetting(userID)
+	if err != nil {
+		return nil, mysql.ErrorSystem
+	}
+	// 格式化生日为xx-xx格式，好友隐藏生日时不展示
 	birthday := ""
-	if user.Birthday != nil {
+	if user.Birthday != nil && !setting.HideBirthday {
 		birthday = user.Birthday.Format("01-02")
 	}
 
-	// 计算用户年龄
-	if user.Birthday != nil {
+	// 计算用户年龄，好友隐藏生日或出生年份时不展示
+	if setting.HideBirthday || setting.HideBirthYear {
+		user.Age = 0
+	} else if user.Birthday != nil {
 		user.Age = calculateAge(*user.Birthday)
 	}
 
@@ -165,27 +231,100 @@ func DeleteFriend(userID int64, friendID int64) error {
 		return mysql.ErrorCannotDeleteSelf
 	}
 	//检查该用户是否是您的好友
-	if err := mysql.IsFriend(userID, friendID); !errors.Is(err, mysql.ErrorIsFriend) {
-		return mysql.ErrorIsNotFriend
+	if err := IsFriend(userID, friendID); err != nil {
+		return err
 	}
-	return mysql.DeleteFriend(userID, friendID)
+	if err := mysql.DeleteFriend(userID, friendID); err != nil {
+		return err
+	}
+	invalidateFriendCache(userID, friendID)
+	return nil
 }
 
 // UpdateFriendRemark 更新好友备注
 func UpdateFriendRemark(userID int64, friendID int64, remark string) error {
 	//检查该用户是否是您的好友
-	if err := mysql.IsFriend(userID, friendID); !errors.Is(err, mysql.ErrorIsFriend) {
-		return mysql.ErrorIsNotFriend
+	if err := IsFriend(userID, friendID); err != nil {
+		return err
 	}
 	//更新备注
-	return mysql.UpdateFriendRemark(userID, friendID, remark)
+	if err := mysql.UpdateFriendRemark(userID, friendID, remark); err != nil {
+		return err
+	}
+	invalidateFriendCache(userID)
+	return nil
 }
 
-// IsFriend 判断是否为好友
+// IsFriend 判断是否为好友，非好友返回ErrorIsNotFriend
 func IsFriend(userID int64, friendID int64) error {
-	err := mysql.IsFriend(userID, friendID)
-	if !errors.Is(err, mysql.ErrorIsFriend) {
+	ok, err := isFriend(userID, friendID)
+	if err != nil {
+		return err
+	}
+	if !ok {
 		return mysql.ErrorIsNotFriend
 	}
 	return nil
 }
+
+// isFriend 判断是否为好友，优先读取Redis好友缓存
+func isFriend(userID, friendID int64) (bool, error) {
+	// 缓存中的占位字段不是合法的用户ID，非法ID直接视为非好友
+	if friendID <= 0 {
+		return false, nil
+	}
+	ctx := context.Background()
+	ok, cached, err := redis.IsFriendCached(ctx, userID, friendID)
+	if err != nil {
+		zap.L().Error("redis.IsFriendCached failed", zap.Error(err))
+	}
+	if cached {
+		return ok, nil
+	}
+	// 缓存未命中，加载好友缓存后再判断
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return false, err
+	}
+	_, ok = remarks[friendID]
+	return ok, nil
+}
+
+// getFriendRemarks 获取好友ID与备注的映射，优先读取Redis好友缓存，未命中时从MySQL加载并回填
+func getFriendRemarks(userID int64) (map[int64]string, error) {
+	ctx := context.Background()
+	remarks, cached, err := redis.GetFriendCache(ctx, userID)
+	if err != nil {
+		zap.L().Error("redis.GetFriendCache failed", zap.Error(err))
+	}
+	if cached {
+		return remarks, nil
+	}
+	if remarks, err = mysql.GetFriendRemarks(userID); err != nil {
+		return nil, err
+	}
+	if err = redis.SetFriendCache(ctx, userID, remarks); err != nil {
+		zap.L().Error("redis.SetFriendCache failed", zap.Error(err))
+	}
+	return remarks, nil
+}
+
+// GetFriendIDs 获取好友ID列表
+func GetFriendIDs(userID int64) ([]int64, error) {
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(remarks))
+	for id := range remarks {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// invalidateFriendCache 好友关系或备注变更后删除相关用户的好友缓存
+func invalidateFriendCache(userIDs ...int64) {
+	if err := redis.DelFriendCache(context.Background(), userIDs...); err != nil {
+		zap.L().Error("redis.DelFriendCache failed", zap.Error(err))
+	}
+}
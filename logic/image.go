@@ -0,0 +1,141 @@
+package logic
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/models"
+	"gosocial/pkg/imageproc"
+	"gosocial/pkg/snowflake"
+)
+
+const (
+	PostImageMediumSize = 1080 // 中图长边像素
+	PostImageThumbSize  = 300  // 缩略图长边像素
+	postImageDir        = "static/images/posts"
+)
+
+// SavePostImages 处理并保存动态图片(去除元数据、生成中图与缩略图)，返回未关联动态的图片记录
+// 任意一张图片处理失败时删除本次已写入的文件
+func SavePostImages(userID int64, files [][]byte) ([]models.PostImage, error) {
+	images, err := saveImages(postImageDir, files)
+	if err != nil {
+		return nil, err
+	}
+	for i := range images {
+		images[i].UserID = userID
+	}
+	return images, nil
+}
+
+// saveImages 处理图片并将原图、中图与缩略图按月保存到baseDir下，图片文件信息以PostImage返回
+// 任意一张图片处理失败时删除本次已写入的文件
+func saveImages(baseDir string, files [][]byte) (images []models.PostImage, err error) {
+	dir := filepath.Join(baseDir, time.Now().Format("200601"))
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	var written []string
+	defer func() {
+		if err != nil {
+			for _, p := range written {
+				_ = os.Remove(p)
+			}
+		}
+	}()
+	save := func(name string, v imageproc.Variant) (string, error) {
+		p := filepath.Join(dir, name+v.Ext)
+		if err := os.WriteFile(p, v.Data, 0644); err != nil {
+			return "", err
+		}
+		written = append(written, p)
+		return "/" + filepath.ToSlash(p), nil
+	}
+
+	for i, data := range files {
+		res, err := imageproc.Process(data, PostImageMediumSize, PostImageThumbSize)
+		if err != nil {
+			return nil, err
+		}
+		id, err := snowflake.GenID()
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("%d", id)
+		img := models.PostImage{
+			Width:  res.Original.Width,
+			Height: res.Original.Height,
+			Size:   int64(len(res.Original.Data)),
+			Format: res.Format,
+			Sort:   i,
+		}
+		if img.URL, err = save(name, res.Original); err != nil {
+			return nil, err
+		}
+		if img.MediumURL, err = save(name+"_m", res.Medium); err != nil {
+			return nil, err
+		}
+		if img.ThumbURL, err = save(name+"_t", res.Thumb); err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+// removePostImageFiles 删除动态图片的原图、中图与缩略图文件
+func removePostImageFiles(images []models.PostImage) {
+	for _, img := range images {
+		removeImageFiles(img.URL, img.MediumURL, img.ThumbURL)
+	}
+}
+
+// removeImageFiles 删除站内图片文件，文件不存在时忽略
+func removeImageFiles(urls ...string) {
+	for _, u := range urls {
+		if u == "" {
+			continue
+		}
+		if err := os.Remove(strings.TrimPrefix(u, "/")); err != nil && !os.IsNotExist(err) {
+			zap.L().Warn("删除图片文件失败", zap.String("path", u), zap.Error(err))
+		}
+	}
+}
+
+// getPostImageMap 批量获取动态图片，按动态ID分组
+func getPostImageMap(postIDs []int64) (map[int64][]models.PostImage, error) {
+	images, err := mysql.GetPostImages(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int64][]models.PostImage, len(postIDs))
+	for _, img := range images {
+		m[img.PostID] = append(m[img.PostID], img)
+	}
+	return m, nil
+}
+
+// fillPostImages 填充动态图片，未使用图片表的旧动态保留原有的图片URL
+func fillPostImages(item *models.ParamPostWithUserInfo, images []models.PostImage) {
+	if len(images) == 0 {
+		return
+	}
+	thumbs := make([]string, 0, len(images))
+	item.ImageList = make([]models.ParamPostImage, 0, len(images))
+	for _, img := range images {
+		thumbs = append(thumbs, img.ThumbURL)
+		item.ImageList = append(item.ImageList, models.ParamPostImage{
+			URL:       img.URL,
+			MediumURL: img.MediumURL,
+			ThumbURL:  img.ThumbURL,
+			Width:     img.Width,
+			Height:    img.Height,
+		})
+	}
+	item.Images = strings.Join(thumbs, ",")
+}
@@ -0,0 +1,59 @@
+package logic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
+	"time"
+)
+
+// 各类互动对亲密度的贡献
+const (
+	InteractWeightMessage      = 1.0 // 发送消息
+	InteractWeightPostView     = 0.5 // 浏览好友动态
+	InteractWeightProfileVisit = 0.3 // 访问好友主页
+)
+
+// interactFlushInterval 互动记录批量落库间隔
+const interactFlushInterval = 30 * time.Second
+
+// RecordInteraction 记录好友互动，由后台任务批量更新双方的最后互动时间与亲密度
+// 非好友之间的互动在落库时不会匹配到好友关系，因此无需在此额外查询
+func RecordInteraction(ctx context.Context, userID, friendID int64, weight float64) error {
+	if userID == friendID {
+		return nil
+	}
+	return redis.RecordInteraction(ctx, userID, friendID, time.Now(), weight)
+}
+
+// FlushInteractions 将Redis中累积的互动记录批量写入MySQL，写入失败的记录放回队列下次重试
+func FlushInteractions(ctx context.Context) error {
+	interactions, err := redis.PopInteractions(ctx)
+	if err != nil {
+		return err
+	}
+	var failed []redis.Interaction
+	for _, it := range interactions {
+		if err = mysql.UpdateInteraction(it.UserID, it.FriendID, it.At, it.Weight); err != nil {
+			// 单条失败不影响其它记录落库
+			zap.L().Error("mysql.UpdateInteraction failed",
+				zap.Int64("user_id", it.UserID),
+				zap.Int64("friend_id", it.FriendID),
+				zap.Error(err))
+			failed = append(failed, it)
+		}
+	}
+	return redis.RequeueInteractions(ctx, failed)
+}
+
+// StartInteractionFlusher 启动互动记录定时落库任务
+func StartInteractionFlusher() {
+	ticker := time.NewTicker(interactFlushInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := FlushInteractions(context.Background()); err != nil {
+			zap.L().Error("FlushInteractions failed", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package logic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
+	"gosocial/models"
+	"time"
+)
+
+// likeFlushInterval 点赞数回写MySQL的间隔
+const likeFlushInterval = 30 * time.Second
+
+// LikePost 点赞动态，重复点赞视为成功
+func LikePost(userID, postID int64) error {
+	post, err := getVisiblePost(userID, postID)
+	if err != nil {
+		return err
+	}
+	created, err := mysql.CreatePostLike(postID, userID)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+	if err = redis.IncrPostLikeCount(context.Background(), postID, 1); err != nil {
+		zap.L().Error("redis.IncrPostLikeCount failed", zap.Int64("post_id", postID), zap.Error(err))
+	}
+	Notify(post.UserID, userID, models.NotificationTypeLike, postID, "赞了你的动态")
+	return nil
+}
+
+// UnlikePost 取消点赞，未点赞时视为成功
+func UnlikePost(userID, postID int64) error {
+	if _, err := getVisiblePost(userID, postID); err != nil {
+		return err
+	}
+	deleted, err := mysql.DeletePostLike(postID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return nil
+	}
+	if err = redis.IncrPostLikeCount(context.Background(), postID, -1); err != nil {
+		zap.L().Error("redis.IncrPostLikeCount failed", zap.Int64("post_id", postID), zap.Error(err))
+	}
+	return nil
+}
+
+// GetPostLikers 获取动态的点赞用户，仅展示当前用户的好友(类似微信朋友圈)
+func GetPostLikers(userID, postID int64, offset int) ([]models.ParamLikerItem, error) {
+	if _, err := getVisiblePost(userID, postID); err != nil {
+		return nil, err
+	}
+	likes, err := mysql.GetFriendLikers(postID, userID, offset, 20)
+	if err != nil {
+		return nil, err
+	}
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamLikerItem, 0, len(likes))
+	for _, l := range likes {
+		name := l.User.Username
+		if remark := remarks[l.UserID]; remark != "" {
+			name = remark
+		}
+		result = append(result, models.ParamLikerItem{
+			UserID:      l.UserID,
+			DisplayName: name,
+			AvatarURL:   l.User.AvatarURL,
+			CreatedAt:   l.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// getLikeCounts 批量获取动态点赞数，优先读取Redis，未命中的从MySQL统计并回填
+func getLikeCounts(postIDs []int64) (map[int64]int64, error) {
+	ctx := context.Background()
+	counts, err := redis.GetPostLikeCounts(ctx, postIDs)
+	if err != nil {
+		zap.L().Error("redis.GetPostLikeCounts failed", zap.Error(err))
+		counts = make(map[int64]int64, len(postIDs))
+	}
+	var missing []int64
+	for _, id := range postIDs {
+		if _, ok := counts[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return counts, nil
+	}
+	loaded, err := mysql.CountPostLikes(missing)
+	if err != nil {
+		return nil, err
+	}
+	if err = redis.SetPostLikeCounts(ctx, loaded); err != nil {
+		zap.L().Error("redis.SetPostLikeCounts failed", zap.Error(err))
+	}
+	for id, count := range loaded {
+		counts[id] = count
+	}
+	return counts, nil
+}
+
+// FlushLikeCounts 将点赞数有变化的动态从MySQL重新统计后回写，并校正缓存中的点赞数
+// 以点赞记录为准重新统计，缓存加载与点赞并发导致的偏差会在回写时修正；回写失败的动态重新标记，下次重试
+func FlushLikeCounts(ctx context.Context) error {
+	for {
+		postIDs, err := redis.PopDirtyLikePosts(ctx, 100)
+		if err != nil || len(postIDs) == 0 {
+			return err
+		}
+		counts, err := mysql.CountPostLikes(postIDs)
+		if err != nil {
+			if err := redis.MarkDirtyLikePosts(ctx, postIDs); err != nil {
+				zap.L().Error("redis.MarkDirtyLikePosts failed", zap.Error(err))
+			}
+			return err
+		}
+		var failed []int64
+		for postID, count := range counts {
+			if err = mysql.UpdatePostLikeCount(postID, count); err != nil {
+				zap.L().Error("mysql.UpdatePostLikeCount failed", zap.Int64("post_id", postID), zap.Error(err))
+				failed = append(failed, postID)
+				delete(counts, postID)
+			}
+		}
+		if err = redis.RefreshPostLikeCounts(ctx, counts); err != nil {
+			zap.L().Error("redis.RefreshPostLikeCounts failed", zap.Error(err))
+		}
+		if len(failed) > 0 {
+			return redis.MarkDirtyLikePosts(ctx, failed)
+		}
+	}
+}
+
+// StartLikeCountFlusher 启动点赞数定时回写任务
+func StartLikeCountFlusher() {
+	ticker := time.NewTicker(likeFlushInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := FlushLikeCounts(context.Background()); err != nil {
+			zap.L().Error("FlushLikeCounts failed", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package logic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+const memoryMaxYears = 20 // 那年今日最多回溯的年数
+
+// memoryDay 往年的同一天，平年的2月28日对应闰年的2月28日与29日两天
+type memoryDay struct {
+	Year  int
+	Range mysql.DateRange
+}
+
+// GetMemories 那年今日：往年今天自己发布的动态(不含已删除的动态)与添加的好友，按年份倒序排列
+// 用户关闭那年今日时返回空结果
+func GetMemories(userID int64) (*models.ParamMemories, error) {
+	now := time.Now()
+	result := &models.ParamMemories{Date: now.Format("2006-01-02"), Years: make([]models.ParamMemoryYear, 0)}
+	setting, err := mysql.GetUserSetting(userID)
+	if err != nil {
+		return nil, err
+	}
+	if setting.DisableMemories {
+		return result, nil
+	}
+
+	days := memoryDays(now)
+	ranges := make([]mysql.DateRange, len(days))
+	for i, d := range days {
+		ranges[i] = d.Range
+	}
+	posts, err := mysql.GetUserPostsInRanges(userID, ranges)
+	if err != nil {
+		return nil, err
+	}
+	items, err := decoratePosts(userID, posts)
+	if err != nil {
+		return nil, err
+	}
+	friendships, err := mysql.GetFriendshipsInRanges(userID, ranges)
+	if err != nil {
+		return nil, err
+	}
+	friendIDs := make([]int64, len(friendships))
+	for i, f := range friendships {
+		friendIDs[i] = f.FriendID
+	}
+	profiles, err := getProfiles(friendIDs)
+	if err != nil {
+		return nil, err
+	}
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按年份归类，days已按年份倒序排列
+	for _, d := range days {
+		year := models.ParamMemoryYear{
+			Year:     d.Year,
+			YearsAgo: now.Year() - d.Year,
+			Posts:    make([]models.ParamPostWithUserInfo, 0),
+			Friends:  make([]models.ParamMemoryFriend, 0),
+		}
+		for i, p := range posts {
+			if d.contains(p.CreatedAt) {
+				year.Posts = append(year.Posts, items[i])
+			}
+		}
+		for _, f := range friendships {
+			if d.contains(f.CreatedAt) {
+				year.Friends = append(year.Friends, models.ParamMemoryFriend{
+					UserID:    f.FriendID,
+					Nickname:  displayName(f.FriendID, profiles, remarks),
+					Avatar:    profiles[f.FriendID].AvatarURL,
+					CreatedAt: f.CreatedAt,
+				})
+			}
+		}
+		if len(year.Posts)+len(year.Friends) > 0 {
+			result.Years = append(result.Years, year)
+		}
+	}
+	return result, nil
+}
+
+// NotifyMemories 给往年今天发布过动态或添加过好友的用户发送那年今日通知
+// 关闭那年今日或关闭通知的用户不通知
+func NotifyMemories(now time.Time) error {
+	days := memoryDays(now)
+	ranges := make([]mysql.DateRange, len(days))
+	for i, d := range days {
+		ranges[i] = d.Range
+	}
+	postCounts, err := mysql.CountPostsInRangesByUser(ranges)
+	if err != nil {
+		return err
+	}
+	friendCounts, err := mysql.CountFriendshipsInRangesByUser(ranges)
+	if err != nil {
+		return err
+	}
+	userIDs := make([]int64, 0, len(postCounts)+len(friendCounts))
+	for uid := range postCounts {
+		userIDs = append(userIDs, uid)
+	}
+	for uid := range friendCounts {
+		userIDs = append(userIDs, uid)
+	}
+	userIDs = uniqueIDs(userIDs)
+	settings, err := mysql.GetUserSettings(userIDs)
+	if err != nil {
+		return err
+	}
+	for _, uid := range userIDs {
+		if settings[uid].DisableMemories || !settings[uid].MemoryNotification {
+			continue
+		}
+		var parts []string
+		if n := postCounts[uid]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d条动态", n))
+		}
+		if n := friendCounts[uid]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d位好友", n))
+		}
+		Notify(uid, 0, models.NotificationTypeMemory, 0, "那年今日：你在往年的今天留下了"+strings.Join(parts, "和")+"，去看看吧")
+	}
+	return nil
+}
+
+// StartMemoryNotifier 启动那年今日通知任务，每天9点后发送一次
+func StartMemoryNotifier() {
+	runDailyJob("memory", NotifyMemories)
+}
+
+// memoryDays 计算往年的今天(本地时间)，按年份倒序排列
+// 2月29日的回忆只在闰年的当天出现，平年的2月28日同时展示往年2月29日的回忆
+func memoryDays(now time.Time) []memoryDay {
+	month, day := now.Month(), now.Day()
+	days := make([]memoryDay, 0, memoryMaxYears)
+	for year := now.Year() - 1; year >= now.Year()-memoryMaxYears; year-- {
+		d, ok := localDay(year, month, day, now.Location())
+		if !ok {
+			continue
+		}
+		// 同一年的2月28日与2月29日相邻，合并为一个范围，保证每年只有一组回忆
+		if month == time.February && day == 28 && !isLeapYear(now.Year()) && isLeapYear(year) {
+			d.Range.End = d.Range.End.AddDate(0, 0, 1)
+		}
+		days = append(days, d)
+	}
+	return days
+}
+
+// localDay 构造指定日期的全天范围，日期不存在(如平年的2月29日)时返回false
+func localDay(year int, month time.Month, day int, loc *time.Location) (memoryDay, bool) {
+	start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if start.Month() != month || start.Day() != day {
+		return memoryDay{}, false
+	}
+	return memoryDay{Year: year, Range: mysql.DateRange{Start: start, End: start.AddDate(0, 0, 1)}}, true
+}
+
+// contains 判断时间是否在该日期范围内
+func (d memoryDay) contains(t time.Time) bool {
+	return !t.Before(d.Range.Start) && t.Before(d.Range.End)
+}
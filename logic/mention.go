@@ -0,0 +1,252 @@
+package logic
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+const (
+	maxPostTopics     = 10 // 单条动态最多关联的话题数
+	maxPostMentions   = 20 // 单条动态最多提及的用户数
+	maxTopicNameLen   = 32 // 话题名称最大长度(字符)
+	minUsernameLen    = 2  // 与用户名的长度限制保持一致
+	maxUsernameLen    = 20
+	mentionTokenStart = "@["
+)
+
+var (
+	// topicRe 匹配 #话题#
+	topicRe = regexp.MustCompile(`#([^#\r\n]+)#`)
+	// mentionRe 匹配 @用户名，用户名之后的文字可能紧跟其后，解析时按最长前缀匹配用户
+	mentionRe = regexp.MustCompile(`@([\p{L}\p{N}_\-.]+)`)
+	// mentionTokenRe 匹配内容中保存的提及 @[用户ID]
+	mentionTokenRe = regexp.MustCompile(`@\[(\d+)\]`)
+)
+
+// postRefs 动态内容解析结果
+type postRefs struct {
+	Content    string   // 提及替换为 @[用户ID] 后的内容
+	Topics     []string // 话题名称
+	MentionIDs []int64  // 提及的用户ID
+}
+
+// parseTopics 解析内容中的 #话题#，去除首尾空白并去重
+func parseTopics(content string) []string {
+	var topics []string
+	seen := make(map[string]bool)
+	for _, m := range topicRe.FindAllStringSubmatch(content, -1) {
+		name := strings.ToLower(strings.TrimSpace(m[1]))
+		if name == "" || utf8.RuneCountInString(name) > maxTopicNameLen || seen[name] {
+			continue
+		}
+		seen[name] = true
+		topics = append(topics, name)
+		if len(topics) == maxPostTopics {
+			break
+		}
+	}
+	return topics
+}
+
+// parsePostContent 解析动态内容中的话题与@提及，提及的用户名解析为用户ID保存，
+// 用户之后改名提及仍然有效；无法唯一确定或与作者存在拉黑关系的用户名保留为普通文本
+func parsePostContent(authorID int64, content string) (*postRefs, error) {
+	refs := &postRefs{Content: content, Topics: parseTopics(content)}
+	matches := mentionRe.FindAllStringSubmatchIndex(content, maxPostMentions)
+	if len(matches) == 0 {
+		return refs, nil
+	}
+
+	// 用户名之后可能紧跟正文(如"@张三你好")，收集所有可能的前缀一次性查询
+	var names []string
+	for _, m := range matches {
+		names = append(names, namePrefixes(content[m[2]:m[3]])...)
+	}
+	users, err := mysql.GetUsersByUsernames(names)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return refs, nil
+	}
+	friends, err := getFriendRemarks(authorID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string][]int64)
+	for _, u := range users {
+		byName[u.Username] = append(byName[u.Username], u.UserID)
+	}
+
+	var b strings.Builder
+	last := 0
+	seen := make(map[int64]bool)
+	for _, m := range matches {
+		prefixes := namePrefixes(content[m[2]:m[3]])
+		for _, name := range prefixes {
+			uid, ok := pickMentionUser(authorID, byName[name], friends)
+			if !ok {
+				continue
+			}
+			b.WriteString(content[last:m[0]])
+			b.WriteString(fmt.Sprintf("@[%d]", uid))
+			last = m[2] + len(name)
+			if !seen[uid] {
+				seen[uid] = true
+				refs.MentionIDs = append(refs.MentionIDs, uid)
+			}
+			break
+		}
+	}
+	b.WriteString(content[last:])
+	refs.Content = b.String()
+
+	// 与作者存在拉黑关系的用户不能被提及
+	mentionIDs := refs.MentionIDs[:0]
+	for _, uid := range refs.MentionIDs {
+		if uid == authorID {
+			mentionIDs = append(mentionIDs, uid)
+			continue
+		}
+		blocked, err := mysql.IsBlockedBetween(authorID, uid)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			refs.Content = strings.ReplaceAll(refs.Content, fmt.Sprintf("@[%d]", uid), "@"+mentionName(users, uid))
+			continue
+		}
+		mentionIDs = append(mentionIDs, uid)
+	}
+	refs.MentionIDs = mentionIDs
+	return refs, nil
+}
+
+// namePrefixes 返回可能作为用户名的前缀，从长到短排列
+func namePrefixes(s string) []string {
+	runes := []rune(s)
+	if len(runes) > maxUsernameLen {
+		runes = runes[:maxUsernameLen]
+	}
+	var prefixes []string
+	for n := len(runes); n >= minUsernameLen; n-- {
+		prefixes = append(prefixes, string(runes[:n]))
+	}
+	return prefixes
+}
+
+// pickMentionUser 从同名用户中确定被提及的用户：唯一时直接使用，重名时优先作者的好友
+func pickMentionUser(authorID int64, candidates []int64, friends map[int64]string) (int64, bool) {
+	switch len(candidates) {
+	case 0:
+		return 0, false
+	case 1:
+		return candidates[0], true
+	}
+	var picked int64
+	for _, uid := range candidates {
+		if _, ok := friends[uid]; ok || uid == authorID {
+			if picked != 0 {
+				return 0, false
+			}
+			picked = uid
+		}
+	}
+	return picked, picked != 0
+}
+
+// mentionName 从用户列表中查找用户名
+func mentionName(users []models.User, uid int64) string {
+	for _, u := range users {
+		if u.UserID == uid {
+			return u.Username
+		}
+	}
+	return ""
+}
+
+// mentionedNames 批量获取动态提及过的用户的当前用户名，按动态ID分组
+func mentionedNames(postIDs []int64) (map[int64]map[int64]string, error) {
+	mentions, err := mysql.GetPostMentions(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	uids := make([]int64, 0, len(mentions))
+	for _, m := range mentions {
+		uids = append(uids, m.UserID)
+	}
+	profiles, err := getProfiles(uids)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]map[int64]string, len(postIDs))
+	for _, m := range mentions {
+		profile, ok := profiles[m.UserID]
+		if !ok {
+			continue
+		}
+		if result[m.PostID] == nil {
+			result[m.PostID] = make(map[int64]string)
+		}
+		result[m.PostID][m.UserID] = profile.Username
+	}
+	return result, nil
+}
+
+// renderMentions 将内容中的 @[用户ID] 替换为 @当前用户名，并返回内容中提及的用户
+// 只替换该动态确实提及过的用户，用户手动输入的同格式文本保持原样
+func renderMentions(content string, names map[int64]string) (string, []models.ParamMention) {
+	if !strings.Contains(content, mentionTokenStart) {
+		return content, nil
+	}
+	var mentions []models.ParamMention
+	seen := make(map[int64]bool)
+	rendered := mentionTokenRe.ReplaceAllStringFunc(content, func(token string) string {
+		uid, err := strconv.ParseInt(token[2:len(token)-1], 10, 64)
+		if err != nil {
+			return token
+		}
+		name, ok := names[uid]
+		if !ok {
+			return token
+		}
+		if !seen[uid] {
+			seen[uid] = true
+			mentions = append(mentions, models.ParamMention{UserID: uid, Username: name})
+		}
+		return "@" + name
+	})
+	return rendered, mentions
+}
+
+// notifyMentions 通知动态中新提及的用户，不通知存在拉黑关系或看不到该动态的用户
+func notifyMentions(post *models.Post, userIDs []int64) {
+	for _, uid := range userIDs {
+		if uid == post.UserID {
+			continue
+		}
+		blocked, err := mysql.IsBlockedBetween(post.UserID, uid)
+		if err != nil {
+			zap.L().Error("mysql.IsBlockedBetween failed", zap.Error(err))
+			continue
+		}
+		if blocked {
+			continue
+		}
+		visible, err := canViewPost(uid, post)
+		if err != nil {
+			zap.L().Error("canViewPost failed", zap.Int64("post_id", post.ID), zap.Error(err))
+			continue
+		}
+		if visible {
+			Notify(uid, post.UserID, models.NotificationTypeMention, post.ID, "在动态中提到了你")
+		}
+	}
+}
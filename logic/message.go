@@ -3,6 +3,7 @@ package logic
 import (
 	"context"
 	"fmt"
+	"go.uber.org/zap"
 	"gosocial/dao/mysql"
 	"gosocial/dao/redis"
 	"gosocial/models"
@@ -39,6 +40,11 @@ func (l *MessageLogic) SendTextMessage(ctx context.Context, from, to int64, cont
 		return err
 	}
 
+	// 记录好友互动
+	if err := RecordInteraction(ctx, from, to, InteractWeightMessage); err != nil {
+		zap.L().Error("RecordInteraction failed", zap.Error(err))
+	}
+
 	// 异步持久化到MySQL(7天后)
 	go func() {
 		time.Sleep(oneWeek)
@@ -69,6 +75,11 @@ func (l *MessageLogic) SendFileMessage(ctx context.Context, from, to int64, file
 		return err
 	}
 
+	// 记录好友互动
+	if err := RecordInteraction(ctx, from, to, InteractWeightMessage); err != nil {
+		zap.L().Error("RecordInteraction failed", zap.Error(err))
+	}
+
 	return nil
 }
 
@@ -111,6 +122,15 @@ func (l *MessageLogic) GetMessages(ctx context.Context, from, to int64, startTim
 		}
 	}
 
+	// 从资料缓存填充发送者的昵称与头像
+	profiles, err := getProfiles([]int64{from, to})
+	if err != nil {
+		return nil, err
+	}
+	for i := range redisMsgs {
+		redisMsgs[i].My = profiles[redisMsgs[i].From]
+	}
+
 	return redisMsgs, nil
 }
 
@@ -0,0 +1,434 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
+	"gosocial/models"
+)
+
+const (
+	moderationPageSize    = 20 // 审核工单与审核日志每页条数
+	moderationRecentPosts = 10 // 审核账号时展示的最近动态条数
+)
+
+// IsAdmin 判断用户是否为管理员
+func IsAdmin(userID int64) (bool, error) {
+	user, err := mysql.GetUserByUID(userID)
+	if err != nil {
+		return false, err
+	}
+	return user.Role == models.UserRoleAdmin, nil
+}
+
+// IsSuspended 判断用户是否处于封禁状态，优先读取Redis缓存
+// 缓存不存在或Redis不可用时以MySQL中的封禁截止时间为准，并重新写入缓存
+func IsSuspended(userID int64) bool {
+	ctx := context.Background()
+	suspended, cached, err := redis.GetSuspended(ctx, userID)
+	if err != nil {
+		zap.L().Error("redis.GetSuspended failed", zap.Int64("user_id", userID), zap.Error(err))
+	}
+	if cached {
+		return suspended
+	}
+	user, err := mysql.GetUserByUID(userID)
+	if err != nil {
+		zap.L().Error("mysql.GetUserByUID failed", zap.Int64("user_id", userID), zap.Error(err))
+		return false
+	}
+	var until time.Time
+	if user.SuspendedUntil != nil {
+		until = *user.SuspendedUntil
+	}
+	if err = redis.SetSuspended(ctx, userID, until); err != nil {
+		zap.L().Error("redis.SetSuspended failed", zap.Int64("user_id", userID), zap.Error(err))
+	}
+	return until.After(time.Now())
+}
+
+// GetModerationCases 审核队列，按状态筛选，status为空时返回全部工单
+func GetModerationCases(status string, offset int) ([]models.ParamModerationCase, error) {
+	cases, err := mysql.GetModerationCases(status, offset, moderationPageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]int64, len(cases))
+	for i, c := range cases {
+		userIDs[i] = c.TargetUserID
+	}
+	profiles, err := getProfiles(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamModerationCase, 0, len(cases))
+	for i := range cases {
+		result = append(result, toParamModerationCase(&cases[i], profiles))
+	}
+	return result, nil
+}
+
+// GetModerationCase 审核工单详情：举报记录、举报时的内容快照、内容当前状态与作者的处置历史
+func GetModerationCase(moderatorID, caseID int64) (*models.ParamModerationCaseDetail, error) {
+	c, err := mysql.GetModerationCaseByID(caseID)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := mysql.GetCaseReports(caseID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := []int64{c.TargetUserID}
+	for _, r := range reports {
+		userIDs = append(userIDs, r.ReporterID)
+	}
+	profiles, err := getProfiles(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.ParamModerationCaseDetail{
+		Case:    toParamModerationCase(c, profiles),
+		Reports: make([]models.ParamReportItem, 0, len(reports)),
+	}
+	for _, r := range reports {
+		detail.Reports = append(detail.Reports, models.ParamReportItem{
+			ReporterID:   r.ReporterID,
+			ReporterName: profiles[r.ReporterID].Username,
+			Reason:       r.Reason,
+			Detail:       r.Detail,
+			CreatedAt:    r.CreatedAt,
+		})
+	}
+	if len(reports) > 0 {
+		detail.Snapshot = parseSnapshot(reports[0].Snapshot)
+	}
+
+	// 被举报内容的当前状态
+	switch c.TargetType {
+	case models.ReportTargetPost:
+		post, err := mysql.GetPostByIDUnscoped(c.TargetID)
+		if err != nil && err != mysql.ErrorPostNotExist {
+			return nil, err
+		}
+		if post != nil {
+			items, err := decoratePosts(moderatorID, []models.Post{*post})
+			if err != nil {
+				return nil, err
+			}
+			detail.Post = &items[0]
+		}
+	case models.ReportTargetUser:
+		posts, err := mysql.GetRecentPostsByUserID(c.TargetUserID, moderationRecentPosts)
+		if err != nil {
+			return nil, err
+		}
+		if detail.RecentPosts, err = decoratePosts(moderatorID, posts); err != nil {
+			return nil, err
+		}
+	}
+
+	// 作者的账号信息与处置历史
+	author, err := mysql.GetUserByUID(c.TargetUserID)
+	if err != nil && err != mysql.ErrorUserNotExist {
+		return nil, err
+	}
+	if author != nil {
+		detail.Author = &models.ParamModerationUser{
+			UserID:         author.UserID,
+			Username:       author.Username,
+			AvatarURL:      author.AvatarURL,
+			Signature:      author.Signature,
+			CreatedAt:      author.CreatedAt,
+			SuspendedUntil: author.SuspendedUntil,
+		}
+	}
+	logs, err := mysql.GetModerationLogs(0, c.TargetUserID, 0, moderationPageSize)
+	if err != nil {
+		return nil, err
+	}
+	detail.History = toParamModerationLogs(logs)
+	return detail, nil
+}
+
+// ClaimModerationCase 认领工单，认领后其他管理员不能处理该工单
+func ClaimModerationCase(moderatorID, caseID int64) error {
+	c, err := mysql.GetModerationCaseByID(caseID)
+	if err != nil {
+		return err
+	}
+	if err = mysql.ClaimModerationCase(caseID, moderatorID); err != nil {
+		return err
+	}
+	return mysql.CreateModerationLog(newModerationLog(c, moderatorID, models.ModerationActionClaim, "", ""))
+}
+
+// DecideModerationCase 处理工单：按请求隐藏或删除内容、警告或封禁作者，并结案
+// 未认领的工单处理时自动认领；内容与账号均不处置时驳回举报。
+// 每项处置执行前先写入审核日志，处置中途失败时已执行的操作仍可追溯
+func DecideModerationCase(moderatorID, caseID int64, req *models.ParamModerationDecisionRequest) error {
+	c, err := mysql.GetModerationCaseByID(caseID)
+	if err != nil {
+		return err
+	}
+	if err = checkModerationDecision(c, req); err != nil {
+		return err
+	}
+	if err = mysql.ClaimModerationCase(caseID, moderatorID); err != nil {
+		return err
+	}
+	c.AssigneeID = moderatorID
+
+	// 保存处置前的内容，内容删除后仍可在审核日志中追溯
+	snapshot, err := currentSnapshot(c)
+	if err != nil {
+		return err
+	}
+	var actions []string
+	newLog := func(action, detail string) *models.ModerationLog {
+		if req.Note != "" {
+			detail = strings.TrimPrefix(detail+"；"+req.Note, "；")
+		}
+		return newModerationLog(c, moderatorID, action, detail, snapshot)
+	}
+	record := func(action, detail string) error {
+		actions = append(actions, action)
+		return mysql.CreateModerationLog(newLog(action, detail))
+	}
+
+	// 1. 处置内容
+	switch req.ContentAction {
+	case models.ModerationActionHide:
+		if err = record(models.ModerationActionHide, ""); err != nil {
+			return err
+		}
+		if err = mysql.HidePost(c.TargetID); err != nil {
+			return err
+		}
+		touchFeed(c.TargetUserID)
+		Notify(c.TargetUserID, 0, models.NotificationTypeModeration, c.TargetID, "你的动态因违反社区规范已被隐藏，仅自己可见")
+	case models.ModerationActionDelete:
+		if err = record(models.ModerationActionDelete, ""); err != nil {
+			return err
+		}
+		if err = deleteReportedContent(c); err != nil {
+			return err
+		}
+		touchFeed(c.TargetUserID)
+		Notify(c.TargetUserID, 0, models.NotificationTypeModeration, c.ID, "你发布的内容因违反社区规范已被删除")
+	}
+
+	// 2. 处置账号
+	now := time.Now()
+	switch req.UserAction {
+	case models.ModerationActionWarn:
+		if err = record(models.ModerationActionWarn, ""); err != nil {
+			return err
+		}
+		Notify(c.TargetUserID, 0, models.NotificationTypeModeration, c.ID, "你发布的内容违反社区规范，现予以警告，多次违规将被封禁")
+	case models.ModerationActionSuspend:
+		until := now.AddDate(0, 0, req.SuspendDays)
+		if err = record(models.ModerationActionSuspend, fmt.Sprintf("封禁%d天", req.SuspendDays)); err != nil {
+			return err
+		}
+		if err = mysql.SuspendUser(c.TargetUserID, until); err != nil {
+			return err
+		}
+		// 缓存中可能是未封禁状态，写入失败时已签发的Token在缓存过期前仍然有效，须重新处理
+		if err = redis.SetSuspended(context.Background(), c.TargetUserID, until); err != nil {
+			return err
+		}
+		Notify(c.TargetUserID, 0, models.NotificationTypeModeration, c.ID,
+			fmt.Sprintf("你的账号因违反社区规范被封禁至%s", until.Format("2006-01-02 15:04")))
+	}
+
+	// 3. 结案，驳回的日志与结案在同一事务中写入
+	c.Status = models.CaseStatusResolved
+	var logs []models.ModerationLog
+	if len(actions) == 0 {
+		c.Status = models.CaseStatusDismissed
+		logs = append(logs, *newLog(models.ModerationActionDismiss, ""))
+	}
+	c.Resolution = strings.Join(actions, ",")
+	c.Note = req.Note
+	c.ClosedAt = &now
+	return mysql.CloseModerationCase(c, logs)
+}
+
+// GetModerationLogs 审核日志，moderatorID或targetUserID不为0时按操作人或被处置用户筛选
+func GetModerationLogs(moderatorID, targetUserID int64, offset int) ([]models.ParamModerationLog, error) {
+	logs, err := mysql.GetModerationLogs(moderatorID, targetUserID, offset, moderationPageSize)
+	if err != nil {
+		return nil, err
+	}
+	return toParamModerationLogs(logs), nil
+}
+
+// checkModerationDecision 校验处置方式是否适用于举报对象：隐藏仅适用于动态，账号不能删除，封禁须填写天数
+func checkModerationDecision(c *models.ModerationCase, req *models.ParamModerationDecisionRequest) error {
+	switch req.ContentAction {
+	case "", "none":
+		req.ContentAction = ""
+	case models.ModerationActionHide:
+		if c.TargetType != models.ReportTargetPost {
+			return mysql.ErrorInvalidModeration
+		}
+	case models.ModerationActionDelete:
+		if c.TargetType == models.ReportTargetUser {
+			return mysql.ErrorInvalidModeration
+		}
+	default:
+		return mysql.ErrorInvalidModeration
+	}
+	switch req.UserAction {
+	case "", "none":
+		req.UserAction = ""
+	case models.ModerationActionWarn:
+	case models.ModerationActionSuspend:
+		if req.SuspendDays <= 0 {
+			return mysql.ErrorInvalidModeration
+		}
+	default:
+		return mysql.ErrorInvalidModeration
+	}
+	return nil
+}
+
+// deleteReportedContent 删除被举报的动态或私信，动态直接彻底删除，不进入作者的回收站
+func deleteReportedContent(c *models.ModerationCase) error {
+	if c.TargetType == models.ReportTargetPost {
+		return purgePosts([]int64{c.TargetID})
+	}
+	msg := &models.Message{From: c.TargetID, To: c.MessageTo, CreatedAt: time.Unix(0, c.MessageAt)}
+	reports, err := mysql.GetCaseReports(c.ID)
+	if err != nil {
+		return err
+	}
+	found := false
+	if len(reports) > 0 {
+		if snapshot := parseSnapshot(reports[0].Snapshot); snapshot != nil {
+			for _, m := range snapshot.Messages {
+				if m.Reported {
+					msg.Content = m.Content
+					found = true
+				}
+			}
+		}
+	}
+	// 快照中找不到被举报的私信时无法定位消息，不能当作已删除
+	if !found {
+		return mysql.ErrorMessageNotExist
+	}
+	ctx := context.Background()
+	if err = redis.DeleteChatMessage(ctx, msg); err != nil {
+		return err
+	}
+	return mysql.NewMessageDao().DeleteMessage(ctx, msg)
+}
+
+// currentSnapshot 获取被举报内容当前的快照，私信使用举报时保存的快照
+func currentSnapshot(c *models.ModerationCase) (string, error) {
+	var snapshot *models.ReportSnapshot
+	var err error
+	switch c.TargetType {
+	case models.ReportTargetPost:
+		post, err := mysql.GetPostByIDUnscoped(c.TargetID)
+		if err == mysql.ErrorPostNotExist {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if snapshot, err = postSnapshot(post); err != nil {
+			return "", err
+		}
+	case models.ReportTargetUser:
+		snapshot, err = userSnapshot(c.TargetUserID)
+		if err == mysql.ErrorUserNotExist {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+	default:
+		reports, err := mysql.GetCaseReports(c.ID)
+		if err != nil || len(reports) == 0 {
+			return "", err
+		}
+		return reports[0].Snapshot, nil
+	}
+	data, err := json.Marshal(snapshot)
+	return string(data), err
+}
+
+// newModerationLog 生成审核日志
+func newModerationLog(c *models.ModerationCase, moderatorID int64, action, detail, snapshot string) *models.ModerationLog {
+	return &models.ModerationLog{
+		CaseID:       c.ID,
+		ModeratorID:  moderatorID,
+		Action:       action,
+		TargetType:   c.TargetType,
+		TargetID:     c.TargetID,
+		TargetUserID: c.TargetUserID,
+		Detail:       detail,
+		Snapshot:     snapshot,
+	}
+}
+
+// parseSnapshot 解析JSON格式的内容快照，解析失败返回nil
+func parseSnapshot(data string) *models.ReportSnapshot {
+	if data == "" {
+		return nil
+	}
+	var snapshot models.ReportSnapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		zap.L().Error("unmarshal report snapshot failed", zap.Error(err))
+		return nil
+	}
+	return &snapshot
+}
+
+// toParamModerationCase 封装审核工单
+func toParamModerationCase(c *models.ModerationCase, profiles map[int64]models.UserProfile) models.ParamModerationCase {
+	return models.ParamModerationCase{
+		ID:             c.ID,
+		TargetType:     c.TargetType,
+		TargetID:       c.TargetID,
+		TargetUserID:   c.TargetUserID,
+		TargetUsername: profiles[c.TargetUserID].Username,
+		Status:         c.Status,
+		ReportCount:    c.ReportCount,
+		AssigneeID:     c.AssigneeID,
+		Resolution:     c.Resolution,
+		Note:           c.Note,
+		LastReportedAt: c.LastReportedAt,
+		ClosedAt:       c.ClosedAt,
+		CreatedAt:      c.CreatedAt,
+	}
+}
+
+// toParamModerationLogs 封装审核日志
+func toParamModerationLogs(logs []models.ModerationLog) []models.ParamModerationLog {
+	result := make([]models.ParamModerationLog, 0, len(logs))
+	for _, l := range logs {
+		result = append(result, models.ParamModerationLog{
+			ID:           l.ID,
+			CaseID:       l.CaseID,
+			ModeratorID:  l.ModeratorID,
+			Action:       l.Action,
+			TargetType:   l.TargetType,
+			TargetID:     l.TargetID,
+			TargetUserID: l.TargetUserID,
+			Detail:       l.Detail,
+			Snapshot:     parseSnapshot(l.Snapshot),
+			CreatedAt:    l.CreatedAt,
+		})
+	}
+	return result
+}
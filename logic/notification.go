@@ -0,0 +1,70 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
+	"gosocial/models"
+)
+
+// Notify 给用户发送站内通知并实时推送，自己触发的操作不通知自己
+// 通知属于附加功能，失败只记录日志，不影响主流程
+func Notify(userID, actorID int64, typ string, targetID int64, content string) {
+	if userID == actorID {
+		return
+	}
+	n := &models.Notification{
+		UserID:   userID,
+		ActorID:  actorID,
+		Type:     typ,
+		TargetID: targetID,
+		Content:  content,
+	}
+	if err := mysql.CreateNotification(n); err != nil {
+		zap.L().Error("mysql.CreateNotification failed", zap.Error(err))
+		return
+	}
+	payload, err := json.Marshal(n)
+	if err != nil {
+		zap.L().Error("marshal notification failed", zap.Error(err))
+		return
+	}
+	if err = redis.PublishNotification(context.Background(), userID, payload); err != nil {
+		zap.L().Error("redis.PublishNotification failed", zap.Error(err))
+	}
+}
+
+// GetNotifications 获取通知列表
+func GetNotifications(userID int64, offset int) ([]models.ParamNotificationItem, error) {
+	notifications, err := mysql.GetNotifications(userID, offset, 20)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamNotificationItem, 0, len(notifications))
+	for _, n := range notifications {
+		result = append(result, models.ParamNotificationItem{
+			ID:          n.ID,
+			Type:        n.Type,
+			ActorID:     n.ActorID,
+			ActorName:   n.Actor.Username,
+			ActorAvatar: n.Actor.AvatarURL,
+			TargetID:    n.TargetID,
+			Content:     n.Content,
+			IsRead:      n.IsRead,
+			CreatedAt:   n.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// CountUnreadNotifications 获取未读通知数
+func CountUnreadNotifications(userID int64) (int64, error) {
+	return mysql.CountUnreadNotifications(userID)
+}
+
+// MarkNotificationsRead 将全部通知标记为已读
+func MarkNotificationsRead(userID int64) error {
+	return mysql.MarkNotificationsRead(userID)
+}
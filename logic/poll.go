@@ -0,0 +1,193 @@
+package logic
+
+import (
+	"strings"
+	"time"
+
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+const (
+	pollMinOptions      = 2  // 投票最少选项数
+	pollMaxOptions      = 10 // 投票最多选项数
+	pollOptionMaxLength = 50 // 选项内容最大字数
+	pollVoterPageSize   = 20 // 投票人列表每页条数
+)
+
+// newPoll 校验发布动态时附带的投票，选项去除首尾空白后不能为空或重复，截止时间须在将来
+func newPoll(req *models.ParamPollRequest, now time.Time) (*models.Poll, error) {
+	if len(req.Options) < pollMinOptions || len(req.Options) > pollMaxOptions {
+		return nil, mysql.ErrorInvalidPoll
+	}
+	if req.Deadline != nil && !req.Deadline.After(now) {
+		return nil, mysql.ErrorInvalidPoll
+	}
+	poll := &models.Poll{
+		Multiple:  req.Multiple,
+		Anonymous: req.Anonymous,
+		Deadline:  req.Deadline,
+		Options:   make([]models.PollOption, 0, len(req.Options)),
+	}
+	seen := make(map[string]bool, len(req.Options))
+	for i, content := range req.Options {
+		content = strings.TrimSpace(content)
+		if content == "" || len([]rune(content)) > pollOptionMaxLength || seen[content] {
+			return nil, mysql.ErrorInvalidPoll
+		}
+		seen[content] = true
+		poll.Options = append(poll.Options, models.PollOption{Content: content, Sort: i})
+	}
+	return poll, nil
+}
+
+// getPolls 批量获取动态附带的投票，并标记当前用户的投票情况
+func getPolls(viewerID int64, postIDs []int64) (map[int64]*models.ParamPoll, error) {
+	polls, err := mysql.GetPollsByPostIDs(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]*models.ParamPoll, len(polls))
+	if len(polls) == 0 {
+		return result, nil
+	}
+	pollIDs := make([]int64, len(polls))
+	for i, p := range polls {
+		pollIDs[i] = p.ID
+	}
+	votes, err := mysql.GetUserPollVotes(viewerID, pollIDs)
+	if err != nil {
+		return nil, err
+	}
+	voted := make(map[int64]bool, len(votes))
+	for _, v := range votes {
+		voted[v.OptionID] = true
+	}
+	now := time.Now()
+	for i := range polls {
+		result[polls[i].PostID] = toParamPoll(&polls[i], voted, now)
+	}
+	return result, nil
+}
+
+// toParamPoll 封装投票信息，查看者未投票且投票未截止时隐藏票数
+func toParamPoll(poll *models.Poll, voted map[int64]bool, now time.Time) *models.ParamPoll {
+	item := &models.ParamPoll{
+		ID:        poll.ID,
+		Multiple:  poll.Multiple,
+		Anonymous: poll.Anonymous,
+		Deadline:  poll.Deadline,
+		Closed:    poll.Closed(now),
+		Options:   make([]models.ParamPollOption, len(poll.Options)),
+	}
+	for i, o := range poll.Options {
+		item.Options[i] = models.ParamPollOption{ID: o.ID, Content: o.Content, Voted: voted[o.ID]}
+		item.Voted = item.Voted || voted[o.ID]
+	}
+	item.ResultVisible = item.Voted || item.Closed
+	if item.ResultVisible {
+		item.VoterCount = poll.VoterCount
+		for i, o := range poll.Options {
+			item.Options[i].VoteCount = o.VoteCount
+		}
+	}
+	return item
+}
+
+// VotePoll 对自己可见的动态中的投票进行投票，每人只能投一次，投票后返回最新结果
+func VotePoll(userID, postID int64, optionIDs []int64) (*models.ParamPoll, error) {
+	if _, err := getVisiblePost(userID, postID); err != nil {
+		return nil, err
+	}
+	poll, err := mysql.GetPollByPostID(postID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if poll.Closed(now) {
+		return nil, mysql.ErrorPollClosed
+	}
+	// 所选选项必须属于该投票，单选投票只能选择一项
+	optionIDs = uniqueIDs(optionIDs)
+	if len(optionIDs) == 0 || (!poll.Multiple && len(optionIDs) > 1) {
+		return nil, mysql.ErrorInvalidPollOption
+	}
+	for _, id := range optionIDs {
+		if !hasPollOption(poll, id) {
+			return nil, mysql.ErrorInvalidPollOption
+		}
+	}
+	if err = mysql.CreatePollVote(poll.ID, userID, optionIDs, now); err != nil {
+		return nil, err
+	}
+
+	polls, err := getPolls(userID, []int64{postID})
+	if err != nil {
+		return nil, err
+	}
+	return polls[postID], nil
+}
+
+// GetPollVoters 获取实名投票的投票人，optionID不为0时只返回选择了该选项的用户
+// 与查看结果相同，需已投票或投票已截止
+func GetPollVoters(userID, postID, optionID int64, offset int) ([]models.ParamPollVoter, error) {
+	if _, err := getVisiblePost(userID, postID); err != nil {
+		return nil, err
+	}
+	poll, err := mysql.GetPollByPostID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.Anonymous {
+		return nil, mysql.ErrorPollAnonymous
+	}
+	if optionID != 0 && !hasPollOption(poll, optionID) {
+		return nil, mysql.ErrorInvalidPollOption
+	}
+	if !poll.Closed(time.Now()) {
+		votes, err := mysql.GetUserPollVotes(userID, []int64{poll.ID})
+		if err != nil {
+			return nil, err
+		}
+		if len(votes) == 0 {
+			return nil, mysql.ErrorPollResultHidden
+		}
+	}
+
+	voters, err := mysql.GetPollVoters(poll.ID, optionID, offset, pollVoterPageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]int64, len(voters))
+	for i, v := range voters {
+		userIDs[i] = v.UserID
+	}
+	profiles, err := getProfiles(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamPollVoter, 0, len(voters))
+	for _, v := range voters {
+		result = append(result, models.ParamPollVoter{
+			UserID:   v.UserID,
+			Nickname: displayName(v.UserID, profiles, remarks),
+			Avatar:   profiles[v.UserID].AvatarURL,
+			VotedAt:  v.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// hasPollOption 判断选项是否属于该投票
+func hasPollOption(poll *models.Poll, optionID int64) bool {
+	for _, o := range poll.Options {
+		if o.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
@@ -1,134 +1,448 @@
 package logic
 
 import (
+	"context"
 	"errors"
+	"go.uber.org/zap"
 	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
 	"gosocial/models"
+	"strconv"
 	"time"
 )
 
-// GetFriendPosts 获取好友动态列表
-func GetFriendPosts(userID int64, offset int) ([]models.ParamPostWithUserInfo, error) {
-	// 1. 获取好友列表
-	friends, err := GetFriendInfo(userID)
+// GetUserPosts 获取指定用户动态列表
+func GetUserPosts(currentUserID, targetUserID int64, offset int) ([]models.ParamPostWithUserInfo, error) {
+	//1.检查是否该用户为自己,若是，则直接获取动态
+	if currentUserID == targetUserID {
+		posts, err := mysql.GetPostsByUserID(targetUserID, offset, 10)
+		if err != nil {
+			return nil, err
+		}
+		//  补充用户信息
+		return decoratePosts(currentUserID, posts)
+	}
+
+	// 2. 检查是否是好友关系，好友按动态可见范围过滤，非好友只能查看公开动态
+	var posts []models.Post
+	var err error
+	err = IsFriend(currentUserID, targetUserID)
+	switch {
+	case err == nil:
+		posts, err = mysql.GetFriendVisiblePostsByUserID(currentUserID, targetUserID, offset, 10)
+	case errors.Is(err, mysql.ErrorIsNotFriend):
+		// 存在拉黑关系时公开动态也不可见
+		var blocked bool
+		if blocked, err = mysql.IsBlockedBetween(currentUserID, targetUserID); err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, mysql.ErrorBlocked
+		}
+		posts, err = mysql.GetPublicPostsByUserID(targetUserID, offset, 10)
+	}
 	if err != nil {
 		return nil, err
 	}
-	// 2. 获取好友ID列表
-	friendIDs := make([]int64, len(friends))
-	for i, friend := range friends {
-		friendIDs[i] = friend.FriendID
+	//  补充用户信息
+	return decoratePosts(currentUserID, posts)
+}
+
+// decoratePosts 将动态转换为前端需要的格式，并批量补充作者资料、点赞数、评论数等互动数据
+func decoratePosts(viewerID int64, posts []models.Post) ([]models.ParamPostWithUserInfo, error) {
+	result := make([]models.ParamPostWithUserInfo, 0, len(posts))
+	if len(posts) == 0 {
+		return result, nil
+	}
+	postIDs := make([]int64, len(posts))
+	authorIDs := make([]int64, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+		authorIDs[i] = post.UserID
+	}
+	// 作者昵称与头像从资料缓存批量获取，好友备注优先
+	profiles, err := getProfiles(authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	remarks, err := getFriendRemarks(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	// 批量获取点赞数与当前用户的点赞、收藏状态
+	likeCounts, err := getLikeCounts(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	likedIDs, err := mysql.GetLikedPostIDs(viewerID, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	liked := make(map[int64]bool, len(likedIDs))
+	for _, id := range likedIDs {
+		liked[id] = true
+	}
+	bookmarkedIDs, err := mysql.GetBookmarkedPostIDs(viewerID, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	bookmarked := make(map[int64]bool, len(bookmarkedIDs))
+	for _, id := range bookmarkedIDs {
+		bookmarked[id] = true
+	}
+	// 批量获取尚未回写的浏览量
+	pendingViews, err := redis.GetPendingPostViews(context.Background(), postIDs)
+	if err != nil {
+		zap.L().Error("redis.GetPendingPostViews failed", zap.Error(err))
+	}
+	// 批量获取当前用户可见的评论数
+	commentCounts, err := mysql.CountVisibleComments(postIDs, viewerID)
+	if err != nil {
+		return nil, err
 	}
-	postIDs := append(friendIDs, userID)
-	// 3. 获取好友和自己的动态并附上备注
-	posts, err := mysql.GetPostsByUserIDs(postIDs, offset, 10)
 
+	// 作者本人可以看到自己动态的自定义可见名单
+	audiences, err := getOwnPostAudiences(viewerID, posts)
 	if err != nil {
 		return nil, err
 	}
-	for k, _ := range posts {
-		posts[k].Username, err = GetRemarkByPost(userID, posts[k])
-		if err != nil {
-			return nil, err
-		}
+	images, err := getPostImageMap(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	names, err := mentionedNames(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	repostCounts, err := mysql.CountReposts(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	origins, err := getRepostOrigins(viewerID, posts)
+	if err != nil {
+		return nil, err
+	}
+	polls, err := getPolls(viewerID, postIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	// 4. 补充动态的简略个人信息
-	var result []models.ParamPostWithUserInfo
 	for _, post := range posts {
-		result = append(result, ToParamPostWithUserInfo(post))
+		item := ToParamPostWithUserInfo(post)
+		item.Avatar = profiles[post.UserID].AvatarURL
+		item.Nickname = displayName(post.UserID, profiles, remarks)
+		item.LikeCount = likeCounts[post.ID]
+		item.Liked = liked[post.ID]
+		item.Bookmarked = bookmarked[post.ID]
+		item.CommentCount = commentCounts[post.ID]
+		item.ViewCount += uint64(pendingViews[post.ID])
+		item.Audience = audiences[post.ID]
+		fillPostImages(&item, images[post.ID])
+		item.Content, item.Mentions = renderMentions(item.Content, names[post.ID])
+		item.RepostCount = repostCounts[post.ID]
+		item.Poll = polls[post.ID]
+		if post.RepostOfID != 0 {
+			item.RepostOfID = post.RepostOfID
+			item.RepostOf = origins[post.RepostOfID]
+		}
+		result = append(result, item)
 	}
 	return result, nil
 }
 
-// GetUserPosts 获取指定用户动态列表
-func GetUserPosts(currentUserID, targetUserID int64, offset int) ([]models.ParamPostWithUserInfo, error) {
-	var result []models.ParamPostWithUserInfo
-	//1.检查是否该用户为自己,若是，则直接获取动态
-	if currentUserID == targetUserID {
-		posts, err := mysql.GetPostsByUserID(targetUserID, offset, 10)
-		if err != nil {
-			return nil, err
+// getOwnPostAudiences 批量获取查看者自己发布的部分可见/不给谁看动态的可见名单
+func getOwnPostAudiences(viewerID int64, posts []models.Post) (map[int64]*models.ParamPostAudience, error) {
+	result := make(map[int64]*models.ParamPostAudience)
+	postIDs := make([]int64, 0)
+	for _, post := range posts {
+		if post.UserID == viewerID && hasAudience(post.Visibility) {
+			postIDs = append(postIDs, post.ID)
+			result[post.ID] = &models.ParamPostAudience{UserIDs: []string{}, GroupIDs: []string{}}
 		}
-		//  补充用户信息
-		for _, post := range posts {
-			result = append(result, ToParamPostWithUserInfo(post))
+	}
+	audiences, err := mysql.GetPostAudiences(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range audiences {
+		item := result[a.PostID]
+		id := strconv.FormatInt(a.TargetID, 10)
+		if a.TargetType == models.AudienceTargetGroup {
+			item.GroupIDs = append(item.GroupIDs, id)
+		} else {
+			item.UserIDs = append(item.UserIDs, id)
 		}
-		return result, nil
 	}
+	return result, nil
+}
+
+// hasAudience 可见范围是否需要自定义可见名单
+func hasAudience(visibility string) bool {
+	return visibility == models.PostVisibilityAllow || visibility == models.PostVisibilityDeny
+}
+
+// buildPostAudiences 校验并生成动态的自定义可见名单
+// 部分可见/不给谁看时名单不能为空，好友须为作者的好友，分组须为作者自己的分组；其他可见范围忽略名单
+func buildPostAudiences(userID int64, visibility string, userIDs, groupIDs []int64) ([]models.PostAudience, error) {
+	if !hasAudience(visibility) {
+		return nil, nil
+	}
+	userIDs, err := checkFriendIDs(userID, userIDs)
+	if err != nil {
+		return nil, mysql.ErrorInvalidAudience
+	}
+	groupIDs = uniqueIDs(groupIDs)
+	if len(userIDs)+len(groupIDs) == 0 {
+		return nil, mysql.ErrorInvalidAudience
+	}
+	count, err := mysql.CountFriendGroupsIn(userID, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	if count != int64(len(groupIDs)) {
+		return nil, mysql.ErrorInvalidAudience
+	}
+	audiences := make([]models.PostAudience, 0, len(userIDs)+len(groupIDs))
+	for _, id := range userIDs {
+		audiences = append(audiences, models.PostAudience{TargetType: models.AudienceTargetUser, TargetID: id})
+	}
+	for _, id := range groupIDs {
+		audiences = append(audiences, models.PostAudience{TargetType: models.AudienceTargetGroup, TargetID: id})
+	}
+	return audiences, nil
+}
 
-	// 2. 检查是否是好友关系，非好友返回错误
-	if err := mysql.IsFriend(currentUserID, targetUserID); !errors.Is(err, mysql.ErrorIsFriend) {
-		return nil, mysql.ErrorIsNotFriend
+// UpdatePostVisibility 修改动态的可见范围，仅作者本人可操作
+func UpdatePostVisibility(userID, postID int64, visibility string, userIDs, groupIDs []int64) error {
+	post, err := mysql.GetPostByID(postID)
+	if err != nil {
+		return err
+	}
+	if post.UserID != userID {
+		return mysql.ErrorCannotEditOthersPost
 	}
+	// 被管理员隐藏的动态不能自行恢复可见
+	if post.Visibility == models.PostVisibilityHidden {
+		return mysql.ErrorPostHidden
+	}
+	if err = checkRepostVisibility(post.RepostOfID, visibility); err != nil {
+		return err
+	}
+	audiences, err := buildPostAudiences(userID, visibility, userIDs, groupIDs)
+	if err != nil {
+		return err
+	}
+	if err = mysql.UpdatePostVisibility(postID, visibility, audiences); err != nil {
+		return err
+	}
+	touchFeed(userID)
+	// 改为公开后需要写入粉丝的时间线
+	if visibility == models.PostVisibilityPublic && post.Visibility != models.PostVisibilityPublic {
+		post.Visibility = visibility
+		go fanoutPost(post)
+	}
+	return nil
+}
 
-	// 3. 获取用户动态
-	posts, err := mysql.GetPostsByUserID(targetUserID, offset, 10)
+// getVisiblePost 获取当前用户可见的动态，不可见时返回ErrorPostNotExist以免泄露动态是否存在
+func getVisiblePost(viewerID, postID int64) (*models.Post, error) {
+	post, err := mysql.GetPostByID(postID)
 	if err != nil {
 		return nil, err
 	}
-	for k, _ := range posts {
-		posts[k].Username, err = GetRemarkByPost(currentUserID, posts[k])
-		if err != nil {
-			return nil, err
+	ok, err := canViewPost(viewerID, post)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, mysql.ErrorPostNotExist
+	}
+	return post, nil
+}
+
+// canViewPost 判断用户是否可以查看动态：作者本人、可见范围内的作者好友，或未被拉黑用户查看公开动态
+func canViewPost(viewerID int64, post *models.Post) (bool, error) {
+	if viewerID == post.UserID {
+		return true, nil
+	}
+	err := IsFriend(viewerID, post.UserID)
+	if err == nil {
+		switch post.Visibility {
+		case models.PostVisibilityPublic, models.PostVisibilityFriends:
+			return true, nil
+		case models.PostVisibilityPrivate:
+			return false, nil
 		}
+		return mysql.IsPostVisibleToFriend(post.ID, viewerID)
 	}
-	//  补充用户信息
-	for _, post := range posts {
-		result = append(result, ToParamPostWithUserInfo(post))
+	if !errors.Is(err, mysql.ErrorIsNotFriend) {
+		return false, err
 	}
-	return result, nil
+	if post.Visibility != models.PostVisibilityPublic {
+		return false, nil
+	}
+	blocked, err := mysql.IsBlockedBetween(viewerID, post.UserID)
+	if err != nil {
+		return false, err
+	}
+	return !blocked, nil
 }
 
 // ToParamPostWithUserInfo 封装动态信息与用户信息
 func ToParamPostWithUserInfo(post models.Post) models.ParamPostWithUserInfo {
 	return models.ParamPostWithUserInfo{
-		ViewCount: post.ViewCount,
-		Avatar:    post.AvatarURL,
-		Nickname:  post.Username,
-		Content:   post.Content,
-		Images:    post.Images,
-		CreatedAt: post.CreatedAt,
+		ID:         post.ID,
+		UserID:     post.UserID,
+		ViewCount:  post.ViewCount,
+		Content:    post.Content,
+		Images:     post.Images,
+		Visibility: post.Visibility,
+		CreatedAt:  post.CreatedAt,
+		EditedAt:   post.EditedAt,
 	}
 }
 
-// GetRemarkByPost 获取好友备注
-func GetRemarkByPost(userID int64, post models.Post) (string, error) {
-	return mysql.GetRemarkByPost(userID, post)
-}
-
-// IncrementPostViewCount 增加动态浏览量
-func IncrementPostViewCount(postID int64) error {
-	return mysql.IncrementPostViewCount(postID)
+// CreatePost 创建用户动态，visibility为allow/deny时userIDs与groupIDs为自定义可见名单，poll不为空时附带投票
+func CreatePost(userID int64, content string, images []models.PostImage, visibility string, userIDs, groupIDs []int64, poll *models.ParamPollRequest) (*models.Post, error) {
+	post := &models.Post{
+		UserID:     userID,
+		Content:    content,
+		ImageList:  images,
+		Visibility: visibility,
+	}
+	var err error
+	if poll != nil {
+		post.Poll, err = newPoll(poll, time.Now())
+	}
+	if err == nil {
+		err = publishPost(post, userIDs, groupIDs)
+	}
+	if err != nil {
+		// 只删除本次新上传的图片文件，草稿中已保存的图片保留
+		var unsaved []models.PostImage
+		for _, img := range images {
+			if img.ID == 0 {
+				unsaved = append(unsaved, img)
+			}
+		}
+		removePostImageFiles(unsaved)
+		return nil, err
+	}
+	return post, nil
 }
 
-// CreatePost 创建用户动态
-func CreatePost(userID int64, content, images string) (*models.Post, error) {
+// publishPost 保存动态(原创或转发)并写入时间线，post中需已填写作者、内容、图片与可见范围
+func publishPost(post *models.Post, userIDs, groupIDs []int64) error {
 	// 1. 验证用户存在性
-	user, err := mysql.GetUserByUID(userID)
+	if err := mysql.IsUserExist(post.UserID); err != nil {
+		return err
+	}
+	audiences, err := buildPostAudiences(post.UserID, post.Visibility, userIDs, groupIDs)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	// 2. 创建动态
-	post := models.Post{
-		UserID:    userID,
-		Username:  user.Username,
-		AvatarURL: user.AvatarURL,
-		Content:   content,
-		Images:    images,
-		ViewCount: 0,
-		CreatedAt: time.Now(),
+	refs, err := parsePostContent(post.UserID, post.Content)
+	if err != nil {
+		return err
 	}
 
+	// 2. 补全动态信息
+	post.Content = refs.Content
+	// 发布时间精确到毫秒，与时间线的score及分页游标保持一致
+	post.CreatedAt = time.Now().Truncate(time.Millisecond)
+
 	// 3. 保存到数据库
-	if err = mysql.CreatePost(&post); err != nil {
-		return nil, err
+	if err = mysql.CreatePost(post, audiences, refs.Topics, refs.MentionIDs); err != nil {
+		return err
+	}
+	touchFeed(post.UserID)
+
+	// 4. 写入好友与粉丝的时间线，并通知提及的用户
+	go fanoutPost(post)
+	go notifyMentions(post, refs.MentionIDs)
+	return nil
+}
+
+// DeletePost 删除自己的动态，动态进入回收站，30天内可恢复
+func DeletePost(userID, postID int64) error {
+	post, err := mysql.GetPostByID(postID)
+	if err != nil {
+		return err
+	}
+	if post.UserID != userID {
+		return mysql.ErrorCannotDeleteOthersPost
 	}
+	if err = mysql.DeletePost(postID); err != nil {
+		return err
+	}
+	touchFeed(userID)
+	return nil
+}
 
-	return &post, nil
+// EditPost 编辑自己动态的文字内容，编辑前的内容保存到编辑历史
+func EditPost(userID, postID int64, content string) error {
+	post, err := mysql.GetPostByID(postID)
+	if err != nil {
+		return err
+	}
+	if post.UserID != userID {
+		return mysql.ErrorCannotEditOthersPost
+	}
+	refs, err := parsePostContent(userID, content)
+	if err != nil {
+		return err
+	}
+	// 内容未变化时无需记录历史
+	if post.Content == refs.Content {
+		return nil
+	}
+	// 纯文本动态不能编辑为空，转发的评语可以为空
+	if content == "" && post.RepostOfID == 0 {
+		images, err := mysql.GetPostImages([]int64{postID})
+		if err != nil {
+			return err
+		}
+		if post.Images == "" && len(images) == 0 {
+			return mysql.ErrorInvalidParam
+		}
+	}
+	// 只通知本次编辑新提及的用户
+	names, err := mentionedNames([]int64{postID})
+	if err != nil {
+		return err
+	}
+	var added []int64
+	for _, uid := range refs.MentionIDs {
+		if _, ok := names[postID][uid]; !ok {
+			added = append(added, uid)
+		}
+	}
+	if err = mysql.UpdatePostContent(post, refs.Content, time.Now(), refs.Topics, refs.MentionIDs); err != nil {
+		return err
+	}
+	touchFeed(userID)
+	post.Content = refs.Content
+	go notifyMentions(post, added)
+	return nil
 }
 
-func DeletePostByUser(userID int64) error {
-	return mysql.DeletePostByUser(userID)
+// GetPostEditHistory 获取动态的编辑历史，可查看动态的用户均可查看
+func GetPostEditHistory(userID, postID int64) ([]models.ParamPostEditItem, error) {
+	if _, err := getVisiblePost(userID, postID); err != nil {
+		return nil, err
+	}
+	edits, err := mysql.GetPostEdits(postID)
+	if err != nil {
+		return nil, err
+	}
+	names, err := mentionedNames([]int64{postID})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamPostEditItem, 0, len(edits))
+	for _, e := range edits {
+		content, _ := renderMentions(e.Content, names[postID])
+		result = append(result, models.ParamPostEditItem{Content: content, EditedAt: e.CreatedAt})
+	}
+	return result, nil
 }
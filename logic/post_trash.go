@@ -0,0 +1,111 @@
+package logic
+
+import (
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/models"
+	"time"
+)
+
+const (
+	PostTrashRetention   = 30 * 24 * time.Hour // 回收站保留时间，超时后彻底清除
+	postTrashCleanPeriod = time.Hour           // 回收站清理间隔
+	postTrashCleanBatch  = 100                 // 每批彻底清除的动态数
+)
+
+// GetTrashPosts 获取回收站中的动态
+func GetTrashPosts(userID int64, offset int) ([]models.ParamTrashPostItem, error) {
+	posts, err := mysql.GetDeletedPosts(userID, time.Now().Add(-PostTrashRetention), offset, 10)
+	if err != nil {
+		return nil, err
+	}
+	items, err := decoratePosts(userID, posts)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamTrashPostItem, 0, len(posts))
+	for i, post := range posts {
+		result = append(result, models.ParamTrashPostItem{
+			ParamPostWithUserInfo: items[i],
+			DeletedAt:             post.DeletedAt.Time,
+			ExpireAt:              post.DeletedAt.Time.Add(PostTrashRetention),
+		})
+	}
+	return result, nil
+}
+
+// RestorePost 从回收站恢复自己的动态
+func RestorePost(userID, postID int64) error {
+	post, err := getOwnTrashPost(userID, postID)
+	if err != nil {
+		return err
+	}
+	if err = mysql.RestorePost(postID); err != nil {
+		return err
+	}
+	touchFeed(userID)
+	// 时间线中的动态可能已被裁剪，恢复后重新写入
+	go fanoutPost(post)
+	return nil
+}
+
+// PurgePost 彻底删除回收站中自己的动态
+func PurgePost(userID, postID int64) error {
+	if _, err := getOwnTrashPost(userID, postID); err != nil {
+		return err
+	}
+	return purgePosts([]int64{postID})
+}
+
+// purgePosts 彻底删除动态及其关联数据，并删除动态图片文件
+func purgePosts(postIDs []int64) error {
+	images, err := mysql.GetPostImages(postIDs)
+	if err != nil {
+		return err
+	}
+	if err = mysql.PurgePosts(postIDs); err != nil {
+		return err
+	}
+	removePostImageFiles(images)
+	return nil
+}
+
+// getOwnTrashPost 获取回收站中自己的、未超过保留时间的动态
+func getOwnTrashPost(userID, postID int64) (*models.Post, error) {
+	post, err := mysql.GetDeletedPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != userID || time.Since(post.DeletedAt.Time) > PostTrashRetention {
+		return nil, mysql.ErrorPostNotExist
+	}
+	return post, nil
+}
+
+// CleanPostTrash 彻底清除超过保留时间的已删除动态
+func CleanPostTrash() error {
+	before := time.Now().Add(-PostTrashRetention)
+	for {
+		ids, err := mysql.GetExpiredDeletedPostIDs(before, postTrashCleanBatch)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err = purgePosts(ids); err != nil {
+			return err
+		}
+	}
+}
+
+// StartPostTrashCleaner 启动回收站定时清理任务
+func StartPostTrashCleaner() {
+	ticker := time.NewTicker(postTrashCleanPeriod)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := CleanPostTrash(); err != nil {
+			zap.L().Error("CleanPostTrash failed", zap.Error(err))
+		}
+	}
+}
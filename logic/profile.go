@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
+	"gosocial/models"
+)
+
+// getProfiles 批量获取用户的昵称与头像：先从Redis资料缓存一次性读取，未命中的用户从MySQL加载后回填
+// 缓存不可用时直接查询MySQL，不影响展示
+func getProfiles(userIDs []int64) (map[int64]models.UserProfile, error) {
+	ctx := context.Background()
+	userIDs = uniqueIDs(userIDs)
+	profiles, missing, err := redis.GetProfiles(ctx, userIDs)
+	if err != nil {
+		zap.L().Error("redis.GetProfiles failed", zap.Error(err))
+		profiles, missing = make(map[int64]models.UserProfile, len(userIDs)), userIDs
+	}
+	if len(missing) == 0 {
+		return profiles, nil
+	}
+	users, err := mysql.GetUsersByUIDs(missing)
+	if err != nil {
+		return nil, err
+	}
+	loaded := make([]models.UserProfile, 0, len(users))
+	for _, u := range users {
+		p := models.UserProfile{UserID: u.UserID, Username: u.Username, AvatarURL: u.AvatarURL}
+		profiles[u.UserID] = p
+		loaded = append(loaded, p)
+	}
+	if err = redis.SetProfiles(ctx, loaded); err != nil {
+		zap.L().Error("redis.SetProfiles failed", zap.Error(err))
+	}
+	return profiles, nil
+}
+
+// invalidateProfile 用户资料变更后删除资料缓存
+func invalidateProfile(userID int64) {
+	if err := redis.DelProfile(context.Background(), userID); err != nil {
+		zap.L().Error("redis.DelProfile failed", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
+
+// displayName 优先显示当前用户给对方的好友备注，否则显示昵称
+func displayName(userID int64, profiles map[int64]models.UserProfile, remarks map[int64]string) string {
+	if remark := remarks[userID]; remark != "" {
+		return remark
+	}
+	return profiles[userID].Username
+}
@@ -0,0 +1,176 @@
+package logic
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
+	"gosocial/models"
+)
+
+// 动态流排序方式
+const (
+	FeedModeLatest = "latest" // 按发布时间倒序(默认)
+	FeedModeRanked = "ranked" // 按热度与亲密度综合排序
+)
+
+const (
+	rankCandidateLimit = 300            // 参与排序的候选动态数(最新的300条可见动态)
+	rankHalfLife       = 12 * time.Hour // 时间衰减半衰期，发布时间每经过一个半衰期得分减半
+	rankLikeWeight     = 1.0            // 点赞权重
+	rankCommentWeight  = 2.0            // 评论权重
+	rankViewWeight     = 0.3            // 浏览权重
+	rankIntimacyWeight = 0.5            // 亲密度权重
+)
+
+// rankedCursor 排序动态流分页游标，翻页时读取同一份排序快照
+type rankedCursor struct {
+	Snapshot int64 `json:"s"` // 快照生成时间(毫秒)
+	Offset   int   `json:"o"` // 快照内的偏移量
+}
+
+// GetRankedFeed 获取按热度排序的好友动态流
+// 首页对最新的候选动态打分并缓存排序快照，后续翻页沿用同一快照，保证分页期间顺序稳定、不重复不遗漏
+// 快照过期后返回ErrorFeedExpired，客户端需从首页重新加载
+func GetRankedFeed(userID int64, cursor string) (*models.ParamPostPage, error) {
+	var c rankedCursor
+	if err := decodeCursor(cursor, &c); err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return nil, err
+	}
+	friendIDs := make([]int64, 0, len(remarks))
+	for friendID := range remarks {
+		friendIDs = append(friendIDs, friendID)
+	}
+	followingIDs, err := mysql.GetFollowingIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. 首页生成排序快照，翻页时从快照读取
+	var ids []int64
+	var more bool
+	if c.Snapshot == 0 {
+		entries, err := rankFeedPosts(userID, friendIDs, followingIDs)
+		if err != nil {
+			return nil, err
+		}
+		c.Snapshot = time.Now().UnixMilli()
+		if err = redis.SaveRankedFeed(ctx, userID, c.Snapshot, entries); err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(entries) && i < feedPageSize; i++ {
+			ids = append(ids, entries[i].PostID)
+		}
+		more = len(entries) > feedPageSize
+	} else {
+		var exists bool
+		ids, more, exists, err = redis.ReadRankedFeed(ctx, userID, c.Snapshot, c.Offset, feedPageSize)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, mysql.ErrorFeedExpired
+		}
+	}
+
+	// 2. 重新校验可见范围，过滤掉生成快照后被删除或改为不可见的动态
+	posts, err := mysql.GetFeedPostsByIDs(userID, friendIDs, followingIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	rank := make(map[int64]int, len(ids))
+	for i, id := range ids {
+		rank[id] = i
+	}
+	sort.Slice(posts, func(i, j int) bool { return rank[posts[i].ID] < rank[posts[j].ID] })
+
+	items, err := decoratePosts(userID, posts)
+	if err != nil {
+		return nil, err
+	}
+	page := &models.ParamPostPage{Posts: items}
+	if more {
+		page.NextCursor = encodeCursor(rankedCursor{Snapshot: c.Snapshot, Offset: c.Offset + feedPageSize})
+	}
+	return page, nil
+}
+
+// rankFeedPosts 对候选动态打分并按得分降序排序
+func rankFeedPosts(userID int64, friendIDs, followingIDs []int64) ([]redis.RankedEntry, error) {
+	posts, err := mysql.GetPostsByUserIDs(userID, friendIDs, followingIDs, time.Time{}, 0, rankCandidateLimit)
+	if err != nil || len(posts) == 0 {
+		return nil, err
+	}
+	postIDs := make([]int64, len(posts))
+	for i, p := range posts {
+		postIDs[i] = p.ID
+	}
+	likeCounts, err := getLikeCounts(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	commentCounts, err := mysql.CountVisibleComments(postIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	pendingViews, err := redis.GetPendingPostViews(context.Background(), postIDs)
+	if err != nil {
+		zap.L().Error("redis.GetPendingPostViews failed", zap.Error(err))
+	}
+	intimacies, err := getFriendIntimacies(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entries := make([]redis.RankedEntry, len(posts))
+	for i, p := range posts {
+		views := int64(p.ViewCount) + pendingViews[p.ID]
+		score := rankScore(now.Sub(p.CreatedAt), likeCounts[p.ID], commentCounts[p.ID], views, intimacies[p.UserID])
+		entries[i] = redis.RankedEntry{PostID: p.ID, Score: score}
+	}
+	// 得分相同时较新的动态在前
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].PostID > entries[j].PostID
+	})
+	return entries, nil
+}
+
+// rankScore 计算动态得分：互动热度与亲密度加成的乘积，再按发布时长指数衰减
+// 互动数与亲密度取对数，避免个别热门动态或亲密好友占满整个动态流
+func rankScore(age time.Duration, likes, comments, views int64, intimacy float64) float64 {
+	engagement := 1 +
+		rankLikeWeight*math.Log1p(float64(likes)) +
+		rankCommentWeight*math.Log1p(float64(comments)) +
+		rankViewWeight*math.Log1p(float64(views))
+	affinity := 1 + rankIntimacyWeight*math.Log1p(math.Max(intimacy, 0))
+	decay := math.Pow(0.5, math.Max(age.Seconds(), 0)/rankHalfLife.Seconds())
+	return engagement * affinity * decay
+}
+
+// getFriendIntimacies 获取与各好友当前(衰减后)的亲密度
+func getFriendIntimacies(userID int64) (map[int64]float64, error) {
+	rows, err := mysql.GetFriendIntimacies(userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	intimacies := make(map[int64]float64, len(rows))
+	for _, f := range rows {
+		intimacies[f.FriendID] = f.CurrentIntimacy(now)
+	}
+	return intimacies, nil
+}
@@ -0,0 +1,165 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
+	"gosocial/models"
+)
+
+const reportMessageContext = 10 // 举报私信时保存被举报私信前后各10条聊天记录作为上下文
+
+// CreateReport 举报动态、私信或账号，举报归入该对象未结案的审核工单
+// 举报私信时targetID为发送者ID，messageAt为私信的发送时间，只能举报别人发给自己的私信
+func CreateReport(reporterID int64, targetType string, targetID int64, messageAt *time.Time, reason, detail string) error {
+	c := &models.ModerationCase{
+		TargetType:     targetType,
+		TargetID:       targetID,
+		Status:         models.CaseStatusPending,
+		LastReportedAt: time.Now(),
+	}
+	var snapshot *models.ReportSnapshot
+	var err error
+	switch targetType {
+	case models.ReportTargetPost:
+		// 只能举报自己可见的动态
+		post, err := getVisiblePost(reporterID, targetID)
+		if err != nil {
+			return err
+		}
+		c.TargetUserID = post.UserID
+		if snapshot, err = postSnapshot(post); err != nil {
+			return err
+		}
+	case models.ReportTargetUser:
+		c.TargetUserID = targetID
+		if snapshot, err = userSnapshot(targetID); err != nil {
+			return err
+		}
+	case models.ReportTargetMessage:
+		if messageAt == nil {
+			return mysql.ErrorInvalidParam
+		}
+		c.TargetUserID = targetID
+		c.MessageTo = reporterID
+		messages, err := getMessageContext(targetID, reporterID, *messageAt)
+		if err != nil {
+			return err
+		}
+		for _, m := range messages {
+			if m.Reported {
+				c.MessageAt = m.CreatedAt.UnixNano()
+			}
+		}
+		snapshot = &models.ReportSnapshot{Messages: messages}
+	default:
+		return mysql.ErrorInvalidParam
+	}
+	if c.TargetUserID == reporterID {
+		return mysql.ErrorCannotReportSelf
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	openKey := caseOpenKey(c)
+	c.OpenKey = &openKey
+	report := &models.Report{
+		ReporterID: reporterID,
+		Reason:     reason,
+		Detail:     detail,
+		Snapshot:   string(data),
+	}
+	return mysql.CreateReport(c, report)
+}
+
+// caseOpenKey 举报对象的唯一标识，用于把同一对象的举报归入同一个未结工单
+func caseOpenKey(c *models.ModerationCase) string {
+	if c.TargetType == models.ReportTargetMessage {
+		return fmt.Sprintf("%s:%d:%d:%d", c.TargetType, c.TargetID, c.MessageTo, c.MessageAt)
+	}
+	return fmt.Sprintf("%s:%d", c.TargetType, c.TargetID)
+}
+
+// postSnapshot 保存动态当前的内容与图片
+func postSnapshot(post *models.Post) (*models.ReportSnapshot, error) {
+	images, err := mysql.GetPostImages([]int64{post.ID})
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &models.ReportSnapshot{Content: post.Content, CreatedAt: &post.CreatedAt}
+	for _, img := range images {
+		snapshot.Images = append(snapshot.Images, img.URL)
+	}
+	return snapshot, nil
+}
+
+// userSnapshot 保存账号当前的用户名、签名与头像
+func userSnapshot(userID int64) (*models.ReportSnapshot, error) {
+	user, err := mysql.GetUserByUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReportSnapshot{
+		Username:  user.Username,
+		Signature: user.Signature,
+		AvatarURL: user.AvatarURL,
+		CreatedAt: &user.CreatedAt,
+	}, nil
+}
+
+// getMessageContext 查找from发给to的指定私信，返回该私信及其前后的聊天记录
+// 持久化到MySQL的私信时间精度较低，按1秒内最接近的私信匹配
+func getMessageContext(from, to int64, at time.Time) ([]models.SnapshotMessage, error) {
+	ctx := context.Background()
+	start, end := at.Add(-24*time.Hour), at.Add(24*time.Hour)
+	messages, err := redis.GetChatMessages(ctx, from, to, start.Unix(), end.Unix())
+	if err != nil {
+		return nil, err
+	}
+	if at.Before(time.Now().Add(-oneWeek)) {
+		persisted, err := mysql.NewMessageDao().GetMessages(ctx, from, to, start, end)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, persisted...)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+
+	target, best := -1, time.Second
+	for i, m := range messages {
+		if m.From != from || m.To != to {
+			continue
+		}
+		if d := m.CreatedAt.Sub(at).Abs(); d < best {
+			target, best = i, d
+		}
+	}
+	if target < 0 {
+		return nil, mysql.ErrorMessageNotExist
+	}
+
+	lo, hi := max(0, target-reportMessageContext), min(len(messages), target+reportMessageContext+1)
+	result := make([]models.SnapshotMessage, 0, hi-lo)
+	for i := lo; i < hi; i++ {
+		m := messages[i]
+		result = append(result, models.SnapshotMessage{
+			From:      m.From,
+			To:        m.To,
+			Type:      m.Type,
+			Content:   m.Content,
+			FileURL:   m.FileURL,
+			CreatedAt: m.CreatedAt,
+			Reported:  i == target,
+		})
+	}
+	return result, nil
+}
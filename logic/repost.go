@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"errors"
+
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+// repostUnavailableText 原动态已删除或不可见时的占位文字
+const repostUnavailableText = "该内容已无法查看"
+
+// RepostPost 转发动态，可附带评语。转发只引用原动态，转发动态的查看者
+// 仍需能看到原动态才会展示原文，因此转发不会让原动态被更多人看到
+func RepostPost(userID, postID int64, content, visibility string, userIDs, groupIDs []int64) (*models.Post, error) {
+	origin, err := getVisiblePost(userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	// 转发的转发统一引用最初的原动态
+	if origin.RepostOfID != 0 {
+		if origin, err = getVisiblePost(userID, origin.RepostOfID); err != nil {
+			return nil, err
+		}
+	}
+	if err = checkRepostVisibility(origin.ID, visibility); err != nil {
+		return nil, err
+	}
+	post := &models.Post{
+		UserID:     userID,
+		Content:    content,
+		Visibility: visibility,
+		RepostOfID: origin.ID,
+	}
+	if err = publishPost(post, userIDs, groupIDs); err != nil {
+		return nil, err
+	}
+	go Notify(origin.UserID, userID, models.NotificationTypeRepost, post.ID, "转发了你的动态")
+	return post, nil
+}
+
+// checkRepostVisibility 转发非公开动态时不能设置为公开，避免原动态出现在作者粉丝以外的动态流中
+// 原动态已删除时同样按非公开处理，以免恢复后扩大可见范围
+func checkRepostVisibility(repostOfID int64, visibility string) error {
+	if repostOfID == 0 || visibility != models.PostVisibilityPublic {
+		return nil
+	}
+	origin, err := mysql.GetPostByID(repostOfID)
+	if errors.Is(err, mysql.ErrorPostNotExist) {
+		return mysql.ErrorRepostWidenAudience
+	}
+	if err != nil {
+		return err
+	}
+	if origin.Visibility != models.PostVisibilityPublic {
+		return mysql.ErrorRepostWidenAudience
+	}
+	return nil
+}
+
+// getRepostOrigins 批量获取转发动态引用的原动态，原动态已删除或查看者不可见时返回占位内容
+func getRepostOrigins(viewerID int64, posts []models.Post) (map[int64]*models.ParamPostWithUserInfo, error) {
+	var ids []int64
+	for _, p := range posts {
+		if p.RepostOfID != 0 {
+			ids = append(ids, p.RepostOfID)
+		}
+	}
+	result := make(map[int64]*models.ParamPostWithUserInfo, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	ids = uniqueIDs(ids)
+	origins, err := mysql.GetPostsByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]models.Post, 0, len(origins))
+	for _, origin := range origins {
+		// 原动态不会再引用其他动态，避免递归
+		if origin.RepostOfID != 0 {
+			continue
+		}
+		ok, err := canViewPost(viewerID, &origin)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		visible = append(visible, origin)
+	}
+	items, err := decoratePosts(viewerID, visible)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		result[items[i].ID] = &items[i]
+	}
+	for _, id := range ids {
+		if result[id] == nil {
+			result[id] = &models.ParamPostWithUserInfo{ID: id, Content: repostUnavailableText, Unavailable: true}
+		}
+	}
+	return result, nil
+}
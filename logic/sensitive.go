@@ -0,0 +1,50 @@
+package logic
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/models"
+	"gosocial/pkg/sensitive"
+)
+
+// FilterText 按敏感词分类策略处理用户输入：命中拒绝类词语返回ErrorSensitiveContent，
+// 屏蔽类词语替换为***，审核类词语照常保存并记录原文待审核
+// 用户名不能包含***，命中屏蔽类词语时同样拒绝
+func FilterText(userID int64, scene, text string) (string, error) {
+	result := sensitive.Check(text)
+	if result.Rejected || (scene == models.ContentSceneUsername && result.Text != text) {
+		return "", mysql.ErrorSensitiveContent
+	}
+	if result.Review {
+		go flagContent(userID, scene, text, result.Hits)
+	}
+	return result.Text, nil
+}
+
+// flagContent 记录命中审核类敏感词的原文
+func flagContent(userID int64, scene, text string, hits []sensitive.Hit) {
+	// 命中词语按字段长度(255字节)截断
+	words := make([]string, 0, len(hits))
+	size := 0
+	for _, h := range hits {
+		if h.Policy != sensitive.PolicyReview {
+			continue
+		}
+		if size += len(h.Word) + 1; size > 256 {
+			break
+		}
+		words = append(words, h.Word)
+	}
+	flag := &models.ContentFlag{
+		UserID:  userID,
+		Scene:   scene,
+		Content: text,
+		Words:   strings.Join(words, ","),
+		Status:  models.ContentFlagPending,
+	}
+	if err := mysql.CreateContentFlag(flag); err != nil {
+		zap.L().Error("mysql.CreateContentFlag failed", zap.Int64("user_id", userID), zap.String("scene", scene), zap.Error(err))
+	}
+}
@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+// GetUserSetting 获取用户设置
+func GetUserSetting(userID int64) (*models.UserSetting, error) {
+	return mysql.GetUserSetting(userID)
+}
+
+// UpdateUserSetting 更新用户设置，仅更新请求中携带的字段
+func UpdateUserSetting(userID int64, req models.ParamUpdateUserSettingRequest) error {
+	updateData := make(map[string]interface{})
+	if req.AllowSearchByUsername != nil {
+		updateData["allow_search_by_username"] = *req.AllowSearchByUsername
+	}
+	if req.AllowSearchByEmail != nil {
+		updateData["allow_search_by_email"] = *req.AllowSearchByEmail
+	}
+	if req.AllowSearchByUID != nil {
+		updateData["allow_search_by_uid"] = *req.AllowSearchByUID
+	}
+	if req.FollowNeedApproval != nil {
+		updateData["follow_need_approval"] = *req.FollowNeedApproval
+	}
+	if req.HideVisitRecord != nil {
+		updateData["hide_visit_record"] = *req.HideVisitRecord
+	}
+	if req.HideBirthYear != nil {
+		updateData["hide_birth_year"] = *req.HideBirthYear
+	}
+	if req.HideBirthday != nil {
+		updateData["hide_birthday"] = *req.HideBirthday
+	}
+	if req.DisableMemories != nil {
+		updateData["disable_memories"] = *req.DisableMemories
+	}
+	if req.MemoryNotification != nil {
+		updateData["memory_notification"] = *req.MemoryNotification
+	}
+	return mysql.UpdateUserSetting(userID, updateData)
+}
@@ -0,0 +1,240 @@
+package logic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
+	"gosocial/models"
+	"sort"
+	"time"
+)
+
+const (
+	TimelineFanoutLimit = 2000 // 好友数与粉丝数之和超过该值的账号不再写扩散，改由读者拉取
+	feedPageSize        = 10   // 动态流每页条数
+	feedMaxRounds       = 5    // 单次请求最多扫描的时间线窗口数，避免大量不可见动态导致请求过慢
+)
+
+// feedCursor 动态流分页游标，指向上一页最后一条动态
+type feedCursor struct {
+	At int64 `json:"t"` // 发布时间(毫秒)
+	ID int64 `json:"i"` // 动态ID
+}
+
+// before 判断动态是否排在游标之后(更早)
+func (c feedCursor) before(post models.Post) bool {
+	at := post.CreatedAt.UnixMilli()
+	return at < c.At || (at == c.At && post.ID < c.ID)
+}
+
+// beforeTime 游标对应的发布时间，空游标返回零值
+func (c feedCursor) beforeTime() time.Time {
+	if c.At == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(c.At)
+}
+
+// GetFriendPosts 获取好友动态流：普通账号的动态在发布时写入读者的Redis时间线(写扩散)，
+// 大账号的动态在读取时从MySQL拉取(读扩散)，两者按发布时间归并后再按可见范围过滤
+func GetFriendPosts(userID int64, cursor string) (*models.ParamPostPage, error) {
+	var c feedCursor
+	if err := decodeCursor(cursor, &c); err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+
+	// 1. 从好友缓存获取好友ID与备注，并获取关注用户ID列表(仅可见其公开动态)
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return nil, err
+	}
+	friendIDs := make([]int64, 0, len(remarks))
+	for friendID := range remarks {
+		friendIDs = append(friendIDs, friendID)
+	}
+	followingIDs, err := mysql.GetFollowingIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 区分写扩散账号与读扩散账号
+	pullAuthors, err := redis.GetPullAuthors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pushFriends, pullFriends := splitByPull(friendIDs, pullAuthors)
+	pushFollowing, pullFollowing := splitByPull(followingIDs, pullAuthors)
+
+	// 3. 时间线不存在时从MySQL构建
+	if err = ensureTimeline(ctx, userID, pushFriends, pushFollowing); err != nil {
+		return nil, err
+	}
+
+	// 4. 逐个窗口归并时间线与读扩散动态，直到凑满一页或没有更多动态
+	posts := make([]models.Post, 0, feedPageSize)
+	hasMore := true
+	for round := 0; round < feedMaxRounds && len(posts) < feedPageSize; round++ {
+		need := feedPageSize - len(posts)
+		entries, more, err := redis.ReadTimeline(ctx, userID, c.At, c.ID, need*2)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int64, len(entries))
+		for i, e := range entries {
+			ids[i] = e.PostID
+		}
+		candidates, err := mysql.GetFeedPostsByIDs(userID, friendIDs, followingIDs, ids)
+		if err != nil {
+			return nil, err
+		}
+		// 时间线读完后回源MySQL获取更早的动态，否则只拉取读扩散账号的动态
+		pf, pfo := pullFriends, pullFollowing
+		if !more {
+			pf, pfo = friendIDs, followingIDs
+		}
+		if !more || len(pf)+len(pfo) > 0 {
+			pulled, err := mysql.GetPostsByUserIDs(userID, pf, pfo, c.beforeTime(), c.ID, need)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, pulled...)
+		}
+		candidates = sortFeedPosts(candidates)
+
+		// 时间线未读完时，窗口之外更早的动态留到下一轮，保证归并顺序正确
+		var floor feedCursor
+		if more && len(entries) > 0 {
+			last := entries[len(entries)-1]
+			floor = feedCursor{At: last.At, ID: last.PostID}
+			kept := candidates[:0]
+			for _, p := range candidates {
+				if !floor.before(p) {
+					kept = append(kept, p)
+				}
+			}
+			candidates = kept
+		}
+
+		if len(candidates) >= need {
+			posts = append(posts, candidates[:need]...)
+			last := posts[len(posts)-1]
+			c = feedCursor{At: last.CreatedAt.UnixMilli(), ID: last.ID}
+			break
+		}
+		posts = append(posts, candidates...)
+		if !more {
+			hasMore = false
+			break
+		}
+		c = floor
+	}
+
+	// 5. 补充作者资料与互动数据
+	items, err := decoratePosts(userID, posts)
+	if err != nil {
+		return nil, err
+	}
+	page := &models.ParamPostPage{Posts: items}
+	// 连续多个窗口的动态都不可见时本页可能为空，仍返回游标让客户端继续翻页
+	if hasMore {
+		page.NextCursor = encodeCursor(c)
+	}
+	return page, nil
+}
+
+// ensureTimeline 时间线不存在时，用写扩散账号的最新动态构建时间线
+func ensureTimeline(ctx context.Context, userID int64, friendIDs, followingIDs []int64) error {
+	exists, err := redis.TimelineExists(ctx, userID)
+	if err != nil || exists {
+		return err
+	}
+	posts, err := mysql.GetFeedPostKeys(userID, friendIDs, followingIDs, redis.TimelineMaxLen)
+	if err != nil {
+		return err
+	}
+	entries := make([]redis.TimelineEntry, len(posts))
+	for i, p := range posts {
+		entries[i] = redis.TimelineEntry{PostID: p.ID, At: p.CreatedAt.UnixMilli()}
+	}
+	return redis.BuildTimeline(ctx, userID, entries)
+}
+
+// fanoutPost 将动态写入作者本人、好友及粉丝(仅公开动态)的时间线
+// 好友与粉丝过多的账号标记为读扩散账号，只写入作者本人的时间线，其他读者在读取时拉取
+func fanoutPost(post *models.Post) {
+	ctx := context.Background()
+	friendIDs, err := GetFriendIDs(post.UserID)
+	if err != nil {
+		zap.L().Error("GetFriendIDs failed", zap.Int64("user_id", post.UserID), zap.Error(err))
+		return
+	}
+	followerCount, err := mysql.CountFollowers(post.UserID)
+	if err != nil {
+		zap.L().Error("mysql.CountFollowers failed", zap.Int64("user_id", post.UserID), zap.Error(err))
+		return
+	}
+	// 作者本人的时间线始终写入，读扩散账号也能在第一页看到自己的新动态
+	entry := redis.TimelineEntry{PostID: post.ID, At: post.CreatedAt.UnixMilli()}
+	if int64(len(friendIDs))+followerCount > TimelineFanoutLimit {
+		if err = redis.AddPullAuthor(ctx, post.UserID); err != nil {
+			zap.L().Error("redis.AddPullAuthor failed", zap.Int64("user_id", post.UserID), zap.Error(err))
+		}
+		if err = redis.PushToTimelines(ctx, []int64{post.UserID}, entry); err != nil {
+			zap.L().Error("redis.PushToTimelines failed", zap.Int64("post_id", post.ID), zap.Error(err))
+		}
+		return
+	}
+	recipients := append(friendIDs, post.UserID)
+	if post.Visibility == models.PostVisibilityPublic {
+		followerIDs, err := mysql.GetFollowerIDs(post.UserID)
+		if err != nil {
+			zap.L().Error("mysql.GetFollowerIDs failed", zap.Int64("user_id", post.UserID), zap.Error(err))
+			return
+		}
+		recipients = uniqueIDs(append(recipients, followerIDs...))
+	}
+	// 私密等范围的动态同样写入好友时间线，读取时会按可见范围过滤
+	if err = redis.PushToTimelines(ctx, recipients, entry); err != nil {
+		zap.L().Error("redis.PushToTimelines failed", zap.Int64("post_id", post.ID), zap.Error(err))
+	}
+}
+
+// invalidateTimeline 好友或关注关系变化后删除相关用户的时间线，下次读取时重新构建
+func invalidateTimeline(userIDs ...int64) {
+	if err := redis.DelTimeline(context.Background(), userIDs...); err != nil {
+		zap.L().Error("redis.DelTimeline failed", zap.Error(err))
+	}
+}
+
+// splitByPull 按是否为读扩散账号拆分用户ID
+func splitByPull(ids []int64, pullAuthors map[int64]bool) (push, pull []int64) {
+	for _, id := range ids {
+		if pullAuthors[id] {
+			pull = append(pull, id)
+		} else {
+			push = append(push, id)
+		}
+	}
+	return push, pull
+}
+
+// sortFeedPosts 按发布时间与ID降序排序并去重
+func sortFeedPosts(posts []models.Post) []models.Post {
+	sort.Slice(posts, func(i, j int) bool {
+		ai, aj := posts[i].CreatedAt.UnixMilli(), posts[j].CreatedAt.UnixMilli()
+		if ai != aj {
+			return ai > aj
+		}
+		return posts[i].ID > posts[j].ID
+	})
+	result := posts[:0]
+	for _, p := range posts {
+		if len(result) > 0 && result[len(result)-1].ID == p.ID {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package logic
+
+import (
+	"strings"
+
+	"gosocial/dao/mysql"
+	"gosocial/models"
+)
+
+// GetTopicPosts 获取话题下当前用户可见的动态，按发布时间倒序游标分页
+func GetTopicPosts(userID int64, name, cursor string) (*models.ParamTopicPage, error) {
+	var c feedCursor
+	if err := decodeCursor(cursor, &c); err != nil {
+		return nil, err
+	}
+	name = strings.ToLower(strings.TrimSpace(strings.Trim(name, "#")))
+	topic, err := mysql.GetTopicByName(name)
+	if err != nil {
+		return nil, err
+	}
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return nil, err
+	}
+	friendIDs := make([]int64, 0, len(remarks))
+	for friendID := range remarks {
+		friendIDs = append(friendIDs, friendID)
+	}
+	posts, err := mysql.GetTopicPosts(userID, friendIDs, topic.ID, c.beforeTime(), c.ID, feedPageSize)
+	if err != nil {
+		return nil, err
+	}
+	items, err := decoratePosts(userID, posts)
+	if err != nil {
+		return nil, err
+	}
+	page := &models.ParamTopicPage{Topic: topic.Name, Posts: items}
+	if len(posts) == feedPageSize {
+		last := posts[len(posts)-1]
+		page.NextCursor = encodeCursor(feedCursor{At: last.CreatedAt.UnixMilli(), ID: last.ID})
+	}
+	return page, nil
+}
@@ -74,6 +74,10 @@ func LoginLogic(p *models.ParamLogin) (*models.User, error) {
 	if err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(p.Password)); err != nil {
 		return nil, mysql.ErrorInvalidPassword
 	}
+	// 封禁期间不能登录
+	if user.SuspendedUntil != nil && user.SuspendedUntil.After(time.Now()) {
+		return nil, mysql.ErrorUserSuspended
+	}
 	// 生成JWT Token
 	token, err := jwt.GenToken(uint64(user.UserID), user.Username)
 	if err != nil {
@@ -150,6 +154,14 @@ func UpdateUserInfoLogic(userID int64, req models.ParamUpdateUserInfoRequest) er
 	if err := mysql.UpdateUserInfo(userID, updateData); err != nil {
 		return mysql.ErrorSystem
 	}
+	// 昵称或头像变更后删除资料缓存，动态、评论与消息随即展示新资料
+	if req.Username != "" || req.AvatarURL != "" {
+		invalidateProfile(userID)
+	}
+	// 昵称、头像与签名会出现在订阅源中
+	if req.Username != "" || req.AvatarURL != "" || req.Signature != "" {
+		touchFeed(userID)
+	}
 
 	return nil
 }
@@ -205,5 +217,51 @@ func UpdateUserAvatar(userID int64, avatarURL string) error {
 	updateData := map[string]interface{}{
 		"avatar_url": avatarURL,
 	}
-	return mysql.UpdateUserInfo(userID, updateData)
+	if err := mysql.UpdateUserInfo(userID, updateData); err != nil {
+		return err
+	}
+	invalidateProfile(userID)
+	touchFeed(userID)
+	return nil
+}
+
+// SearchUsers 全站搜索用户，并标记已是好友的用户
+func SearchUsers(userID int64, keyword string, offset int) ([]models.ParamUserSearchItem, error) {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return nil, mysql.ErrorInvalidParam
+	}
+	// 关键字为纯数字时同时按UID精确匹配
+	uid, _ := strconv.ParseInt(keyword, 10, 64)
+
+	users, err := mysql.SearchUsers(userID, keyword, uid, offset, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	// 批量查询结果中哪些已是好友
+	ids := make([]int64, len(users))
+	for i, u := range users {
+		ids[i] = u.UserID
+	}
+	friendIDs, err := mysql.GetFriendIDsIn(userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	isFriend := make(map[int64]bool, len(friendIDs))
+	for _, id := range friendIDs {
+		isFriend[id] = true
+	}
+
+	result := make([]models.ParamUserSearchItem, 0, len(users))
+	for _, u := range users {
+		result = append(result, models.ParamUserSearchItem{
+			UserID:    u.UserID,
+			Username:  u.Username,
+			AvatarURL: u.AvatarURL,
+			Signature: u.Signature,
+			IsFriend:  isFriend[u.UserID],
+		})
+	}
+	return result, nil
 }
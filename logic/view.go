@@ -0,0 +1,118 @@
+package logic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"gosocial/dao/mysql"
+	"gosocial/dao/redis"
+	"gosocial/models"
+	"time"
+)
+
+// viewFlushInterval 浏览量回写MySQL的间隔
+const viewFlushInterval = 30 * time.Second
+
+// IncrementPostViewCount 记录一次动态浏览：同一用户24小时内重复浏览只计一次，
+// 首次浏览时记录访客(用户可设置不留痕迹)并记录浏览者与作者的互动
+func IncrementPostViewCount(viewerID, postID int64) error {
+	post, err := getVisiblePost(viewerID, postID)
+	if err != nil {
+		return err
+	}
+	// 作者浏览自己的动态不计数
+	if post.UserID == viewerID {
+		return nil
+	}
+	ctx := context.Background()
+	now := time.Now()
+	counted, err := redis.RecordPostView(ctx, postID, viewerID)
+	if err != nil || !counted {
+		return err
+	}
+
+	setting, err := mysql.GetUserSetting(viewerID)
+	if err != nil {
+		return err
+	}
+	if !setting.HideVisitRecord {
+		if err = mysql.RecordPostVisit(postID, viewerID, now); err != nil {
+			zap.L().Error("mysql.RecordPostVisit failed", zap.Int64("post_id", postID), zap.Error(err))
+		}
+	}
+	if err = RecordInteraction(ctx, viewerID, post.UserID, InteractWeightPostView); err != nil {
+		zap.L().Error("RecordInteraction failed", zap.Error(err))
+	}
+	return nil
+}
+
+// GetPostVisitors 获取自己某条动态的访客列表
+func GetPostVisitors(userID, postID int64, offset int) ([]models.ParamVisitorItem, error) {
+	post, err := mysql.GetPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	// 仅作者本人可查看访客，他人视为动态不存在
+	if post.UserID != userID {
+		return nil, mysql.ErrorPostNotExist
+	}
+	visits, err := mysql.GetPostVisitors(postID, userID, offset, 20)
+	if err != nil {
+		return nil, err
+	}
+	return toVisitorItems(userID, visits)
+}
+
+// GetMyPostVisitors 获取自己所有动态的访客记录(谁看过我)
+func GetMyPostVisitors(userID int64, offset int) ([]models.ParamVisitorItem, error) {
+	visits, err := mysql.GetUserPostVisitors(userID, offset, 20)
+	if err != nil {
+		return nil, err
+	}
+	return toVisitorItems(userID, visits)
+}
+
+// toVisitorItems 封装访客列表，昵称优先显示好友备注
+func toVisitorItems(userID int64, visits []models.PostVisit) ([]models.ParamVisitorItem, error) {
+	remarks, err := getFriendRemarks(userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.ParamVisitorItem, 0, len(visits))
+	for _, v := range visits {
+		name := v.Viewer.Username
+		if remark := remarks[v.ViewerID]; remark != "" {
+			name = remark
+		}
+		result = append(result, models.ParamVisitorItem{
+			UserID:      v.ViewerID,
+			DisplayName: name,
+			AvatarURL:   v.Viewer.AvatarURL,
+			PostID:      v.PostID,
+			VisitedAt:   v.VisitedAt,
+		})
+	}
+	return result, nil
+}
+
+// FlushPostViews 将Redis中累计的浏览量批量回写MySQL，回写成功后才从Redis中删除，失败时下次重试
+func FlushPostViews(ctx context.Context) error {
+	counts, err := redis.TakePendingPostViews(ctx)
+	if err != nil || len(counts) == 0 {
+		return err
+	}
+	if err = mysql.AddPostViewCounts(counts); err != nil {
+		return err
+	}
+	return redis.AckPendingPostViews(ctx)
+}
+
+// StartPostViewFlusher 启动浏览量定时回写任务
+func StartPostViewFlusher() {
+	ticker := time.NewTicker(viewFlushInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := FlushPostViews(context.Background()); err != nil {
+			zap.L().Error("FlushPostViews failed", zap.Error(err))
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"gosocial/dao/mysql"
 	"gosocial/dao/redis"
 	"gosocial/logger"
+	"gosocial/logic"
+	"gosocial/pkg/sensitive"
 	"gosocial/pkg/snowflake"
 	"gosocial/routes"
 	"gosocial/settings"
@@ -52,6 +54,16 @@ func main() {
 	defer zap.L().Sync()
 	zap.L().Debug("logger init success!")
 
+	//2.1加载敏感词词库，未配置时不过滤
+	if cfg := settings.Conf.SensitiveConfig; cfg != nil && cfg.DictFile != "" {
+		if err := sensitive.Init(cfg.DictFile); err != nil {
+			fmt.Printf("init sensitive dict failed, err:%v\n", err)
+			return
+		}
+	} else {
+		zap.L().Warn("未配置敏感词词库，不进行敏感词过滤")
+	}
+
 	//3.初始化mysql
 	if err := mysql.Init(settings.Conf.MySQLConfig); err != nil {
 		fmt.Printf("init mysql failed, err:%v\n", err)
@@ -63,7 +75,15 @@ func main() {
 		return
 	}
 	defer redis.Close()
-	//5.注册路由
+	//5.启动后台任务
+	go logic.StartInteractionFlusher()     //好友互动记录批量落库
+	go logic.StartLikeCountFlusher()       //点赞数回写
+	go logic.StartPostTrashCleaner()       //回收站过期动态清理
+	go logic.StartPostViewFlusher()        //浏览量批量回写
+	go logic.StartScheduledPostPublisher() //定时动态发布
+	go logic.StartBirthdayNotifier()       //好友生日提醒
+	go logic.StartMemoryNotifier()         //那年今日提醒
+	//6.注册路由
 	r := routes.Init()
 	err := r.Run(fmt.Sprintf(":%d", settings.Conf.Port))
 	if err != nil {
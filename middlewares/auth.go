@@ -2,11 +2,13 @@ package middlewares
 
 import (
 	"gosocial/controllers"
+	"gosocial/logic"
 	"gosocial/pkg/jwt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 // JWTAuthMiddleware 基于JWT的认证中间件
@@ -38,8 +40,34 @@ func JWTAuthMiddleware() func(c *gin.Context) {
 			c.Abort()
 			return
 		}
+		// 封禁期间已签发的Token同样失效
+		if logic.IsSuspended(int64(mc.UserID)) {
+			controllers.ResponseError(c, controllers.CodeUserSuspended)
+			c.Abort()
+			return
+		}
 		// 将当前请求的uid信息保存到请求的上下文c上
 		c.Set(controllers.CtxUserIDKey, int64(mc.UserID))
 		c.Next() // 后续的处理函数可以用过c.Get("uid")来获取当前请求的用户信息
 	}
 }
+
+// AdminMiddleware 管理员权限中间件，需在JWTAuthMiddleware之后使用
+func AdminMiddleware() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := c.MustGet(controllers.CtxUserIDKey).(int64)
+		ok, err := logic.IsAdmin(userID)
+		if err != nil {
+			zap.L().Error("logic.IsAdmin failed", zap.Int64("user_id", userID), zap.Error(err))
+			controllers.ResponseError(c, controllers.CodeServerBusy)
+			c.Abort()
+			return
+		}
+		if !ok {
+			controllers.ResponseError(c, controllers.CodeNoPermission)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Album 用户相册，可见范围为public/friends/private(同动态可见范围)
+type Album struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	UserID       int64     `gorm:"index;not null;comment:相册所属用户ID" json:"-"`
+	Name         string    `gorm:"type:varchar(32);not null;comment:相册名称" json:"-"`
+	Description  string    `gorm:"type:varchar(255);not null;default:'';comment:相册描述" json:"-"`
+	CoverPhotoID int64     `gorm:"not null;default:0;comment:封面照片ID，0为使用最新的照片" json:"-"`
+	Visibility   string    `gorm:"type:varchar(16);not null;default:'friends';comment:可见范围" json:"-"`
+	CreatedAt    time.Time `gorm:"autoCreateTime;comment:创建时间" json:"-"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime;comment:修改时间" json:"-"`
+}
+
+// IsValidAlbumVisibility 判断相册可见范围是否合法，相册不支持部分好友可见与不给谁看
+func IsValidAlbumVisibility(visibility string) bool {
+	switch visibility {
+	case PostVisibilityPublic, PostVisibilityFriends, PostVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// Photo 相册照片，与动态图片一样保存去除元数据后的原图及中图、缩略图
+type Photo struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	UserID    int64     `gorm:"index;not null;comment:上传用户ID" json:"-"`
+	AlbumID   int64     `gorm:"index:idx_album_created;not null;comment:所属相册ID" json:"-"`
+	URL       string    `gorm:"type:varchar(255);not null;comment:原图URL" json:"-"`
+	MediumURL string    `gorm:"type:varchar(255);not null;comment:中图URL" json:"-"`
+	ThumbURL  string    `gorm:"type:varchar(255);not null;comment:缩略图URL" json:"-"`
+	Width     int       `gorm:"not null;comment:原图宽度" json:"-"`
+	Height    int       `gorm:"not null;comment:原图高度" json:"-"`
+	Size      int64     `gorm:"not null;comment:原图字节数" json:"-"`
+	Format    string    `gorm:"type:varchar(8);not null;comment:图片格式" json:"-"`
+	Caption   string    `gorm:"type:varchar(255);not null;default:'';comment:照片说明" json:"-"`
+	ViewCount uint64    `gorm:"not null;default:0;comment:浏览量" json:"-"`
+	CreatedAt time.Time `gorm:"index:idx_album_created;autoCreateTime;comment:上传时间" json:"-"`
+}
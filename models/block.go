@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Block 黑名单模型(单向，UserID 拉黑了 BlockedID)
+type Block struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	UserID    int64     `gorm:"uniqueIndex:idx_user_blocked;not null;comment:用户ID" json:"user_id,string"`
+	BlockedID int64     `gorm:"uniqueIndex:idx_user_blocked;index;not null;comment:被拉黑用户ID" json:"blocked_id,string"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:拉黑时间" json:"created_at"`
+
+	// 关联被拉黑用户的信息（非数据库字段）
+	Blocked User `gorm:"foreignKey:BlockedID;references:UserID" json:"-"`
+}
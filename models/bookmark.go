@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Bookmark 动态收藏(每个用户对每条动态只能收藏一次)
+type Bookmark struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	UserID       int64     `gorm:"uniqueIndex:idx_user_post;index:idx_user_created;not null;comment:收藏用户ID" json:"-"`
+	PostID       int64     `gorm:"uniqueIndex:idx_user_post;index;not null;comment:动态ID" json:"-"`
+	CollectionID int64     `gorm:"index;not null;default:0;comment:所属收藏夹ID，0为未分类" json:"-"`
+	CreatedAt    time.Time `gorm:"index:idx_user_created;autoCreateTime;comment:收藏时间" json:"-"`
+}
+
+// Collection 收藏夹，仅创建者本人可见
+type Collection struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id,string"`
+	UserID    int64     `gorm:"index;not null;comment:收藏夹所属用户ID" json:"-"`
+	Name      string    `gorm:"type:varchar(32);not null;comment:收藏夹名称" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
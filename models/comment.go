@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Comment 动态评论模型
+// 只保存用户ID，昵称与头像在展示时通过资料缓存获取
+// 楼中楼采用两级结构：RootID为所属一级评论(一级评论自身为0)，ReplyToID为直接回复的评论
+type Comment struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id,string"`
+	PostID        int64     `gorm:"index:idx_post_created;not null;comment:动态ID" json:"post_id,string"`
+	UserID        int64     `gorm:"index;not null;comment:评论用户ID" json:"user_id,string"`
+	RootID        int64     `gorm:"index;not null;default:0;comment:所属一级评论ID" json:"root_id,string"`
+	ReplyToID     int64     `gorm:"not null;default:0;comment:回复的评论ID" json:"reply_to_id,string"`
+	ReplyToUserID int64     `gorm:"not null;default:0;comment:回复的用户ID" json:"reply_to_user_id,string"`
+	Content       string    `gorm:"type:varchar(1000);not null;comment:评论内容" json:"content"`
+	CreatedAt     time.Time `gorm:"index:idx_post_created;autoCreateTime;comment:评论时间" json:"created_at"`
+}
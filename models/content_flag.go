@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// 用户输入内容的来源场景
+const (
+	ContentScenePost      = "post"      // 动态、转发评语与草稿
+	ContentSceneComment   = "comment"   // 评论
+	ContentSceneMessage   = "message"   // 私信
+	ContentSceneUsername  = "username"  // 用户名
+	ContentSceneSignature = "signature" // 个性签名
+	ContentSceneRemark    = "remark"    // 好友备注
+	ContentSceneAlbum     = "album"     // 相册名称、描述与照片说明
+)
+
+// 内容审核标记状态
+const (
+	ContentFlagPending  = "pending"  // 待审核
+	ContentFlagReviewed = "reviewed" // 已审核
+)
+
+// ContentFlag 命中审核类敏感词的用户输入，内容照常保存，同时记录原文待人工审核
+type ContentFlag struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id,string"`
+	UserID    int64     `gorm:"index;not null;comment:提交用户ID" json:"user_id,string"`
+	Scene     string    `gorm:"type:varchar(16);not null;comment:来源场景" json:"scene"`
+	Content   string    `gorm:"type:text;not null;comment:提交的原文" json:"content"`
+	Words     string    `gorm:"type:varchar(255);not null;comment:命中的敏感词，多个用逗号分隔" json:"words"`
+	Status    string    `gorm:"type:varchar(16);index:idx_status_created;not null;default:'pending';comment:审核状态" json:"status"`
+	CreatedAt time.Time `gorm:"index:idx_status_created;autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// FeedToken 用户订阅源令牌，持有令牌即可免登录访问该用户公开动态的订阅源，重置后旧令牌失效
+type FeedToken struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	UserID    int64     `gorm:"uniqueIndex;not null;comment:用户ID" json:"-"`
+	Token     string    `gorm:"type:varchar(64);uniqueIndex;not null;comment:订阅令牌" json:"-"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:生成时间" json:"-"`
+
+	// 订阅源内容最后变化的时间，发布、编辑、删除动态，修改可见范围或个人资料时更新，用作Last-Modified
+	ModifiedAt time.Time `gorm:"autoCreateTime;comment:订阅源内容最后变化时间" json:"-"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// 关注状态
+const (
+	FollowStatusPending  = "pending"  // 待对方审核
+	FollowStatusAccepted = "accepted" // 已关注
+)
+
+// Follow 关注关系模型(单向，FollowerID 关注了 FolloweeID)
+type Follow struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	FollowerID int64     `gorm:"uniqueIndex:idx_follower_followee;not null;comment:关注者ID" json:"follower_id,string"`
+	FolloweeID int64     `gorm:"uniqueIndex:idx_follower_followee;index:idx_followee_status;not null;comment:被关注者ID" json:"followee_id,string"`
+	Status     string    `gorm:"type:varchar(16);index:idx_followee_status;not null;default:'accepted';comment:关注状态" json:"status"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;comment:关注时间" json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	// 关联双方的用户信息（非数据库字段）
+	Follower User `gorm:"foreignKey:FollowerID;references:UserID" json:"-"`
+	Followee User `gorm:"foreignKey:FolloweeID;references:UserID" json:"-"`
+}
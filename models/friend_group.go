@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// FriendGroup 好友分组模型，用于按分组设置动态可见范围
+type FriendGroup struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id,string"`
+	UserID    int64     `gorm:"index;not null;comment:分组所属用户ID" json:"-"`
+	Name      string    `gorm:"type:varchar(32);not null;comment:分组名称" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+// FriendGroupMember 好友分组成员
+type FriendGroupMember struct {
+	ID       int64 `gorm:"primaryKey;autoIncrement" json:"-"`
+	GroupID  int64 `gorm:"uniqueIndex:idx_group_friend;not null;comment:分组ID" json:"-"`
+	FriendID int64 `gorm:"uniqueIndex:idx_group_friend;index;not null;comment:成员用户ID" json:"-"`
+}
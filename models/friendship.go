@@ -1,16 +1,36 @@
 package models
 
-import "time"
+import (
+	"math"
+	"time"
+)
+
+// IntimacyHalfLife 亲密度半衰期，无互动时亲密度每经过一个半衰期减半
+const IntimacyHalfLife = 14 * 24 * time.Hour
 
 // Friendship 好友关系模型
 type Friendship struct {
-	ID             int64     `gorm:"primaryKey;autoIncrement"`
-	UserID         int64     `gorm:"index;not null;comment:用户ID" json:"user_id,string"`
-	FriendID       int64     `gorm:"index;not null;comment:好友ID" json:"friend_id,string"`
-	Remark         string    `gorm:"type:varchar(50);default:'';comment:好友备注"`
-	LastInteractAt time.Time `gorm:"index;comment:最后互动时间"`
-	CreatedAt      time.Time `gorm:"autoCreateTime;comment:添加时间"`
+	ID             int64      `gorm:"primaryKey;autoIncrement"`
+	UserID         int64      `gorm:"index;not null;comment:用户ID" json:"user_id,string"`
+	FriendID       int64      `gorm:"index;not null;comment:好友ID" json:"friend_id,string"`
+	Remark         string     `gorm:"type:varchar(50);default:'';comment:好友备注"`
+	LastInteractAt time.Time  `gorm:"index;comment:最后互动时间"`
+	Intimacy       float64    `gorm:"not null;default:0;comment:亲密度(计算于IntimacyAt时刻)"`
+	IntimacyAt     *time.Time `gorm:"comment:亲密度计算时间"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime;comment:添加时间"`
 
 	// 关联好友的用户信息（非数据库字段）
 	Friend User `gorm:"foreignKey:FriendID;references:UserID"`
 }
+
+// CurrentIntimacy 计算当前时刻衰减后的亲密度
+func (f Friendship) CurrentIntimacy(now time.Time) float64 {
+	if f.IntimacyAt == nil || f.Intimacy == 0 {
+		return f.Intimacy
+	}
+	elapsed := now.Sub(*f.IntimacyAt)
+	if elapsed <= 0 {
+		return f.Intimacy
+	}
+	return f.Intimacy * math.Pow(0.5, elapsed.Seconds()/IntimacyHalfLife.Seconds())
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PostLike 动态点赞模型(每个用户对每条动态只能点赞一次)
+type PostLike struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	PostID    int64     `gorm:"uniqueIndex:idx_post_user;not null;comment:动态ID" json:"post_id,string"`
+	UserID    int64     `gorm:"uniqueIndex:idx_post_user;index;not null;comment:点赞用户ID" json:"user_id,string"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:点赞时间" json:"created_at"`
+
+	// 关联点赞用户的信息（非数据库字段）
+	User User `gorm:"foreignKey:UserID;references:UserID" json:"-"`
+}
@@ -14,8 +14,8 @@ type Message struct {
 	IsPersisted bool      `json:"is_persisted"`         // 是否已持久化到数据库
 	HideTime    bool      `json:"hide_time"`            // 是否隐藏时间显示
 
-	// 关联发送者的用户信息（非数据库字段）
-	My User `gorm:"foreignKey:From;references:UserID"`
+	// 发送者的当前资料，读取时从资料缓存填充（非数据库字段）
+	My UserProfile `gorm:"-" json:"-"`
 }
 
 type FileMeta struct {
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// 通知类型
+const (
+	NotificationTypeLike    = "like"    // 动态被点赞
+	NotificationTypeComment = "comment" // 动态被评论
+	NotificationTypeReply   = "reply"   // 评论被回复
+	NotificationTypeMention = "mention" // 在动态中被提及
+	NotificationTypeRepost  = "repost"  // 动态被转发
+
+	NotificationTypeScheduleFailed = "schedule_failed" // 定时动态发布失败
+	NotificationTypeModeration     = "moderation"      // 内容被处置或账号被警告、封禁
+	NotificationTypeBirthday       = "birthday"        // 好友今天过生日
+	NotificationTypeMemory         = "memory"          // 那年今日
+)
+
+// Notification 站内通知模型
+type Notification struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id,string"`
+	UserID    int64     `gorm:"index:idx_user_read;not null;comment:接收者ID" json:"-"`
+	ActorID   int64     `gorm:"not null;default:0;comment:触发者ID" json:"actor_id,string"`
+	Type      string    `gorm:"type:varchar(32);not null;comment:通知类型" json:"type"`
+	TargetID  int64     `gorm:"not null;default:0;comment:关联对象ID(如动态ID)" json:"target_id,string"`
+	Content   string    `gorm:"type:varchar(255);default:'';comment:通知内容" json:"content"`
+	IsRead    bool      `gorm:"index:idx_user_read;not null;default:false;comment:是否已读" json:"is_read"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:通知时间" json:"created_at"`
+
+	// 关联触发者的信息（非数据库字段）
+	Actor User `gorm:"foreignKey:ActorID;references:UserID" json:"-"`
+}
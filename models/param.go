@@ -22,6 +22,13 @@ type ParamFriendItem struct {
 	DisplayName    string    `json:"display_name"` // 优先显示备注，否则显示昵称
 	AvatarURL      string    `json:"avatar_url"`
 	LastInteractAt time.Time `json:"last_interact_at"`
+	Intimacy       float64   `json:"intimacy"` // 当前亲密度
+}
+
+// ParamFriendPage 好友列表分页结果
+type ParamFriendPage struct {
+	Friends    []ParamFriendItem `json:"friends"`
+	NextCursor string            `json:"next_cursor"` // 下一页游标，为空表示没有更多数据
 }
 
 // ParamTextReq  发送文本消息模型结构体
@@ -54,13 +61,28 @@ type ParamFriendAdd struct {
 
 // ParamPostWithUserInfo 包含用户信息的动态
 type ParamPostWithUserInfo struct {
-	ID        int64     `json:"id,string"`
-	ViewCount uint64    `json:"view_count"` // 浏览量
-	Avatar    string    `json:"avatar"`     // 用户头像
-	Nickname  string    `json:"nickname"`   // 用户备注或用户名
-	Content   string    `json:"content"`    // 文字内容
-	Images    string    `json:"images"`     // 图片URL，多个用逗号分隔
-	CreatedAt time.Time `json:"created_at"` // 发布时间
+	ID           int64                  `json:"id,string"`
+	UserID       int64                  `json:"user_id,string"` // 作者ID
+	ViewCount    uint64                 `json:"view_count"`     // 浏览量
+	Avatar       string                 `json:"avatar"`         // 作者当前头像
+	Nickname     string                 `json:"nickname"`       // 作者的好友备注或当前用户名
+	Content      string                 `json:"content"`        // 文字内容(提及已替换为当前用户名)
+	Mentions     []ParamMention         `json:"mentions"`       // 内容中提及的用户
+	Images       string                 `json:"images"`         // 缩略图URL，多个用逗号分隔
+	ImageList    []ParamPostImage       `json:"image_list"`     // 图片详情(原图、中图、缩略图及尺寸)
+	LikeCount    int64                  `json:"like_count"`     // 点赞数
+	CommentCount int64                  `json:"comment_count"`  // 当前用户可见的评论数
+	Liked        bool                   `json:"liked"`          // 当前用户是否已点赞
+	Bookmarked   bool                   `json:"bookmarked"`     // 当前用户是否已收藏
+	Visibility   string                 `json:"visibility"`     // 可见范围
+	RepostCount  int64                  `json:"repost_count"`   // 被转发次数
+	RepostOfID   int64                  `json:"repost_of_id,string,omitempty"`
+	RepostOf     *ParamPostWithUserInfo `json:"repost_of,omitempty"`   // 转发的原动态
+	Poll         *ParamPoll             `json:"poll,omitempty"`        // 动态附带的投票
+	Unavailable  bool                   `json:"unavailable,omitempty"` // 原动态已删除或当前用户不可见，仅用于RepostOf
+	Audience     *ParamPostAudience     `json:"audience,omitempty"`    // 自定义可见名单(仅作者本人可见)
+	CreatedAt    time.Time              `json:"created_at"`            // 发布时间
+	EditedAt     *time.Time             `json:"edited_at"`             // 最后编辑时间，未编辑为空
 }
 
 // ParamUserInfoResponse 用户信息响应结构
@@ -102,3 +124,460 @@ type ParamUpdatePasswordRequest struct {
 	OldPassword string `json:"old_password" binding:"required,min=6,max=20"`
 	NewPassword string `json:"new_password" binding:"required,min=6,max=20"`
 }
+
+// ParamUserSearchItem 全站用户搜索结果
+type ParamUserSearchItem struct {
+	UserID    int64  `json:"user_id,string"`
+	Username  string `json:"username"`
+	AvatarURL string `json:"avatar_url"`
+	Signature string `json:"signature"`
+	IsFriend  bool   `json:"is_friend"` // 是否已是好友
+}
+
+// ParamUpdateUserSettingRequest 更新用户设置请求结构(未传字段保持不变)
+type ParamUpdateUserSettingRequest struct {
+	AllowSearchByUsername *bool `json:"allow_search_by_username"`
+	AllowSearchByEmail    *bool `json:"allow_search_by_email"`
+	AllowSearchByUID      *bool `json:"allow_search_by_uid"`
+	FollowNeedApproval    *bool `json:"follow_need_approval"`
+	HideVisitRecord       *bool `json:"hide_visit_record"`
+	HideBirthYear         *bool `json:"hide_birth_year"`
+	HideBirthday          *bool `json:"hide_birthday"`
+	DisableMemories       *bool `json:"disable_memories"`
+	MemoryNotification    *bool `json:"memory_notification"`
+}
+
+// ParamBlockItem 黑名单列表项
+type ParamBlockItem struct {
+	UserID    int64     `json:"user_id,string"`
+	Username  string    `json:"username"`
+	AvatarURL string    `json:"avatar_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ParamFollowItem 关注/粉丝列表项
+type ParamFollowItem struct {
+	UserID    int64     `json:"user_id,string"`
+	Username  string    `json:"username"`
+	AvatarURL string    `json:"avatar_url"`
+	Signature string    `json:"signature"`
+	CreatedAt time.Time `json:"created_at"` // 关注时间
+}
+
+// ParamFollowCount 关注数与粉丝数
+type ParamFollowCount struct {
+	Followers int64 `json:"followers"` // 粉丝数
+	Following int64 `json:"following"` // 关注数
+}
+
+// ParamLikerItem 点赞用户列表项
+type ParamLikerItem struct {
+	UserID      int64     `json:"user_id,string"`
+	DisplayName string    `json:"display_name"` // 优先显示备注，否则显示昵称
+	AvatarURL   string    `json:"avatar_url"`
+	CreatedAt   time.Time `json:"created_at"` // 点赞时间
+}
+
+// ParamNotificationItem 通知列表项
+type ParamNotificationItem struct {
+	ID          int64     `json:"id,string"`
+	Type        string    `json:"type"`
+	ActorID     int64     `json:"actor_id,string"`
+	ActorName   string    `json:"actor_name"`
+	ActorAvatar string    `json:"actor_avatar"`
+	TargetID    int64     `json:"target_id,string"` // 关联对象ID(如动态ID)
+	Content     string    `json:"content"`
+	IsRead      bool      `json:"is_read"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ParamCreateCommentRequest 发表评论请求结构
+type ParamCreateCommentRequest struct {
+	Content string `json:"content" binding:"required,max=500"`
+	ReplyTo int64  `json:"reply_to,string"` // 回复的评论ID，为空表示直接评论动态
+}
+
+// ParamCommentItem 评论列表项
+type ParamCommentItem struct {
+	ID            int64     `json:"id,string"`
+	PostID        int64     `json:"post_id,string"`
+	UserID        int64     `json:"user_id,string"`
+	DisplayName   string    `json:"display_name"` // 优先显示备注，否则显示昵称
+	AvatarURL     string    `json:"avatar_url"`
+	RootID        int64     `json:"root_id,string"`     // 所属一级评论ID，一级评论为0
+	ReplyToID     int64     `json:"reply_to_id,string"` // 回复的评论ID
+	ReplyToUserID int64     `json:"reply_to_user_id,string"`
+	ReplyToName   string    `json:"reply_to_name"`
+	Content       string    `json:"content"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ParamPostAudience 动态的自定义可见名单
+type ParamPostAudience struct {
+	UserIDs  []string `json:"user_ids"`  // 好友ID列表
+	GroupIDs []string `json:"group_ids"` // 好友分组ID列表
+}
+
+// ParamUpdatePostVisibilityRequest 修改动态可见范围请求结构
+type ParamUpdatePostVisibilityRequest struct {
+	Visibility string   `json:"visibility" binding:"required,oneof=public friends private allow deny"`
+	UserIDs    []string `json:"user_ids"`  // visibility为allow/deny时的好友ID列表
+	GroupIDs   []string `json:"group_ids"` // visibility为allow/deny时的好友分组ID列表
+}
+
+// ParamFriendGroupRequest 创建/修改好友分组请求结构
+type ParamFriendGroupRequest struct {
+	Name      string   `json:"name" binding:"required,max=32"`
+	MemberIDs []string `json:"member_ids"` // 成员好友ID列表
+}
+
+// ParamFriendGroupItem 好友分组列表项
+type ParamFriendGroupItem struct {
+	ID        int64     `json:"id,string"`
+	Name      string    `json:"name"`
+	MemberIDs []string  `json:"member_ids"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ParamUpdatePostRequest 编辑动态请求结构
+type ParamUpdatePostRequest struct {
+	Content string `json:"content" binding:"max=500"`
+}
+
+// ParamPostEditItem 动态编辑历史项
+type ParamPostEditItem struct {
+	Content  string    `json:"content"`   // 编辑前的内容
+	EditedAt time.Time `json:"edited_at"` // 编辑时间
+}
+
+// ParamTrashPostItem 回收站动态列表项
+type ParamTrashPostItem struct {
+	ParamPostWithUserInfo
+	DeletedAt time.Time `json:"deleted_at"` // 删除时间
+	ExpireAt  time.Time `json:"expire_at"`  // 彻底清除时间
+}
+
+// ParamPostPage 动态流分页结果
+type ParamPostPage struct {
+	Posts      []ParamPostWithUserInfo `json:"posts"`
+	NextCursor string                  `json:"next_cursor"` // 下一页游标，为空表示没有更多
+}
+
+// ParamVisitorItem 动态访客列表项
+type ParamVisitorItem struct {
+	UserID      int64     `json:"user_id,string"`
+	DisplayName string    `json:"display_name"` // 优先显示备注，否则显示昵称
+	AvatarURL   string    `json:"avatar_url"`
+	PostID      int64     `json:"post_id,string"` // 浏览的动态ID
+	VisitedAt   time.Time `json:"visited_at"`     // 最近浏览时间
+}
+
+// ParamPostImage 动态图片
+type ParamPostImage struct {
+	URL       string `json:"url"`        // 原图
+	MediumURL string `json:"medium_url"` // 中图
+	ThumbURL  string `json:"thumb_url"`  // 缩略图
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+}
+
+// ParamMention 动态中提及的用户
+type ParamMention struct {
+	UserID   int64  `json:"user_id,string"`
+	Username string `json:"username"` // 当前用户名
+}
+
+// ParamTopicPage 话题页分页结果
+type ParamTopicPage struct {
+	Topic      string                  `json:"topic"`
+	Posts      []ParamPostWithUserInfo `json:"posts"`
+	NextCursor string                  `json:"next_cursor"` // 下一页游标，为空表示没有更多
+}
+
+// ParamRepostRequest 转发动态请求结构
+type ParamRepostRequest struct {
+	Content    string   `json:"content" binding:"max=500"`                                              // 转发评语，可为空
+	Visibility string   `json:"visibility" binding:"omitempty,oneof=public friends private allow deny"` // 默认friends
+	UserIDs    []string `json:"user_ids"`                                                               // visibility为allow/deny时的好友ID列表
+	GroupIDs   []string `json:"group_ids"`                                                              // visibility为allow/deny时的好友分组ID列表
+}
+
+// ParamDraftRequest 修改草稿请求结构
+type ParamDraftRequest struct {
+	Content    string   `json:"content" binding:"max=500"`
+	Visibility string   `json:"visibility" binding:"omitempty,oneof=public friends private allow deny"` // 默认friends
+	UserIDs    []string `json:"user_ids"`                                                               // visibility为allow/deny时的好友ID列表
+	GroupIDs   []string `json:"group_ids"`                                                              // visibility为allow/deny时的好友分组ID列表
+}
+
+// ParamScheduleDraftRequest 定时发布请求结构
+type ParamScheduleDraftRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at" binding:"required"` // 发布时间(RFC3339)，须在1分钟后且30天以内
+}
+
+// ParamDraftImage 草稿图片
+type ParamDraftImage struct {
+	ID int64 `json:"id,string"`
+	ParamPostImage
+}
+
+// ParamDraftItem 草稿与定时动态
+type ParamDraftItem struct {
+	ID          int64              `json:"id,string"`
+	Content     string             `json:"content"`
+	Visibility  string             `json:"visibility"`
+	Audience    *ParamPostAudience `json:"audience,omitempty"` // 自定义可见名单
+	Images      []ParamDraftImage  `json:"images"`
+	Status      string             `json:"status"`                   // draft/scheduled/publishing/failed
+	ScheduledAt *time.Time         `json:"scheduled_at"`             // 定时发布时间，未定时为空
+	LastError   string             `json:"last_error,omitempty"`     // 定时发布失败原因
+	PostID      int64              `json:"post_id,string,omitempty"` // 发布后的动态ID
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// ParamPollRequest 发布动态时附带的投票
+type ParamPollRequest struct {
+	Options   []string   // 选项内容，2~10个
+	Multiple  bool       // 是否多选
+	Anonymous bool       // 是否匿名投票
+	Deadline  *time.Time // 截止时间，为空表示不截止
+}
+
+// ParamPoll 动态中的投票，查看者投票前且投票未截止时不返回结果
+type ParamPoll struct {
+	ID            int64             `json:"id,string"`
+	Multiple      bool              `json:"multiple"`       // 是否多选
+	Anonymous     bool              `json:"anonymous"`      // 是否匿名投票
+	Deadline      *time.Time        `json:"deadline"`       // 截止时间，为空表示不截止
+	Closed        bool              `json:"closed"`         // 是否已截止
+	Voted         bool              `json:"voted"`          // 当前用户是否已投票
+	ResultVisible bool              `json:"result_visible"` // 是否可以查看结果
+	VoterCount    int64             `json:"voter_count"`    // 投票人数，结果不可见时为0
+	Options       []ParamPollOption `json:"options"`
+}
+
+// ParamPollOption 投票选项
+type ParamPollOption struct {
+	ID        int64  `json:"id,string"`
+	Content   string `json:"content"`
+	VoteCount int64  `json:"vote_count"` // 得票数，结果不可见时为0
+	Voted     bool   `json:"voted"`      // 当前用户是否选择了该选项
+}
+
+// ParamPollVoteRequest 投票请求结构
+type ParamPollVoteRequest struct {
+	OptionIDs []string `json:"option_ids" binding:"required,min=1"` // 选择的选项ID，单选投票只能选一个
+}
+
+// ParamPollVoter 投票人列表项(仅实名投票)
+type ParamPollVoter struct {
+	UserID   int64     `json:"user_id,string"`
+	Nickname string    `json:"nickname"` // 好友备注或用户名
+	Avatar   string    `json:"avatar"`
+	VotedAt  time.Time `json:"voted_at"`
+}
+
+// ParamReportRequest 举报请求结构
+type ParamReportRequest struct {
+	TargetType string     `json:"target_type" binding:"required,oneof=post message user"`                                                          // 举报对象类型
+	TargetID   string     `json:"target_id" binding:"required"`                                                                                    // 动态ID/用户ID/私信发送者ID
+	MessageAt  *time.Time `json:"message_at"`                                                                                                      // 举报私信时为私信的发送时间(与聊天记录中的created_at一致)
+	Reason     string     `json:"reason" binding:"required,oneof=spam harassment hate violence sexual illegal misinformation impersonation other"` // 举报原因
+	Detail     string     `json:"detail" binding:"max=500"`                                                                                        // 补充说明
+}
+
+// ParamModerationCase 审核工单列表项
+type ParamModerationCase struct {
+	ID             int64      `json:"id,string"`
+	TargetType     string     `json:"target_type"`
+	TargetID       int64      `json:"target_id,string"`
+	TargetUserID   int64      `json:"target_user_id,string"`
+	TargetUsername string     `json:"target_username"`
+	Status         string     `json:"status"`
+	ReportCount    int64      `json:"report_count"`
+	AssigneeID     int64      `json:"assignee_id,string"`
+	Resolution     string     `json:"resolution"`
+	Note           string     `json:"note"`
+	LastReportedAt time.Time  `json:"last_reported_at"`
+	ClosedAt       *time.Time `json:"closed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ParamReportItem 工单中的举报记录
+type ParamReportItem struct {
+	ReporterID   int64     `json:"reporter_id,string"`
+	ReporterName string    `json:"reporter_name"`
+	Reason       string    `json:"reason"`
+	Detail       string    `json:"detail"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ParamModerationUser 被举报用户的账号信息
+type ParamModerationUser struct {
+	UserID         int64      `json:"user_id,string"`
+	Username       string     `json:"username"`
+	AvatarURL      string     `json:"avatar_url"`
+	Signature      string     `json:"signature"`
+	CreatedAt      time.Time  `json:"created_at"`
+	SuspendedUntil *time.Time `json:"suspended_until"`
+}
+
+// ParamModerationCaseDetail 审核工单详情，包含被举报内容的上下文
+type ParamModerationCaseDetail struct {
+	Case        ParamModerationCase     `json:"case"`
+	Reports     []ParamReportItem       `json:"reports"`
+	Snapshot    *ReportSnapshot         `json:"snapshot"`               // 首次举报时的内容快照
+	Post        *ParamPostWithUserInfo  `json:"post,omitempty"`         // 被举报动态的当前状态(含作者已删除的动态)
+	Author      *ParamModerationUser    `json:"author"`                 // 被举报内容的作者
+	RecentPosts []ParamPostWithUserInfo `json:"recent_posts,omitempty"` // 举报账号时，该账号最近的动态
+	History     []ParamModerationLog    `json:"history"`                // 该作者此前受到的处置
+}
+
+// ParamModerationDecisionRequest 处理工单请求结构，内容与账号处置均为none时视为驳回
+type ParamModerationDecisionRequest struct {
+	ContentAction string `json:"content_action" binding:"omitempty,oneof=none hide delete"` // 内容处置：隐藏仅适用于动态
+	UserAction    string `json:"user_action" binding:"omitempty,oneof=none warn suspend"`   // 账号处置
+	SuspendDays   int    `json:"suspend_days" binding:"omitempty,min=1,max=365"`            // 封禁天数，user_action为suspend时必填
+	Note          string `json:"note" binding:"max=500"`                                    // 处理备注
+}
+
+// ParamModerationLog 审核日志列表项
+type ParamModerationLog struct {
+	ID           int64           `json:"id,string"`
+	CaseID       int64           `json:"case_id,string"`
+	ModeratorID  int64           `json:"moderator_id,string"`
+	Action       string          `json:"action"`
+	TargetType   string          `json:"target_type"`
+	TargetID     int64           `json:"target_id,string"`
+	TargetUserID int64           `json:"target_user_id,string"`
+	Detail       string          `json:"detail"`
+	Snapshot     *ReportSnapshot `json:"snapshot,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// ParamBookmarkRequest 收藏动态/移动收藏请求结构
+type ParamBookmarkRequest struct {
+	CollectionID string `json:"collection_id"` // 收藏夹ID，为空或0表示未分类
+}
+
+// ParamCollectionRequest 创建/重命名收藏夹请求结构
+type ParamCollectionRequest struct {
+	Name string `json:"name" binding:"required,max=32"`
+}
+
+// ParamCollectionItem 收藏夹列表项
+type ParamCollectionItem struct {
+	ID        int64     `json:"id,string"`
+	Name      string    `json:"name"`
+	Count     int64     `json:"count"` // 收藏数(含作者已删除或设为不可见的动态)
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ParamBookmarkItem 收藏列表项
+type ParamBookmarkItem struct {
+	ParamPostWithUserInfo
+	CollectionID int64     `json:"collection_id,string"` // 所属收藏夹ID，0为未分类
+	BookmarkedAt time.Time `json:"bookmarked_at"`        // 收藏时间
+}
+
+// ParamBookmarkPage 收藏列表分页结果
+type ParamBookmarkPage struct {
+	Bookmarks  []ParamBookmarkItem `json:"bookmarks"`
+	NextCursor string              `json:"next_cursor"` // 下一页游标，为空表示没有更多
+}
+
+// ParamBirthdayItem 好友生日提醒列表项
+type ParamBirthdayItem struct {
+	UserID    int64  `json:"user_id,string"`
+	Nickname  string `json:"nickname"`      // 好友备注或当前用户名
+	Avatar    string `json:"avatar"`        // 好友当前头像
+	Birthday  string `json:"birthday"`      // 生日，格式: 01-02
+	Date      string `json:"date"`          // 即将到来的生日日期，格式: 2006-01-02(2月29日出生的好友在平年为2月28日)
+	DaysUntil int    `json:"days_until"`    // 距离生日的天数，0表示今天
+	Age       int    `json:"age,omitempty"` // 即将年满的岁数，好友隐藏出生年份时不返回
+}
+
+// ParamMemories 那年今日
+type ParamMemories struct {
+	Date  string            `json:"date"`  // 今天的日期，格式: 2006-01-02
+	Years []ParamMemoryYear `json:"years"` // 按年份倒序排列，没有回忆的年份不返回
+}
+
+// ParamMemoryYear 某一年的今天发布的动态与添加的好友
+type ParamMemoryYear struct {
+	Year     int                     `json:"year"`
+	YearsAgo int                     `json:"years_ago"`
+	Posts    []ParamPostWithUserInfo `json:"posts"`
+	Friends  []ParamMemoryFriend     `json:"friends"`
+}
+
+// ParamMemoryFriend 那年今日添加的好友
+type ParamMemoryFriend struct {
+	UserID    int64     `json:"user_id,string"`
+	Nickname  string    `json:"nickname"` // 好友备注或当前用户名
+	Avatar    string    `json:"avatar"`
+	CreatedAt time.Time `json:"created_at"` // 成为好友的时间
+}
+
+// ParamFeedToken 订阅源令牌及各格式的订阅地址
+type ParamFeedToken struct {
+	Token     string    `json:"token"`
+	RSSURL    string    `json:"rss_url"`
+	AtomURL   string    `json:"atom_url"`
+	JSONURL   string    `json:"json_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ParamCreateAlbumRequest 创建相册请求
+type ParamCreateAlbumRequest struct {
+	Name        string `json:"name" binding:"required,max=32"`
+	Description string `json:"description" binding:"max=255"`
+	Visibility  string `json:"visibility"` // 可见范围: public/friends/private，默认friends
+}
+
+// ParamUpdateAlbumRequest 修改相册请求，未传字段保持不变
+type ParamUpdateAlbumRequest struct {
+	Name         *string `json:"name" binding:"omitempty,min=1,max=32"`
+	Description  *string `json:"description" binding:"omitempty,max=255"`
+	Visibility   *string `json:"visibility"`     // 可见范围: public/friends/private
+	CoverPhotoID *string `json:"cover_photo_id"` // 封面照片ID(须为该相册中的照片)，"0"为使用最新的照片
+}
+
+// ParamAlbumItem 相册列表项
+type ParamAlbumItem struct {
+	ID          int64     `json:"id,string"` // 动态相册为0
+	UserID      int64     `json:"user_id,string"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Visibility  string    `json:"visibility"` // 动态相册为空，其中每张图片的可见范围同所属动态
+	Auto        bool      `json:"auto"`       // 是否为自动生成的动态相册
+	CoverURL    string    `json:"cover_url"`  // 封面缩略图，相册为空时为空
+	PhotoCount  int64     `json:"photo_count"`
+	CreatedAt   time.Time `json:"created_at"` // 动态相册为最新一张图片的上传时间
+}
+
+// ParamPhotoItem 照片列表项
+type ParamPhotoItem struct {
+	ID        int64     `json:"id,string"`
+	AlbumID   int64     `json:"album_id,string"` // 动态相册的图片为0
+	PostID    int64     `json:"post_id,string"`  // 动态相册的图片所属动态ID，相册照片为0
+	URL       string    `json:"url"`
+	MediumURL string    `json:"medium_url"`
+	ThumbURL  string    `json:"thumb_url"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	Caption   string    `json:"caption"`
+	ViewCount uint64    `json:"view_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ParamPhotoCaptionRequest 修改照片说明请求
+type ParamPhotoCaptionRequest struct {
+	Caption string `json:"caption" binding:"max=255"`
+}
+
+// ParamMovePhotosRequest 移动照片请求
+type ParamMovePhotosRequest struct {
+	PhotoIDs []string `json:"photo_ids" binding:"required,min=1,max=100"`
+	AlbumID  string   `json:"album_id" binding:"required"` // 目标相册ID
+}
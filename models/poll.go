@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Poll 动态附带的投票，每条动态最多一个投票，发布后不可修改
+type Poll struct {
+	ID         int64        `gorm:"primaryKey;autoIncrement" json:"id,string"`
+	PostID     int64        `gorm:"uniqueIndex;not null;comment:动态ID" json:"-"`
+	Multiple   bool         `gorm:"not null;default:false;comment:是否多选" json:"multiple"`
+	Anonymous  bool         `gorm:"not null;default:false;comment:是否匿名投票" json:"anonymous"`
+	Deadline   *time.Time   `gorm:"comment:截止时间，为空表示不截止" json:"deadline"`
+	VoterCount int64        `gorm:"not null;default:0;comment:投票人数" json:"-"`
+	Options    []PollOption `gorm:"foreignKey:PollID" json:"options"`
+	CreatedAt  time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// Closed 判断投票是否已截止
+func (p *Poll) Closed(now time.Time) bool {
+	return p.Deadline != nil && !now.Before(*p.Deadline)
+}
+
+// PollOption 投票选项
+type PollOption struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement" json:"id,string"`
+	PollID    int64  `gorm:"index:idx_poll_sort;not null;comment:投票ID" json:"-"`
+	Content   string `gorm:"type:varchar(50);not null;comment:选项内容" json:"content"`
+	Sort      int    `gorm:"index:idx_poll_sort;not null;default:0;comment:选项顺序" json:"-"`
+	VoteCount int64  `gorm:"not null;default:0;comment:得票数" json:"-"`
+}
+
+// PollVoter 投票人，每人每个投票只有一条记录，用唯一索引保证只能投一次
+type PollVoter struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	PollID    int64     `gorm:"uniqueIndex:idx_poll_user;not null;comment:投票ID"`
+	UserID    int64     `gorm:"uniqueIndex:idx_poll_user;not null;comment:投票用户ID"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// PollVote 投票人选择的选项，多选投票一人可对应多条
+type PollVote struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	PollID    int64     `gorm:"index:idx_poll_user;not null;comment:投票ID"`
+	OptionID  int64     `gorm:"index:idx_option_created;not null;comment:选项ID"`
+	UserID    int64     `gorm:"index:idx_poll_user;not null;comment:投票用户ID"`
+	CreatedAt time.Time `gorm:"index:idx_option_created;autoCreateTime"`
+}
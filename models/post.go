@@ -1,17 +1,45 @@
 package models
 
 import (
+	"gorm.io/gorm"
 	"time"
 )
 
-// Post 用户动态模型
+// 动态可见范围
+const (
+	PostVisibilityPublic  = "public"  // 公开，关注者与任何人可见
+	PostVisibilityFriends = "friends" // 仅好友可见
+	PostVisibilityPrivate = "private" // 仅自己可见
+	PostVisibilityAllow   = "allow"   // 部分好友可见(PostAudience名单内)
+	PostVisibilityDeny    = "deny"    // 不给谁看(PostAudience名单内不可见)
+	PostVisibilityHidden  = "hidden"  // 被管理员隐藏，仅作者可见且不能再修改可见范围
+)
+
+// IsValidPostVisibility 判断可见范围是否合法
+func IsValidPostVisibility(visibility string) bool {
+	switch visibility {
+	case PostVisibilityPublic, PostVisibilityFriends, PostVisibilityPrivate, PostVisibilityAllow, PostVisibilityDeny:
+		return true
+	}
+	return false
+}
+
+// Post 用户动态模型，只保存作者ID，作者昵称与头像在展示时通过资料缓存获取
 type Post struct {
-	ID        int64     `gorm:"primaryKey" json:"-"`             // 动态ID
-	UserID    int64     `json:"user_id,string"`                  // 发布用户ID
-	Username  string    `json:"username"`                        // 发布用户昵称
-	AvatarURL string    `json:"avatar_url"`                      //发布用户头像
-	Content   string    `gorm:"type:text" json:"content"`        // 文字内容
-	Images    string    `gorm:"type:varchar(255)" json:"images"` // 图片URL，多个用逗号分隔
-	ViewCount uint64    `json:"view_count"`                      // 浏览量
-	CreatedAt time.Time `json:"created_at"`                      // 发布时间
+	ID         int64          `gorm:"primaryKey" json:"-"`                                                 // 动态ID
+	UserID     int64          `json:"user_id,string"`                                                      // 发布用户ID
+	Content    string         `gorm:"type:text" json:"content"`                                            // 文字内容
+	Images     string         `gorm:"type:varchar(255)" json:"images"`                                     // 旧版图片URL，多个用逗号分隔，新动态的图片见ImageList
+	ViewCount  uint64         `json:"view_count"`                                                          // 浏览量
+	LikeCount  uint64         `gorm:"not null;default:0" json:"like_count"`                                // 点赞数(由Redis定期回写)
+	Visibility string         `gorm:"type:varchar(16);index;not null;default:'friends'" json:"visibility"` // 可见范围
+	RepostOfID int64          `gorm:"index;not null;default:0" json:"repost_of_id,string"`                 // 转发的原动态ID，0表示原创
+	CreatedAt  time.Time      `json:"created_at"`                                                          // 发布时间
+	EditedAt   *time.Time     `json:"edited_at"`                                                           // 最后编辑时间，未编辑为空
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`                                                      // 删除时间(软删除，进入回收站)
+
+	ImageList []PostImage `gorm:"foreignKey:PostID" json:"image_list,omitempty"` // 动态图片(按Sort排序)
+	Poll      *Poll       `gorm:"foreignKey:PostID" json:"poll,omitempty"`       // 动态附带的投票
+
+	DraftID int64 `gorm:"-" json:"-"` // 由草稿发布时为草稿ID，保存动态时在同一事务中将草稿标记为已发布
 }
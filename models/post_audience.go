@@ -0,0 +1,16 @@
+package models
+
+// 动态自定义可见名单的对象类型
+const (
+	AudienceTargetUser  = "user"  // 指定好友
+	AudienceTargetGroup = "group" // 指定好友分组
+)
+
+// PostAudience 动态的自定义可见名单
+// 动态可见范围为allow时仅名单内可见，为deny时名单内不可见；分组成员在查询时解析，分组调整后立即生效
+type PostAudience struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement" json:"-"`
+	PostID     int64  `gorm:"index;not null;comment:动态ID" json:"-"`
+	TargetType string `gorm:"type:varchar(8);index:idx_target;not null;comment:对象类型(user/group)" json:"-"`
+	TargetID   int64  `gorm:"index:idx_target;not null;comment:用户ID或分组ID" json:"-"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// 草稿状态
+const (
+	DraftStatusDraft      = "draft"      // 草稿
+	DraftStatusScheduled  = "scheduled"  // 等待定时发布
+	DraftStatusPublishing = "publishing" // 定时任务发布中
+	DraftStatusPublished  = "published"  // 已发布
+	DraftStatusFailed     = "failed"     // 定时发布失败，可修改后重新定时或立即发布
+)
+
+// PostDraft 动态草稿与定时发布任务，图片保存在PostImage中(DraftID关联，PostID为0)
+type PostDraft struct {
+	ID              int64      `gorm:"primaryKey;autoIncrement"`
+	UserID          int64      `gorm:"index;not null;comment:作者ID"`
+	Content         string     `gorm:"type:text;comment:文字内容"`
+	Visibility      string     `gorm:"type:varchar(16);not null;default:'friends';comment:可见范围"`
+	AudienceUserIDs string     `gorm:"type:varchar(2048);not null;default:'';comment:自定义可见的好友ID，逗号分隔"`
+	AudienceGroups  string     `gorm:"type:varchar(1024);not null;default:'';comment:自定义可见的好友分组ID，逗号分隔"`
+	Status          string     `gorm:"type:varchar(16);index:idx_status_scheduled;not null;default:'draft';comment:状态"`
+	ScheduledAt     *time.Time `gorm:"index:idx_status_scheduled;comment:定时发布时间"`
+	PostID          int64      `gorm:"not null;default:0;comment:发布后的动态ID"`
+	LastError       string     `gorm:"type:varchar(255);not null;default:'';comment:定时发布失败原因"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// PostEdit 动态编辑历史，记录每次编辑前的内容
+type PostEdit struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	PostID    int64     `gorm:"index;not null;comment:动态ID" json:"-"`
+	Content   string    `gorm:"type:text;comment:编辑前的内容" json:"content"`
+	CreatedAt time.Time `gorm:"autoCreateTime;comment:编辑时间" json:"edited_at"`
+}
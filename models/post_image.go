@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// PostImage 动态图片，保存去除元数据后的原图及中图、缩略图
+type PostImage struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	PostID    int64     `gorm:"index:idx_post_sort;not null;default:0;comment:动态ID" json:"-"`
+	UserID    int64     `gorm:"index;not null;comment:上传用户ID" json:"-"`
+	DraftID   int64     `gorm:"index;not null;default:0;comment:草稿ID，发布后清零" json:"-"`
+	URL       string    `gorm:"type:varchar(255);not null;comment:原图URL" json:"url"`
+	MediumURL string    `gorm:"type:varchar(255);not null;comment:中图URL" json:"medium_url"`
+	ThumbURL  string    `gorm:"type:varchar(255);not null;comment:缩略图URL" json:"thumb_url"`
+	Width     int       `gorm:"not null;comment:原图宽度" json:"width"`
+	Height    int       `gorm:"not null;comment:原图高度" json:"height"`
+	Size      int64     `gorm:"not null;comment:原图字节数" json:"size"`
+	Format    string    `gorm:"type:varchar(8);not null;comment:图片格式" json:"format"`
+	Sort      int       `gorm:"index:idx_post_sort;not null;default:0;comment:图片顺序" json:"sort"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"-"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PostVisit 动态访客记录，每个用户对每条动态保留一条，记录最近一次浏览时间
+type PostVisit struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	PostID    int64     `gorm:"uniqueIndex:idx_post_viewer;not null;comment:动态ID" json:"post_id,string"`
+	ViewerID  int64     `gorm:"uniqueIndex:idx_post_viewer;not null;comment:访客ID" json:"viewer_id,string"`
+	VisitedAt time.Time `gorm:"index;not null;comment:最近浏览时间" json:"visited_at"`
+
+	// 关联访客信息（非数据库字段）
+	Viewer User `gorm:"foreignKey:ViewerID;references:UserID" json:"-"`
+}
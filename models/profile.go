@@ -0,0 +1,8 @@
+package models
+
+// UserProfile 用户展示信息，动态、评论、消息等只保存用户ID，展示时通过资料缓存获取
+type UserProfile struct {
+	UserID    int64  `json:"user_id,string"`
+	Username  string `json:"username"`
+	AvatarURL string `json:"avatar_url"`
+}
@@ -0,0 +1,123 @@
+package models
+
+import "time"
+
+// 举报对象类型
+const (
+	ReportTargetPost    = "post"    // 动态
+	ReportTargetMessage = "message" // 私信
+	ReportTargetUser    = "user"    // 账号
+)
+
+// 举报原因
+const (
+	ReportReasonSpam           = "spam"           // 垃圾广告
+	ReportReasonHarassment     = "harassment"     // 骚扰辱骂
+	ReportReasonHate           = "hate"           // 仇恨言论
+	ReportReasonViolence       = "violence"       // 暴力血腥
+	ReportReasonSexual         = "sexual"         // 色情低俗
+	ReportReasonIllegal        = "illegal"        // 违法违规
+	ReportReasonMisinformation = "misinformation" // 虚假信息
+	ReportReasonImpersonation  = "impersonation"  // 冒充他人
+	ReportReasonOther          = "other"          // 其他
+)
+
+// 审核工单状态
+const (
+	CaseStatusPending   = "pending"   // 待处理
+	CaseStatusReviewing = "reviewing" // 处理中(已被管理员认领)
+	CaseStatusResolved  = "resolved"  // 已处理(执行了处置)
+	CaseStatusDismissed = "dismissed" // 已驳回(举报不成立)
+)
+
+// 审核操作，记录在审核日志中
+const (
+	ModerationActionClaim   = "claim"   // 认领工单
+	ModerationActionDismiss = "dismiss" // 驳回举报
+	ModerationActionHide    = "hide"    // 隐藏内容
+	ModerationActionDelete  = "delete"  // 删除内容
+	ModerationActionWarn    = "warn"    // 警告用户
+	ModerationActionSuspend = "suspend" // 封禁用户
+)
+
+// 用户角色
+const (
+	UserRoleUser  = "user"  // 普通用户
+	UserRoleAdmin = "admin" // 管理员
+)
+
+// IsValidReportReason 判断举报原因是否合法
+func IsValidReportReason(reason string) bool {
+	switch reason {
+	case ReportReasonSpam, ReportReasonHarassment, ReportReasonHate, ReportReasonViolence, ReportReasonSexual,
+		ReportReasonIllegal, ReportReasonMisinformation, ReportReasonImpersonation, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+// ModerationCase 审核工单，同一对象未处理的举报归入同一个工单
+type ModerationCase struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement"`
+	TargetType     string     `gorm:"type:varchar(16);not null;comment:举报对象类型"`
+	TargetID       int64      `gorm:"index:idx_target;not null;comment:动态ID/用户ID/私信发送者ID"`
+	TargetUserID   int64      `gorm:"index;not null;comment:被举报内容的作者ID"`
+	MessageTo      int64      `gorm:"not null;default:0;comment:私信接收者ID"`
+	MessageAt      int64      `gorm:"not null;default:0;comment:私信发送时间(纳秒)"`
+	OpenKey        *string    `gorm:"type:varchar(96);uniqueIndex;comment:未结案时为对象标识，结案后置空，保证同一对象只有一个未结工单"`
+	Status         string     `gorm:"type:varchar(16);index:idx_status_reported;not null;default:'pending';comment:工单状态"`
+	ReportCount    int64      `gorm:"not null;default:0;comment:举报次数"`
+	AssigneeID     int64      `gorm:"not null;default:0;comment:认领的管理员ID"`
+	Resolution     string     `gorm:"type:varchar(64);not null;default:'';comment:执行的处置，多个用逗号分隔"`
+	Note           string     `gorm:"type:varchar(500);not null;default:'';comment:处理备注"`
+	LastReportedAt time.Time  `gorm:"index:idx_status_reported;comment:最近举报时间"`
+	ClosedAt       *time.Time `gorm:"comment:结案时间"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+}
+
+// Report 用户举报记录，同一用户对同一工单只能举报一次
+type Report struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement"`
+	CaseID     int64     `gorm:"uniqueIndex:idx_case_reporter;not null;comment:审核工单ID"`
+	ReporterID int64     `gorm:"uniqueIndex:idx_case_reporter;index;not null;comment:举报人ID"`
+	Reason     string    `gorm:"type:varchar(32);not null;comment:举报原因"`
+	Detail     string    `gorm:"type:varchar(500);not null;default:'';comment:补充说明"`
+	Snapshot   string    `gorm:"type:text;comment:举报时的内容快照(JSON)"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// ModerationLog 审核日志，记录管理员的每一次操作
+type ModerationLog struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement"`
+	CaseID       int64     `gorm:"index;not null;comment:审核工单ID"`
+	ModeratorID  int64     `gorm:"index;not null;comment:操作的管理员ID"`
+	Action       string    `gorm:"type:varchar(16);not null;comment:操作类型"`
+	TargetType   string    `gorm:"type:varchar(16);not null;comment:举报对象类型"`
+	TargetID     int64     `gorm:"not null;comment:举报对象ID"`
+	TargetUserID int64     `gorm:"index:idx_target_user_created;not null;comment:被处置用户ID"`
+	Detail       string    `gorm:"type:varchar(500);not null;default:'';comment:操作说明(封禁时长、备注等)"`
+	Snapshot     string    `gorm:"type:text;comment:操作时的内容快照(JSON)"`
+	CreatedAt    time.Time `gorm:"index:idx_target_user_created;autoCreateTime"`
+}
+
+// ReportSnapshot 举报或处置时保存的内容快照，内容被修改或删除后仍可追溯
+type ReportSnapshot struct {
+	Content   string            `json:"content,omitempty"`    // 动态内容
+	Images    []string          `json:"images,omitempty"`     // 动态图片
+	Username  string            `json:"username,omitempty"`   // 账号用户名
+	Signature string            `json:"signature,omitempty"`  // 账号签名
+	AvatarURL string            `json:"avatar_url,omitempty"` // 账号头像
+	Messages  []SnapshotMessage `json:"messages,omitempty"`   // 被举报私信及其前后的聊天记录
+	CreatedAt *time.Time        `json:"created_at,omitempty"` // 内容发布时间
+}
+
+// SnapshotMessage 快照中的私信
+type SnapshotMessage struct {
+	From      int64     `json:"from,string"`
+	To        int64     `json:"to,string"`
+	Type      int       `json:"type"`
+	Content   string    `json:"content"`
+	FileURL   string    `json:"file_url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Reported  bool      `json:"reported,omitempty"` // 是否为被举报的私信
+}
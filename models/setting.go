@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// UserSetting 用户隐私与偏好设置(每个用户一条记录，无记录时使用默认值)
+type UserSetting struct {
+	ID                    int64     `gorm:"primaryKey;autoIncrement" json:"-"`
+	UserID                int64     `gorm:"uniqueIndex;not null;comment:用户ID" json:"user_id,string"`
+	AllowSearchByUsername bool      `gorm:"default:true;comment:允许通过用户名被搜索" json:"allow_search_by_username"`
+	AllowSearchByEmail    bool      `gorm:"default:true;comment:允许通过邮箱被搜索" json:"allow_search_by_email"`
+	AllowSearchByUID      bool      `gorm:"default:true;comment:允许通过UID被搜索" json:"allow_search_by_uid"`
+	FollowNeedApproval    bool      `gorm:"default:false;comment:新的关注需要审核" json:"follow_need_approval"`
+	HideVisitRecord       bool      `gorm:"default:false;comment:浏览他人动态时不留下访客记录" json:"hide_visit_record"`
+	HideBirthYear         bool      `gorm:"default:false;comment:不向好友展示出生年份(年龄)" json:"hide_birth_year"`
+	HideBirthday          bool      `gorm:"default:false;comment:不向好友展示生日，也不发送生日提醒" json:"hide_birthday"`
+	DisableMemories       bool      `gorm:"default:false;comment:关闭那年今日" json:"disable_memories"`
+	MemoryNotification    bool      `gorm:"default:true;comment:有那年今日的回忆时发送通知" json:"memory_notification"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// DefaultUserSetting 返回用户的默认设置
+func DefaultUserSetting(userID int64) UserSetting {
+	return UserSetting{
+		UserID:                userID,
+		AllowSearchByUsername: true,
+		AllowSearchByEmail:    true,
+		AllowSearchByUID:      true,
+		MemoryNotification:    true,
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Topic 话题，由动态内容中的 #话题# 自动创建
+type Topic struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id,string"`
+	Name      string    `gorm:"uniqueIndex;type:varchar(64);not null;comment:话题名称" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// PostTopic 动态与话题的关联
+type PostTopic struct {
+	ID      int64 `gorm:"primaryKey;autoIncrement"`
+	TopicID int64 `gorm:"uniqueIndex:idx_topic_post;not null;comment:话题ID"`
+	PostID  int64 `gorm:"uniqueIndex:idx_topic_post;index;not null;comment:动态ID"`
+}
+
+// PostMention 动态中@提及的用户，内容中以 @[用户ID] 保存，展示时替换为当前用户名
+type PostMention struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	PostID    int64     `gorm:"uniqueIndex:idx_post_user;not null;comment:动态ID"`
+	UserID    int64     `gorm:"uniqueIndex:idx_post_user;index;not null;comment:被提及用户ID"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -17,6 +17,9 @@ type User struct {
 	Birthday     *time.Time `gorm:"type:DATE" json:"birthday"`
 	CreatedAt    time.Time  `gorm:"column:create_at;autoCreateTime" json:"created_at"`
 	LastLogin    *time.Time `gorm:"column:last_login" json:"last_login"`
+
+	Role           string     `gorm:"type:varchar(16);not null;default:'user'" json:"-"` // 角色(user/admin)，管理员可处理举报
+	SuspendedUntil *time.Time `json:"-"`                                                 // 封禁截止时间，为空表示未封禁
 }
 
 //type User struct {
@@ -0,0 +1,245 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // 注册WebP解码器
+)
+
+// 支持的图片格式
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+	FormatWebP = "webp"
+)
+
+const (
+	MaxPixels   = 40000000 // 允许的最大像素数，防止解压炸弹
+	jpegQuality = 88       // 重新编码JPEG的质量
+)
+
+// GIF动图逐帧解码，除画布尺寸外还需限制帧数与全部帧的像素总数
+const (
+	MaxGIFFrames = 300       // GIF允许的最大帧数
+	MaxGIFPixels = 100000000 // GIF全部帧的像素总数上限
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("不支持的图片格式")
+	ErrImageTooLarge     = errors.New("图片尺寸过大")
+)
+
+// Variant 一种尺寸的图片
+type Variant struct {
+	Data   []byte
+	Ext    string // 文件扩展名(含点)
+	Width  int
+	Height int
+}
+
+// Result 图片处理结果
+type Result struct {
+	Format   string  // 原图的真实格式
+	Original Variant // 去除EXIF/GPS等元数据后的原图
+	Medium   Variant // 中图
+	Thumb    Variant // 缩略图
+}
+
+// Process 校验图片的真实格式，去除元数据并生成中图与缩略图
+// mediumSize与thumbSize为长边的最大像素，原图小于该尺寸时直接复用原图
+func Process(data []byte, mediumSize, thumbSize int) (*Result, error) {
+	format := DetectFormat(data)
+	if format == "" {
+		return nil, ErrUnsupportedFormat
+	}
+	cfg, decodedFormat, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decodedFormat != format {
+		return nil, ErrUnsupportedFormat
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	// 动态WebP无法解码，按不支持的格式处理
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	// 1. 生成去除元数据的原图
+	var original Variant
+	switch format {
+	case FormatJPEG:
+		// 重新编码会丢弃EXIF，先按EXIF方向摆正图片
+		img = applyOrientation(img, jpegOrientation(data))
+		original, err = encodeJPEG(img)
+	case FormatPNG:
+		// 重新编码只保留像素数据，丢弃tEXt/eXIf等附加块
+		original, err = encodePNG(img)
+	case FormatGIF:
+		original, err = reencodeGIF(data, cfg)
+	case FormatWebP:
+		original, err = stripWebP(data, cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 生成中图与缩略图(动图取第一帧)
+	medium, err := resizeVariant(img, original, mediumSize)
+	if err != nil {
+		return nil, err
+	}
+	thumb, err := resizeVariant(img, original, thumbSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Format: format, Original: original, Medium: medium, Thumb: thumb}, nil
+}
+
+// DetectFormat 根据文件头识别图片的真实格式，无法识别时返回空字符串
+func DetectFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return FormatJPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return FormatPNG
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return FormatGIF
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return FormatWebP
+	}
+	return ""
+}
+
+// resizeVariant 将图片等比缩放到长边不超过size，原图不超过size时直接复用原图
+func resizeVariant(img image.Image, original Variant, size int) (Variant, error) {
+	w, h := original.Width, original.Height
+	if w <= size && h <= size {
+		return original, nil
+	}
+	if w >= h {
+		w, h = size, max(1, h*size/w)
+	} else {
+		w, h = max(1, w*size/h), size
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+	// 不透明图片使用JPEG以减小体积，带透明通道的使用PNG
+	if isOpaque(img) {
+		return encodeJPEG(dst)
+	}
+	return encodePNG(dst)
+}
+
+// isOpaque 判断图片是否完全不透明
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+func encodeJPEG(img image.Image) (Variant, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return Variant{}, err
+	}
+	b := img.Bounds()
+	return Variant{Data: buf.Bytes(), Ext: ".jpg", Width: b.Dx(), Height: b.Dy()}, nil
+}
+
+func encodePNG(img image.Image) (Variant, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return Variant{}, err
+	}
+	b := img.Bounds()
+	return Variant{Data: buf.Bytes(), Ext: ".png", Width: b.Dx(), Height: b.Dy()}, nil
+}
+
+// reencodeGIF 重新编码GIF以丢弃注释与应用扩展块(如XMP)，保留全部帧
+func reencodeGIF(data []byte, cfg image.Config) (Variant, error) {
+	if err := checkGIFFrames(data, cfg); err != nil {
+		return Variant{}, err
+	}
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return Variant{}, ErrUnsupportedFormat
+	}
+	var buf bytes.Buffer
+	if err = gif.EncodeAll(&buf, g); err != nil {
+		return Variant{}, err
+	}
+	return Variant{Data: buf.Bytes(), Ext: ".gif", Width: cfg.Width, Height: cfg.Height}, nil
+}
+
+// checkGIFFrames 解码前遍历GIF的数据块，统计帧数与全部帧的像素总数，并检查每帧是否超出画布
+// 只读取块结构，不解压图像数据
+func checkGIFFrames(data []byte, cfg image.Config) error {
+	// 文件头6字节，逻辑屏幕描述符7字节，之后是全局颜色表
+	i := 13
+	if len(data) < i {
+		return ErrUnsupportedFormat
+	}
+	if data[10]&0x80 != 0 {
+		i += 3 << (data[10]&0x07 + 1)
+	}
+	frames, pixels := 0, 0
+	for i < len(data) {
+		switch data[i] {
+		case 0x21: // 扩展块：标签之后是若干子块
+			if i+2 > len(data) {
+				return ErrUnsupportedFormat
+			}
+			i += 2
+		case 0x2C: // 图像描述符：位置与尺寸、局部颜色表，之后是LZW最小码长与若干图像数据子块
+			if i+11 > len(data) {
+				return ErrUnsupportedFormat
+			}
+			left := int(binary.LittleEndian.Uint16(data[i+1 : i+3]))
+			top := int(binary.LittleEndian.Uint16(data[i+3 : i+5]))
+			w := int(binary.LittleEndian.Uint16(data[i+5 : i+7]))
+			h := int(binary.LittleEndian.Uint16(data[i+7 : i+9]))
+			if left+w > cfg.Width || top+h > cfg.Height {
+				return ErrImageTooLarge
+			}
+			frames++
+			pixels += w * h
+			if frames > MaxGIFFrames || pixels > MaxGIFPixels {
+				return ErrImageTooLarge
+			}
+			packed := data[i+9]
+			i += 10
+			if packed&0x80 != 0 {
+				i += 3 << (packed&0x07 + 1)
+			}
+			i++ // LZW最小码长
+		case 0x3B: // 结束标记
+			return nil
+		default:
+			return ErrUnsupportedFormat
+		}
+		// 跳过子块，长度为0的子块表示结束
+		for {
+			if i >= len(data) {
+				return ErrUnsupportedFormat
+			}
+			size := int(data[i])
+			i += 1 + size
+			if size == 0 {
+				break
+			}
+		}
+	}
+	// 缺少结束标记时交由解码器判断数据是否完整
+	return nil
+}
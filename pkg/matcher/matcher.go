@@ -0,0 +1,139 @@
+package matcher
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mozillazg/go-pinyin"
+)
+
+// 匹配得分，分值越高匹配度越高，0表示不匹配
+const (
+	ScoreNone           = iota
+	ScoreFuzzy          // 容错匹配(编辑距离)
+	ScorePinyinContains // 拼音包含匹配
+	ScorePinyinPrefix   // 拼音/首字母前缀匹配
+	ScoreContains       // 包含匹配
+	ScorePrefix         // 前缀匹配
+	ScoreExact          // 完全匹配
+)
+
+// pinyinArgs 全拼不带声调，多音字取第一个读音
+var pinyinArgs = pinyin.NewArgs()
+
+// Score 计算关键字与名称的匹配得分
+// 优先级：完全匹配 > 前缀匹配 > 包含匹配 > 拼音(全拼/首字母)前缀 > 拼音包含 > 容错匹配
+// 所有比较均按rune进行，不会截断多字节字符，英文字母忽略大小写
+func Score(name, keyword string) int {
+	name = strings.ToLower(strings.TrimSpace(name))
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	if name == "" || keyword == "" {
+		return ScoreNone
+	}
+
+	// 1. 原文匹配
+	switch {
+	case name == keyword:
+		return ScoreExact
+	case strings.HasPrefix(name, keyword):
+		return ScorePrefix
+	case strings.Contains(name, keyword):
+		return ScoreContains
+	}
+
+	// 2. 拼音匹配(关键字不含汉字时才有意义)
+	full, initials := ToPinyin(name)
+	if !containsHan(keyword) {
+		switch {
+		case strings.HasPrefix(full, keyword), strings.HasPrefix(initials, keyword):
+			return ScorePinyinPrefix
+		case strings.Contains(full, keyword), strings.Contains(initials, keyword):
+			return ScorePinyinContains
+		}
+	}
+
+	// 3. 容错匹配
+	kw := []rune(keyword)
+	maxDist := maxDistance(len(kw))
+	if maxDist == 0 {
+		return ScoreNone
+	}
+	if prefixDistance(kw, []rune(name)) <= maxDist || prefixDistance(kw, []rune(full)) <= maxDist {
+		return ScoreFuzzy
+	}
+	return ScoreNone
+}
+
+// ToPinyin 将字符串转换为全拼与首字母，非汉字字符原样保留(小写)
+func ToPinyin(s string) (full, initials string) {
+	var fb, ib strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if !unicode.Is(unicode.Han, r) {
+			if unicode.IsSpace(r) {
+				continue
+			}
+			fb.WriteRune(r)
+			ib.WriteRune(r)
+			continue
+		}
+		py := pinyin.SinglePinyin(r, pinyinArgs)
+		if len(py) == 0 || py[0] == "" {
+			fb.WriteRune(r)
+			ib.WriteRune(r)
+			continue
+		}
+		fb.WriteString(py[0])
+		ib.WriteByte(py[0][0])
+	}
+	return fb.String(), ib.String()
+}
+
+// containsHan 判断字符串是否包含汉字
+func containsHan(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxDistance 根据关键字长度确定允许的最大编辑距离，过短的关键字不做容错
+func maxDistance(n int) int {
+	switch {
+	case n <= 2:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// prefixDistance 计算关键字与目标字符串任意前缀之间的最小编辑距离
+// 这样输入 "zhnags" 也能匹配 "zhangsan" 的前缀 "zhangs"
+func prefixDistance(keyword, target []rune) int {
+	// 标准Levenshtein动态规划，prev[j]为keyword前i个字符与target前j个字符的距离
+	prev := make([]int, len(target)+1)
+	cur := make([]int, len(target)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(keyword); i++ {
+		cur[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if keyword[i-1] == target[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	// 取关键字与所有前缀距离中的最小值
+	best := prev[0]
+	for _, d := range prev[1:] {
+		best = min(best, d)
+	}
+	return best
+}
@@ -0,0 +1,133 @@
+package sensitive
+
+import "unicode"
+
+// node Aho-Corasick自动机节点
+type node struct {
+	children map[rune]*node
+	fail     *node // 失配指针：当前路径的最长真后缀对应的节点
+	output   *node // 沿失配链最近的词尾节点，用于一次性取出所有命中的短词
+	word     int   // 以该节点结尾的词在words中的下标，-1表示不是词尾
+	depth    int   // 节点深度，即词的rune长度
+}
+
+func newNode(depth int) *node {
+	return &node{children: make(map[rune]*node), word: -1, depth: depth}
+}
+
+// automaton 多模式匹配自动机，构建后只读，可并发使用
+type automaton struct {
+	root *node
+}
+
+// span 一次命中在原文中的位置(rune下标，左闭右开)
+type span struct {
+	word       int
+	start, end int
+}
+
+// newAutomaton 用归一化后的词语构建自动机，words[i]为空时忽略
+func newAutomaton(words [][]rune) *automaton {
+	root := newNode(0)
+	for i, w := range words {
+		if len(w) == 0 {
+			continue
+		}
+		cur := root
+		for _, r := range w {
+			next, ok := cur.children[r]
+			if !ok {
+				next = newNode(cur.depth + 1)
+				cur.children[r] = next
+			}
+			cur = next
+		}
+		if cur.word < 0 {
+			cur.word = i
+		}
+	}
+
+	// 按层序构建失配指针与输出链
+	queue := make([]*node, 0, len(root.children))
+	for _, child := range root.children {
+		child.fail = root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range cur.children {
+			f := cur.fail
+			for f != root && f.children[r] == nil {
+				f = f.fail
+			}
+			if next := f.children[r]; next != nil {
+				child.fail = next
+			} else {
+				child.fail = root
+			}
+			if child.fail.word >= 0 {
+				child.output = child.fail
+			} else {
+				child.output = child.fail.output
+			}
+			queue = append(queue, child)
+		}
+	}
+	return &automaton{root: root}
+}
+
+// match 在文本中查找所有命中的词，匹配时忽略大小写、全半角以及夹杂的空白与标点
+// 返回的位置对应原文的rune下标，包含词语中间夹杂的符号
+func (a *automaton) match(text []rune) []span {
+	var spans []span
+	// pos记录参与匹配的字符在原文中的下标，跳过的符号不参与匹配
+	pos := make([]int, 0, len(text))
+	cur := a.root
+	for i, r := range text {
+		r, ok := normalizeRune(r)
+		if !ok {
+			continue
+		}
+		pos = append(pos, i)
+		for cur != a.root && cur.children[r] == nil {
+			cur = cur.fail
+		}
+		if next := cur.children[r]; next != nil {
+			cur = next
+		}
+		n := len(pos)
+		for out := cur; out != nil; out = out.output {
+			if out.word < 0 {
+				continue
+			}
+			spans = append(spans, span{word: out.word, start: pos[n-out.depth], end: i + 1})
+		}
+	}
+	return spans
+}
+
+// normalize 归一化词语，去除不参与匹配的字符
+func normalize(s string) []rune {
+	result := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r, ok := normalizeRune(r); ok {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// normalizeRune 全角转半角、转小写；空白、标点与符号等分隔字符返回false
+func normalizeRune(r rune) (rune, bool) {
+	switch {
+	case r == 0x3000:
+		r = ' '
+	case r >= 0xFF01 && r <= 0xFF5E:
+		r -= 0xFEE0
+	}
+	if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+		return r, false
+	}
+	return unicode.ToLower(r), true
+}
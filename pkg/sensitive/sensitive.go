@@ -0,0 +1,195 @@
+package sensitive
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// 敏感词分类的处理策略
+const (
+	PolicyReject = "reject" // 拒绝提交
+	PolicyMask   = "mask"   // 替换为***后保存
+	PolicyReview = "review" // 照常保存并标记待审核
+)
+
+// Mask 屏蔽类敏感词的替换文本
+const Mask = "***"
+
+// Category 词库中的一个分类
+type Category struct {
+	Policy string   `mapstructure:"policy"`
+	Words  []string `mapstructure:"words"`
+}
+
+// Dict 敏感词词库
+type Dict struct {
+	Categories map[string]Category `mapstructure:"categories"`
+}
+
+// Hit 一次命中
+type Hit struct {
+	Word     string // 词库中的原词
+	Category string // 所属分类
+	Policy   string // 分类的处理策略
+}
+
+// Result 文本检查结果
+type Result struct {
+	Text     string // 屏蔽类敏感词替换为***后的文本
+	Rejected bool   // 命中拒绝类敏感词
+	Review   bool   // 命中审核类敏感词
+	Hits     []Hit  // 命中的敏感词(去重)
+}
+
+// Filter 敏感词过滤器，构建后只读，可并发使用
+type Filter struct {
+	ac    *automaton
+	words []Hit
+}
+
+// policyStrictness 处理策略的严格程度，数值越大越严格
+var policyStrictness = map[string]int{
+	PolicyMask:   1,
+	PolicyReview: 2,
+	PolicyReject: 3,
+}
+
+// NewFilter 根据词库构建过滤器，策略无效的分类返回错误
+func NewFilter(dict Dict) (*Filter, error) {
+	f := &Filter{}
+	var patterns [][]rune
+	names := make([]string, 0, len(dict.Categories))
+	for name := range dict.Categories {
+		names = append(names, name)
+	}
+	// 同一个词出现在多个分类时使用最严格的策略(拒绝 > 审核 > 屏蔽)，
+	// 策略相同时按分类名排序后取第一个，保证每次加载结果一致
+	sort.Strings(names)
+	index := make(map[string]int)
+	for _, name := range names {
+		c := dict.Categories[name]
+		if _, ok := policyStrictness[c.Policy]; !ok {
+			return nil, fmt.Errorf("sensitive: invalid policy %q for category %q", c.Policy, name)
+		}
+		for _, w := range c.Words {
+			pattern := normalize(w)
+			hit := Hit{Word: strings.TrimSpace(w), Category: name, Policy: c.Policy}
+			if i, ok := index[string(pattern)]; ok {
+				if policyStrictness[c.Policy] > policyStrictness[f.words[i].Policy] {
+					f.words[i] = hit
+				}
+				continue
+			}
+			index[string(pattern)] = len(patterns)
+			patterns = append(patterns, pattern)
+			f.words = append(f.words, hit)
+		}
+	}
+	f.ac = newAutomaton(patterns)
+	return f, nil
+}
+
+// Check 检查文本，命中多个分类时分别按各自的策略处理
+func (f *Filter) Check(text string) Result {
+	result := Result{Text: text}
+	runes := []rune(text)
+	spans := f.ac.match(runes)
+	if len(spans) == 0 {
+		return result
+	}
+
+	seen := make(map[int]bool, len(spans))
+	var masks []span
+	for _, s := range spans {
+		hit := f.words[s.word]
+		switch hit.Policy {
+		case PolicyReject:
+			result.Rejected = true
+		case PolicyReview:
+			result.Review = true
+		case PolicyMask:
+			masks = append(masks, s)
+		}
+		if !seen[s.word] {
+			seen[s.word] = true
+			result.Hits = append(result.Hits, hit)
+		}
+	}
+	if len(masks) > 0 {
+		result.Text = maskSpans(runes, masks)
+	}
+	return result
+}
+
+// maskSpans 将命中位置替换为***，重叠或相邻的命中合并为一处
+func maskSpans(runes []rune, spans []span) string {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(spans); {
+		start, end := spans[i].start, spans[i].end
+		for i++; i < len(spans) && spans[i].start <= end; i++ {
+			end = max(end, spans[i].end)
+		}
+		b.WriteString(string(runes[last:start]))
+		b.WriteString(Mask)
+		last = end
+	}
+	b.WriteString(string(runes[last:]))
+	return b.String()
+}
+
+// current 当前生效的过滤器，词库热加载时整体替换
+var current atomic.Pointer[Filter]
+
+func init() {
+	current.Store(&Filter{ac: newAutomaton(nil)})
+}
+
+// Init 加载敏感词词库并监听文件变化，词库修改后自动重新加载
+// 重新加载失败时继续使用旧词库
+func Init(dictFile string) error {
+	v := viper.New()
+	v.SetConfigFile(dictFile)
+	if err := v.ReadInConfig(); err != nil {
+		return err
+	}
+	if err := load(v); err != nil {
+		return err
+	}
+	//词库热加载
+	v.WatchConfig()
+	v.OnConfigChange(func(in fsnotify.Event) {
+		if err := load(v); err != nil {
+			zap.L().Error("reload sensitive dict failed", zap.String("file", in.Name), zap.Error(err))
+			return
+		}
+		zap.L().Info("敏感词词库已重新加载", zap.String("file", in.Name))
+	})
+	return nil
+}
+
+// load 从viper中解析词库并替换当前过滤器
+func load(v *viper.Viper) error {
+	var dict Dict
+	if err := v.Unmarshal(&dict); err != nil {
+		return err
+	}
+	f, err := NewFilter(dict)
+	if err != nil {
+		return err
+	}
+	current.Store(f)
+	return nil
+}
+
+// Check 使用当前词库检查文本
+func Check(text string) Result {
+	return current.Load().Check(text)
+}
@@ -54,6 +54,9 @@ func Init() *gin.Engine {
 	v1.POST("/register", controllers.RegisterHandler) //注册业务路由
 	v1.POST("/login", controllers.LoginHandler)       //登录业务路由
 
+	// 订阅源凭订阅令牌免登录访问
+	v1.GET("/feeds/:token/:format", controllers.GetUserFeedHandler)
+
 	// 初始化控制器
 	messageCtrl := controllers.NewMessageController(messageDao, mysqlMessageDao)
 	uploadCtrl := controllers.NewUploadController()
@@ -65,14 +68,36 @@ func Init() *gin.Engine {
 		v1.PUT("/user/update_password", controllers.UpdatePasswordHandler) //更新密码
 		v1.PUT("/user/update_info", controllers.UpdateUserInfoHandler)     //更新用户信息
 		v1.POST("/user/upload_avatar", controllers.UploadAvatarHandler)    //上传头像
+		v1.GET("/user/settings", controllers.GetUserSettingHandler)        //获取用户设置
+		v1.PUT("/user/settings", controllers.UpdateUserSettingHandler)     //更新用户设置
+		v1.GET("/user/feed-token", controllers.GetFeedTokenHandler)        //获取订阅地址
+		v1.POST("/user/feed-token", controllers.ResetFeedTokenHandler)     //生成订阅地址
+		v1.DELETE("/user/feed-token", controllers.RevokeFeedTokenHandler)  //关闭订阅地址
+
+		// 用户搜索与黑名单相关路由
+		v1.GET("/users/search", controllers.SearchUsersHandler)   //全站搜索用户
+		v1.GET("/blocks", controllers.GetBlockListHandler)        //黑名单列表
+		v1.POST("/blocks/:uid", controllers.BlockUserHandler)     //拉黑用户
+		v1.DELETE("/blocks/:uid", controllers.UnblockUserHandler) //取消拉黑
 
 		// 好友相关路由
-		v1.POST("/friends/:friendID", controllers.AddFriendHandler)      //添加好友
-		v1.GET("/friends", controllers.GetFriendListHandler)             //好友列表
-		v1.GET("/friends/:friendID", controllers.GetFriendDetailHandler) //获取好友详情信息
-		v1.GET("/friends/search", controllers.SearchFriendHandler)       //搜索好友
-		v1.PUT("/friends/", controllers.UpdateFriendRemarkHandler)       //更新好友备注
-		v1.DELETE("/friends", controllers.DeleteFriendHandler)           //删除好友
+		v1.POST("/friends/:friendID", controllers.AddFriendHandler)           //添加好友
+		v1.GET("/friends", controllers.GetFriendListHandler)                  //好友列表
+		v1.GET("/friends/:friendID", controllers.GetFriendDetailHandler)      //获取好友详情信息
+		v1.GET("/friends/search", controllers.SearchFriendHandler)            //搜索好友
+		v1.GET("/friends/birthdays", controllers.GetUpcomingBirthdaysHandler) //好友生日提醒
+		v1.PUT("/friends/", controllers.UpdateFriendRemarkHandler)            //更新好友备注
+		v1.DELETE("/friends", controllers.DeleteFriendHandler)                //删除好友
+
+		// 关注相关路由
+		v1.POST("/follows/:uid", controllers.FollowHandler)                         //关注用户
+		v1.DELETE("/follows/:uid", controllers.UnfollowHandler)                     //取消关注
+		v1.GET("/follows/count", controllers.GetFollowCountHandler)                 //关注数与粉丝数
+		v1.GET("/follows/requests", controllers.GetFollowRequestsHandler)           //关注申请列表
+		v1.PUT("/follows/requests/:uid", controllers.AcceptFollowRequestHandler)    //通过关注申请
+		v1.DELETE("/follows/requests/:uid", controllers.RejectFollowRequestHandler) //拒绝关注申请
+		v1.GET("/followers", controllers.GetFollowersHandler)                       //粉丝列表
+		v1.GET("/following", controllers.GetFollowingHandler)                       //关注列表
 
 		// 消息相关路由
 		v1.POST("/messages", messageCtrl.SendMessageHandler)            //发送文本消息
@@ -85,11 +110,92 @@ func Init() *gin.Engine {
 		v1.POST("/upload", uploadCtrl.UploadFileHandler) // 文件上传
 
 		// 动态相关路由
-		v1.POST("/posts", controllers.CreatePostHandler)                //用户创建动态
-		v1.GET("/posts", controllers.GetFriendPostsHandler)             //获取所有好友动态列表(个人空间)
-		v1.GET("/posts/:uid", controllers.GetUserPostsHandler)          //获取指定用户动态列表
-		v1.PUT("/posts/:id/view", controllers.IncrementPostViewHandler) //增加动态浏览量
-		v1.DELETE("/posts/:id", controllers.DeletePostHandler)          //用户删除动态
+		v1.POST("/posts", controllers.CreatePostHandler)                         //用户创建动态
+		v1.GET("/posts", controllers.GetFriendPostsHandler)                      //获取所有好友动态列表(个人空间)
+		v1.GET("/posts/:id", controllers.GetUserPostsHandler)                    //获取指定用户动态列表(:id为用户ID)
+		v1.PUT("/posts/:id/view", controllers.IncrementPostViewHandler)          //增加动态浏览量
+		v1.GET("/posts/:id/visitors", controllers.GetPostVisitorsHandler)        //单条动态访客列表
+		v1.GET("/posts/visitors", controllers.GetMyPostVisitorsHandler)          //谁看过我的动态
+		v1.DELETE("/posts/:id", controllers.DeletePostHandler)                   //用户删除动态(进入回收站)
+		v1.PUT("/posts/:id", controllers.EditPostHandler)                        //编辑动态
+		v1.GET("/posts/:id/history", controllers.GetPostEditHistoryHandler)      //动态编辑历史
+		v1.GET("/posts/trash", controllers.GetTrashPostsHandler)                 //回收站动态列表
+		v1.POST("/posts/:id/restore", controllers.RestorePostHandler)            //从回收站恢复动态
+		v1.DELETE("/posts/:id/purge", controllers.PurgePostHandler)              //彻底删除动态
+		v1.PUT("/posts/:id/visibility", controllers.UpdatePostVisibilityHandler) //修改动态可见范围
+		v1.POST("/posts/:id/like", controllers.LikePostHandler)                  //点赞动态
+		v1.DELETE("/posts/:id/like", controllers.UnlikePostHandler)              //取消点赞
+		v1.GET("/posts/:id/likes", controllers.GetPostLikersHandler)             //点赞用户列表
+		v1.POST("/posts/:id/repost", controllers.RepostPostHandler)              //转发动态
+		v1.POST("/posts/:id/poll/votes", controllers.VotePollHandler)            //投票
+		v1.GET("/posts/:id/poll/voters", controllers.GetPollVotersHandler)       //投票人列表
+		v1.POST("/posts/:id/bookmark", controllers.BookmarkPostHandler)          //收藏动态
+		v1.DELETE("/posts/:id/bookmark", controllers.UnbookmarkPostHandler)      //取消收藏
+
+		// 收藏与收藏夹相关路由
+		v1.GET("/bookmarks", controllers.GetBookmarksHandler)              //收藏列表
+		v1.PUT("/bookmarks/:id", controllers.MoveBookmarkHandler)          //移动收藏(:id为动态ID)
+		v1.POST("/collections", controllers.CreateCollectionHandler)       //创建收藏夹
+		v1.GET("/collections", controllers.GetCollectionsHandler)          //收藏夹列表
+		v1.PUT("/collections/:id", controllers.RenameCollectionHandler)    //重命名收藏夹
+		v1.DELETE("/collections/:id", controllers.DeleteCollectionHandler) //删除收藏夹
+
+		// 相册相关路由
+		v1.POST("/albums", controllers.CreateAlbumHandler)              //创建相册
+		v1.GET("/albums", controllers.GetAlbumsHandler)                 //相册列表(含动态相册)
+		v1.GET("/albums/:id", controllers.GetAlbumHandler)              //相册详情
+		v1.PUT("/albums/:id", controllers.UpdateAlbumHandler)           //修改相册名称、描述、可见范围与封面
+		v1.DELETE("/albums/:id", controllers.DeleteAlbumHandler)        //删除相册
+		v1.POST("/albums/:id/photos", controllers.UploadPhotosHandler)  //上传照片
+		v1.GET("/albums/:id/photos", controllers.GetAlbumPhotosHandler) //相册照片列表
+		v1.GET("/albums/post/photos", controllers.GetPostPhotosHandler) //动态相册照片列表
+		v1.GET("/photos/:id", controllers.GetPhotoHandler)              //照片详情(计浏览量)
+		v1.PUT("/photos/:id", controllers.UpdatePhotoCaptionHandler)    //修改照片说明
+		v1.PUT("/photos/move", controllers.MovePhotosHandler)           //移动照片到其他相册
+		v1.DELETE("/photos/:id", controllers.DeletePhotoHandler)        //删除照片
+
+		// 草稿与定时发布相关路由
+		v1.POST("/drafts", controllers.CreateDraftHandler)                            //保存草稿
+		v1.GET("/drafts", controllers.GetDraftsHandler)                               //草稿箱
+		v1.GET("/drafts/:id", controllers.GetDraftHandler)                            //草稿详情
+		v1.PUT("/drafts/:id", controllers.UpdateDraftHandler)                         //修改草稿
+		v1.DELETE("/drafts/:id", controllers.DeleteDraftHandler)                      //删除草稿
+		v1.POST("/drafts/:id/images", controllers.AddDraftImagesHandler)              //草稿追加图片
+		v1.DELETE("/drafts/:id/images/:imageID", controllers.DeleteDraftImageHandler) //删除草稿图片
+		v1.POST("/drafts/:id/publish", controllers.PublishDraftHandler)               //立即发布草稿
+		v1.PUT("/drafts/:id/schedule", controllers.ScheduleDraftHandler)              //设置或修改定时发布
+		v1.DELETE("/drafts/:id/schedule", controllers.CancelDraftScheduleHandler)     //取消定时发布
+
+		// 好友分组相关路由
+		v1.POST("/friend-groups", controllers.CreateFriendGroupHandler)       //创建好友分组
+		v1.GET("/friend-groups", controllers.GetFriendGroupsHandler)          //好友分组列表
+		v1.PUT("/friend-groups/:id", controllers.UpdateFriendGroupHandler)    //修改好友分组
+		v1.DELETE("/friend-groups/:id", controllers.DeleteFriendGroupHandler) //删除好友分组
+
+		// 评论相关路由
+		v1.POST("/posts/:id/comments", controllers.CreateCommentHandler)  //发表评论
+		v1.GET("/posts/:id/comments", controllers.GetPostCommentsHandler) //评论列表
+		v1.DELETE("/comments/:id", controllers.DeleteCommentHandler)      //删除评论
+
+		// 话题相关路由
+		v1.GET("/topics/:name/posts", controllers.GetTopicPostsHandler) //话题动态列表
+
+		// 通知相关路由
+		v1.GET("/notifications", controllers.GetNotificationsHandler)              //通知列表
+		v1.GET("/notifications/unread", controllers.GetUnreadNotificationsHandler) //未读通知数
+		v1.PUT("/notifications/read", controllers.MarkNotificationsReadHandler)    //全部标记为已读
+		v1.GET("/memories", controllers.GetMemoriesHandler)                        //那年今日
+
+		// 举报路由
+		v1.POST("/reports", controllers.CreateReportHandler) //举报动态、私信或账号
+
+		// 管理员审核路由
+		admin := v1.Group("/admin", middlewares.AdminMiddleware())
+		admin.GET("/cases", controllers.GetModerationCasesHandler)                 //审核队列
+		admin.GET("/cases/:id", controllers.GetModerationCaseHandler)              //审核工单详情
+		admin.PUT("/cases/:id/claim", controllers.ClaimModerationCaseHandler)      //认领审核工单
+		admin.POST("/cases/:id/decision", controllers.DecideModerationCaseHandler) //处理审核工单
+		admin.GET("/audit-logs", controllers.GetModerationLogsHandler)             //审核日志
 	}
 
 	// 添加Swagger路由
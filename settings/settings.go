@@ -11,15 +11,17 @@ import (
 var Conf = new(AppConfig)
 
 type AppConfig struct {
-	Name         string `mapstructure:"name"`
-	Mode         string `mapstructure:"mode"`
-	Version      string `mapstructure:"version"`
-	StartTime    string `mapstructure:"start_time"`
-	MachineID    uint16 `mapstructure:"machine_id"`
-	Port         int    `mapstructure:"port"`
-	*LogConfig   `mapstructure:"log"`
-	*MySQLConfig `mapstructure:"mysql"`
-	*RedisConfig `mapstructure:"redis"`
+	Name             string `mapstructure:"name"`
+	Mode             string `mapstructure:"mode"`
+	Version          string `mapstructure:"version"`
+	StartTime        string `mapstructure:"start_time"`
+	MachineID        uint16 `mapstructure:"machine_id"`
+	Port             int    `mapstructure:"port"`
+	*LogConfig       `mapstructure:"log"`
+	*MySQLConfig     `mapstructure:"mysql"`
+	*RedisConfig     `mapstructure:"redis"`
+	*SensitiveConfig `mapstructure:"sensitive"`
+	*FeedConfig      `mapstructure:"feed"`
 }
 
 type LogConfig struct {
@@ -48,6 +50,15 @@ type RedisConfig struct {
 	PoolSize int    `mapstructure:"pool_size"`
 }
 
+type SensitiveConfig struct {
+	DictFile string `mapstructure:"dict_file"`
+}
+
+// FeedConfig 订阅源配置，BaseURL为站点的外部访问地址(如https://example.com)，为空时不提供订阅源
+type FeedConfig struct {
+	BaseURL string `mapstructure:"base_url"`
+}
+
 func Init() (err error) {
 	//方式1：直接指定文件路径(相对路径或者绝对路径)
 	//viper.SetConfigFile("./conf/config.yaml") // ---相对路径，一般项目使用较多
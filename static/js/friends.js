@@ -191,16 +191,24 @@ new Vue({
             });
         },
 
-        // 加载好友列表
-        loadFriendList() {
+        // 加载好友列表(按游标逐页加载)
+        loadFriendList(cursor) {
+            if (!cursor) {
+                this.friends = [];
+            }
             axios.get('/api/v1/friends', {
+                params: {
+                    cursor: cursor || '',
+                    limit: 100
+                },
                 headers: {
                     'Authorization': 'Bearer ' + localStorage.getItem('token')
                 }
             })
                 .then(response => {
                     if (response.data && response.data.data) {
-                        this.friends = response.data.data.map(friend => {
+                        const page = response.data.data;
+                        this.friends = this.friends.concat((page.friends || []).map(friend => {
                             return {
                                 id: String(friend.friend_id || friend.id),
                                 nickname: friend.display_name || friend.nickname,
@@ -209,7 +217,10 @@ new Vue({
                                 lastMessage: '最近聊天',
                                 lastTime: this.formatTime(friend.last_interact_at)
                             };
-                        });
+                        }));
+                        if (page.next_cursor) {
+                            this.loadFriendList(page.next_cursor);
+                        }
                     } else {
                         console.error('好友列表数据格式不正确:', response);
                     }